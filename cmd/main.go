@@ -2,15 +2,30 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/config"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/memory"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
 	repoImpl "github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/notify"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/oidc"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/lifecycle"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/authn"
+	grpcserver "github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/grpc/server"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/handler"
+	authmw "github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/middleware"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/router"
 )
 
@@ -25,39 +40,112 @@ func main() {
 	cfg := config.Load()
 	log.Info("Starting service", "env", cfg.Env, "address", cfg.HTTPServer.Address)
 
-	// init postgres connection pool with retry logic
-	pg, err := postgres.New(ctx, cfg.PG.URL)
+	// components are stopped in reverse order of registration on SIGTERM
+	lc := lifecycle.New(cfg.Shutdown.Timeout, cfg.Shutdown.Delay)
+
+	// init repositories of the configured storage (DAL)
+	store, err := newStorage(ctx, cfg)
 	if err != nil {
-		log.Error("Failed to init postgres", "error", err)
+		log.Error("Failed to init storage", "storage", cfg.Storage, "error", err)
 		os.Exit(1)
 	}
-	defer pg.Close() // close pool when main exits
-	log.Info("Successfully connected to PostgreSQL")
-
-	// init transaction manager (implements the Transactor interface)
-	trm := postgres.NewTransactionManager(pg.Pool)
-
-	// init concrete repository implementations (DAL)
-	teamRepo := repoImpl.NewTeamRepository(trm)
-	userRepo := repoImpl.NewUserRepository(trm)
-	prRepo := repoImpl.NewPRRepository(trm)
+	// the pool is closed last, after everything that uses it is stopped
+	lc.OnStop("storage", func(context.Context) error {
+		store.close()
+		return nil
+	})
+	trm, teamRepo, userRepo, prRepo := store.transactor, store.teams, store.users, store.prs
+	slaRepo, tokenRepo, idempotencyRepo := store.sla, store.tokens, store.idempotency
 
 	// init domain services and use cases (business logic)
 	assigner := services.NewAssigner()
+	authz := services.NewAuthorizer(userRepo)
+
+	// init reviewer notifier (no-op unless a webhook is configured)
+	var notifier services.Notifier = services.NopNotifier{}
+	if cfg.Notify.Enabled() {
+		webhook, err := notify.NewWebhookNotifier(
+			cfg.Notify.Webhooks, cfg.Notify.DefaultWebhook, cfg.Notify.Mentions, cfg.Notify.Template, cfg.Notify.ReminderTemplate, cfg.Notify.Timeout)
+		if err != nil {
+			log.Error("Failed to init notifier", "error", err)
+			os.Exit(1)
+		}
+		// stopped after the servers and the sla scheduler, so deliveries of their last calls finish
+		async := services.NewAsyncNotifier(webhook)
+		lc.OnStop("notifications", async.Drain)
+		notifier = async
+	}
+
+	// in-process bus feeding the live event stream
+	eventBus := services.NewEventBus(cfg.Events.History)
 
 	// use cases are injected with required repositories and the transactor
-	teamService := services.NewTeamUseCase(teamRepo, trm)
+	teamService := services.NewTeamUseCase(teamRepo, userRepo, trm, authz)
 	// userService doesn't require trm if SetIsActive is not transactional
-	userService := services.NewUserUseCase(userRepo, prRepo)
-	prService := services.NewPRUseCase(prRepo, userRepo, trm, assigner)
+	userService := services.NewUserUseCase(userRepo, prRepo, authz)
+	prService := services.NewPRUseCase(prRepo, userRepo, teamRepo, trm, assigner, notifier, eventBus, authz)
+	tokenService := services.NewTokenUseCase(tokenRepo, userRepo, cfg.Auth.BootstrapToken)
+	eventService := services.NewEventUseCase(eventBus, userRepo)
+	if !cfg.Auth.Enabled {
+		log.Warn("API authentication is disabled")
+	}
+
+	// start review sla scheduler in background
+	if cfg.SLA.Enabled {
+		slaScheduler := services.NewSLAScheduler(slaRepo, prRepo, trm, prService, notifier, cfg.SLA.ScanInterval)
+		lc.Go("sla scheduler", slaScheduler.Run)
+		log.Info("SLA scheduler started", "interval", cfg.SLA.ScanInterval)
+	}
+
+	// expired idempotency keys are deleted in background
+	sweeper := services.NewIdempotencySweeper(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.SweepInterval)
+	lc.Go("idempotency sweeper", sweeper.Run)
 
 	// init http handlers (transport layer)
 	teamHandler := handler.NewTeamHandler(teamService)
 	userHandler := handler.NewUserHandler(userService)
 	prHandler := handler.NewPRHandler(prService)
+	tokenHandler := handler.NewTokenHandler(tokenService)
+	eventHandler := handler.NewEventHandler(eventService, cfg.Events.Heartbeat)
+
+	// accept sso jwts next to api tokens when a jwks source is configured
+	var authenticator authn.TokenAuthenticator = tokenService
+	if cfg.OIDC.Enabled() {
+		verifier, err := newJWTVerifier(cfg.OIDC)
+		if err != nil {
+			log.Error("Failed to init JWT verifier", "error", err)
+			os.Exit(1)
+		}
+		authenticator = authn.WithJWT(verifier, tokenService)
+		log.Info("JWT authentication enabled", "issuer", cfg.OIDC.Issuer)
+	}
 
 	// init chi router with handlers and middleware
-	r := router.NewRouter(teamHandler, userHandler, prHandler)
+	authenticate := authmw.Authenticate(authenticator, cfg.Auth.Enabled)
+	idempotent := authmw.Idempotency(idempotencyRepo, cfg.Idempotency.TTL)
+	r := router.NewRouter(teamHandler, userHandler, prHandler, tokenHandler, eventHandler, lc.Ready, authenticate, idempotent)
+
+	// start grpc server in background, it shares use cases and authentication with http
+	if cfg.GRPCServer.Address != "" {
+		lis, err := net.Listen("tcp", cfg.GRPCServer.Address)
+		if err != nil {
+			log.Error("gRPC server failed to listen", "addr", cfg.GRPCServer.Address, "error", err)
+			os.Exit(1)
+		}
+		healthSrv := health.NewServer()
+		grpcSrv := grpcserver.NewServer(
+			grpcserver.NewTeamServer(teamService),
+			grpcserver.NewUserServer(userService),
+			grpcserver.NewPRServer(prService),
+			grpcserver.Authenticate(authenticator, cfg.Auth.Enabled),
+			healthSrv,
+		)
+		lc.OnNotReady(healthSrv.Shutdown)
+		lc.Serve("grpc server", func() error { return grpcSrv.Serve(lis) }, func(ctx context.Context) error {
+			return stopGRPC(ctx, grpcSrv)
+		})
+		log.Info("gRPC server started", "addr", cfg.GRPCServer.Address)
+	}
 
 	// configure http server
 	srv := &http.Server{
@@ -68,10 +156,125 @@ func main() {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
-	// start http server and block
-	log.Info("HTTP server starting", "addr", cfg.HTTPServer.Address)
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Error("HTTP server failed to start", "error", err)
-		return
+	// start http server in background
+	lis, err := net.Listen("tcp", cfg.HTTPServer.Address)
+	if err != nil {
+		log.Error("HTTP server failed to listen", "addr", cfg.HTTPServer.Address, "error", err)
+		os.Exit(1)
+	}
+	lc.Serve("http server", func() error {
+		if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		if err := srv.Shutdown(ctx); err != nil {
+			// cut the requests still running at the deadline
+			srv.Close()
+			return err
+		}
+		return nil
+	})
+	// event streams never finish on their own, they are ended before the http drain
+	lc.OnStop("event streams", func(context.Context) error {
+		eventBus.Close()
+		return nil
+	})
+	log.Info("HTTP server started", "addr", cfg.HTTPServer.Address)
+
+	// block until SIGTERM and stop everything in order
+	lc.MarkReady()
+	if err := lc.Wait(ctx); err != nil {
+		log.Error("Shutdown failed", "error", err)
+		os.Exit(1)
 	}
+	log.Info("Service stopped")
+}
+
+// stopGRPC waits for running calls to finish and cancels them at the deadline
+func stopGRPC(ctx context.Context, srv *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		srv.Stop()
+		return ctx.Err()
+	}
+}
+
+// storage bundles the repositories of one backend
+type storage struct {
+	transactor  repository.Transactor
+	teams       repository.TeamRepository
+	users       repository.UserRepository
+	prs         repository.PRRepository
+	sla         repository.SLARepository
+	tokens      repository.TokenRepository
+	idempotency repository.IdempotencyRepository
+	close       func()
+}
+
+// newStorage connects to postgres or creates an empty in-memory store
+func newStorage(ctx context.Context, cfg *config.Config) (*storage, error) {
+	if cfg.Storage == config.StorageMemory {
+		slog.Warn("Using in-memory storage, data is lost on restart")
+		store := memory.NewStore()
+		return &storage{
+			transactor:  store,
+			teams:       memory.NewTeamRepository(store),
+			users:       memory.NewUserRepository(store),
+			prs:         memory.NewPRRepository(store),
+			sla:         memory.NewSLARepository(store),
+			tokens:      memory.NewTokenRepository(store),
+			idempotency: memory.NewIdempotencyRepository(store),
+			close:       func() {},
+		}, nil
+	}
+
+	// init postgres connection pool with retry logic
+	pg, err := postgres.New(ctx, cfg.PG.URL)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Successfully connected to PostgreSQL")
+
+	// init transaction manager (implements the Transactor interface)
+	trm := postgres.NewTransactionManager(pg.Pool)
+	return &storage{
+		transactor:  trm,
+		teams:       repoImpl.NewTeamRepository(trm),
+		users:       repoImpl.NewUserRepository(trm),
+		prs:         repoImpl.NewPRRepository(trm),
+		sla:         repoImpl.NewSLARepository(trm),
+		tokens:      repoImpl.NewTokenRepository(trm),
+		idempotency: repoImpl.NewIdempotencyRepository(trm),
+		close:       pg.Close,
+	}, nil
+}
+
+// newJWTVerifier builds the sso token verifier from a jwks file or url
+func newJWTVerifier(cfg config.OIDC) (*oidc.Verifier, error) {
+	var keys oidc.KeySource
+	if cfg.JWKSFile != "" {
+		set, err := oidc.LoadKeySetFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = set
+	} else {
+		keys = oidc.NewRemoteKeySet(cfg.JWKSURL, cfg.JWKSRefresh, 5*time.Second)
+	}
+
+	scopes := make([]entity.Scope, len(cfg.Scopes))
+	for i, s := range cfg.Scopes {
+		scopes[i] = entity.Scope(s)
+	}
+
+	return oidc.NewVerifier(keys, cfg.Issuer, cfg.Audience, cfg.UserClaim, cfg.OrgClaim, scopes, cfg.Leeway)
 }
@@ -0,0 +1,179 @@
+// Command prctl is an operator cli of the pr reviewer assignment service built on pkg/client
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/pkg/client"
+)
+
+const usage = `prctl manages teams, users and pull requests of the reviewer assignment service
+
+Usage:
+  prctl team add -f team.yaml        create a team with its members
+  prctl team get <team>              show a team
+  prctl user activate <user>         mark a user active
+  prctl user deactivate <user>       stop assigning reviews to a user
+  prctl pr create --id <pr> --name <name> --author <user> [--draft]
+  prctl pr get <pr>                  show a pull request with its reviews
+  prctl pr list [--status S] [--author U] [--reviewer U] [--team T] [--limit N]
+  prctl pr merge <pr> [--force] [--version N]
+  prctl pr reassign <pr> <old-reviewer> [--version N]
+  prctl reviews <user> [--pending] [--status S]
+
+Common flags, also read from PRCTL_SERVER, PRCTL_TOKEN and PRCTL_ORG:
+  --server URL     service address (default http://localhost:8080)
+  --token TOKEN    api token
+  --org ORG        organization for tokens not bound to one
+  -o table|json    output format (default table)
+  --timeout D      request timeout (default 30s)
+`
+
+// exit codes
+const (
+	exitOK    = 0
+	exitError = 1 // the call failed
+	exitUsage = 2 // bad arguments
+)
+
+// errUsage marks argument errors, the usage is printed for them
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// command runs one subcommand with its arguments
+type command func(ctx context.Context, env *env, args []string) error
+
+// commands maps "group verb" and single word commands to their implementation
+var commands = map[string]command{
+	"team add":        teamAdd,
+	"team get":        teamGet,
+	"user activate":   userSetActive(true),
+	"user deactivate": userSetActive(false),
+	"pr create":       prCreate,
+	"pr get":          prGet,
+	"pr list":         prList,
+	"pr merge":        prMerge,
+	"pr reassign":     prReassign,
+	"reviews":         reviews,
+}
+
+// run executes the cli and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	name, cmd, rest := lookup(args)
+	if cmd == nil {
+		fmt.Fprintf(stderr, "prctl: unknown command %q\n\n%s", strings.Join(args[:min(2, len(args))], " "), usage)
+		return exitUsage
+	}
+
+	e := &env{stdout: stdout, stderr: stderr}
+	if err := cmd(ctx, e, rest); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "prctl %s: %v\n\n%s", name, err, usage)
+			return exitUsage
+		}
+		fmt.Fprintf(stderr, "prctl %s: %v\n", name, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// lookup finds the command named by the first one or two arguments
+func lookup(args []string) (string, command, []string) {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return args[0] + " " + args[1], cmd, args[2:]
+		}
+	}
+	if cmd, ok := commands[args[0]]; ok {
+		return args[0], cmd, args[1:]
+	}
+	return "", nil, nil
+}
+
+// env holds the common flags and the output of a command
+type env struct {
+	stdout, stderr io.Writer
+
+	server  string
+	token   string
+	org     string
+	output  string
+	timeout time.Duration
+}
+
+// flags creates the flag set of a command with the common flags registered
+func (e *env) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard) // errors are reported by run
+	fs.StringVar(&e.server, "server", envOr("PRCTL_SERVER", "http://localhost:8080"), "service address")
+	fs.StringVar(&e.token, "token", os.Getenv("PRCTL_TOKEN"), "api token")
+	fs.StringVar(&e.org, "org", os.Getenv("PRCTL_ORG"), "organization")
+	fs.StringVar(&e.output, "o", "table", "output format: table or json")
+	fs.DurationVar(&e.timeout, "timeout", 30*time.Second, "request timeout")
+	return fs
+}
+
+// parse parses flags placed before and after positional arguments,
+// it checks the number of positional arguments and the output format
+func (e *env) parse(fs *flag.FlagSet, args []string, positional ...string) ([]string, error) {
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		rest = append(rest, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	switch {
+	case len(rest) == len(positional):
+	case len(positional) == 0:
+		return nil, fmt.Errorf("%w: unexpected argument %q", errUsage, rest[0])
+	default:
+		return nil, fmt.Errorf("%w: expected arguments <%s>", errUsage, strings.Join(positional, "> <"))
+	}
+	if e.output != "table" && e.output != "json" {
+		return nil, fmt.Errorf("%w: -o must be table or json", errUsage)
+	}
+	return rest, nil
+}
+
+// client creates the api client from the common flags
+func (e *env) client() (*client.Client, error) {
+	return client.New(e.server,
+		client.WithToken(e.token),
+		client.WithOrg(e.org),
+		client.WithHTTPClient(&http.Client{Timeout: e.timeout}),
+	)
+}
+
+func envOr(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
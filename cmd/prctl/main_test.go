@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/testutil"
+)
+
+const bootstrapToken = testutil.BootstrapToken
+
+const teamYAML = `team_name: backend
+members:
+  - user_id: u1
+    username: Alice
+    role: ADMIN
+  - user_id: u2
+    username: Bob
+  - user_id: u3
+    username: Carol
+  - user_id: u4
+    username: Dave
+    is_active: false
+`
+
+// newServer starts the service on the in-memory storage and points prctl at it
+func newServer(t *testing.T) {
+	t.Helper()
+
+	srv := httptest.NewServer(testutil.NewService().Router())
+	t.Cleanup(srv.Close)
+
+	t.Setenv("PRCTL_SERVER", srv.URL)
+	t.Setenv("PRCTL_TOKEN", bootstrapToken)
+}
+
+// prctl runs the cli and returns its exit code and output
+func prctl(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestPrctl(t *testing.T) {
+	newServer(t)
+
+	teamPath := filepath.Join(t.TempDir(), "team.yaml")
+	if err := os.WriteFile(teamPath, []byte(teamYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, errOut := prctl(t, "team", "add", "-f", teamPath)
+	if code != exitOK {
+		t.Fatalf("team add: exit %d: %s", code, errOut)
+	}
+	if !strings.Contains(out, "TEAM backend") || !strings.Contains(out, "u4  ") {
+		t.Fatalf("team add output:\n%s", out)
+	}
+
+	code, out, errOut = prctl(t, "user", "deactivate", "u3", "-o", "json")
+	if code != exitOK {
+		t.Fatalf("user deactivate: exit %d: %s", code, errOut)
+	}
+	var user struct {
+		UserID   string `json:"user_id"`
+		IsActive bool   `json:"is_active"`
+	}
+	if err := json.Unmarshal([]byte(out), &user); err != nil || user.UserID != "u3" || user.IsActive {
+		t.Fatalf("user deactivate json = %s, %v", out, err)
+	}
+
+	// u2 is the only active member besides the author
+	code, out, errOut = prctl(t, "pr", "create", "--id", "pr-1", "--name", "Add search", "--author", "u1", "-o", "json")
+	if code != exitOK {
+		t.Fatalf("pr create: exit %d: %s", code, errOut)
+	}
+	var pr struct {
+		AssignedReviewers []string `json:"assigned_reviewers"`
+		Version           int      `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(out), &pr); err != nil || len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u2" {
+		t.Fatalf("pr create json = %s, %v", out, err)
+	}
+
+	code, out, errOut = prctl(t, "reviews", "u2")
+	if code != exitOK || !strings.Contains(out, "pr-1") {
+		t.Fatalf("reviews: exit %d:\n%s%s", code, out, errOut)
+	}
+
+	code, _, errOut = prctl(t, "pr", "reassign", "pr-1", "u2")
+	if code != exitError || !strings.Contains(errOut, "NO_CANDIDATE") {
+		t.Fatalf("pr reassign: exit %d: %s", code, errOut)
+	}
+
+	code, out, errOut = prctl(t, "pr", "merge", "pr-1", "--force", "--version", "1")
+	if code != exitOK || !strings.Contains(out, "MERGED") {
+		t.Fatalf("pr merge: exit %d:\n%s%s", code, out, errOut)
+	}
+
+	code, out, errOut = prctl(t, "pr", "list", "--status", "merged")
+	if code != exitOK || !strings.Contains(out, "pr-1") {
+		t.Fatalf("pr list: exit %d:\n%s%s", code, out, errOut)
+	}
+
+	code, _, errOut = prctl(t, "pr", "reassign", "pr-1", "u2")
+	if code != exitError || !strings.Contains(errOut, "PR_MERGED") {
+		t.Fatalf("pr reassign after merge: exit %d: %s", code, errOut)
+	}
+}
+
+func TestPrctlUsage(t *testing.T) {
+	t.Setenv("PRCTL_SERVER", "http://127.0.0.1:1")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "unknown command", args: []string{"pr", "delete"}, want: "unknown command"},
+		{name: "missing argument", args: []string{"pr", "merge"}, want: "expected arguments <pr>"},
+		{name: "extra argument", args: []string{"team", "add", "-f", "t.yaml", "extra"}, want: "unexpected argument"},
+		{name: "missing file flag", args: []string{"team", "add"}, want: "-f is required"},
+		{name: "required flags", args: []string{"pr", "create", "--id", "pr-1"}, want: "--author are required"},
+		{name: "bad output format", args: []string{"pr", "get", "pr-1", "-o", "yaml"}, want: "table or json"},
+		{name: "unknown flag", args: []string{"reviews", "u1", "--bogus"}, want: "bogus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, errOut := prctl(t, tt.args...)
+			if code != exitUsage || !strings.Contains(errOut, tt.want) {
+				t.Fatalf("exit %d, stderr %q, want exit %d containing %q", code, errOut, exitUsage, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/pkg/client"
+)
+
+// print writes v as indented json or as a table drawn by table
+func (e *env) print(v any, table func(w io.Writer)) error {
+	if e.output == "json" {
+		enc := json.NewEncoder(e.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func teamTable(team *client.Team) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintf(w, "TEAM %s\n\n", team.TeamName)
+		fmt.Fprintln(w, "USER_ID\tUSERNAME\tACTIVE\tROLE")
+		for _, m := range team.Members {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", m.UserID, m.Username, m.IsActive, orDash(string(m.Role)))
+		}
+	}
+}
+
+func userTable(user *client.User) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "USER_ID\tUSERNAME\tTEAM\tACTIVE\tROLE")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", user.UserID, user.Username, user.TeamName, user.IsActive, orDash(string(user.Role)))
+	}
+}
+
+// prsTable lists pull requests one per line
+func prsTable(prs []client.PullRequest, nextCursor string) func(io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "PR_ID\tNAME\tAUTHOR\tSTATUS\tREVIEWERS\tVERSION\tCREATED")
+		for _, pr := range prs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+				pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status,
+				orDash(strings.Join(pr.AssignedReviewers, ",")), pr.Version, formatTime(pr.CreatedAt))
+		}
+		if nextCursor != "" {
+			fmt.Fprintf(w, "\nnext page: --cursor %s\n", nextCursor)
+		}
+	}
+}
+
+// prTable shows one pull request with the state of every review
+func prTable(pr *client.PullRequest) func(io.Writer) {
+	return func(w io.Writer) {
+		prsTable([]client.PullRequest{*pr}, "")(w)
+		if len(pr.Reviews) == 0 {
+			return
+		}
+		fmt.Fprintln(w, "\nREVIEWER\tSTATE\tASSIGNED\tRESPONDED")
+		for _, r := range pr.Reviews {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.ReviewerID, r.State, formatTime(r.AssignedAt), formatTime(r.FirstResponseAt))
+		}
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
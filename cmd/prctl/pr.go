@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/pkg/client"
+)
+
+func prCreate(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("pr create")
+	var req client.CreatePRRequest
+	fs.StringVar(&req.PullRequestID, "id", "", "pull request id")
+	fs.StringVar(&req.PullRequestName, "name", "", "pull request title")
+	fs.StringVar(&req.AuthorID, "author", "", "author user id")
+	fs.BoolVar(&req.Draft, "draft", false, "create a draft without reviewers")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+	if req.PullRequestID == "" || req.PullRequestName == "" || req.AuthorID == "" {
+		return fmt.Errorf("%w: --id, --name and --author are required", errUsage)
+	}
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	pr, err := c.CreatePR(ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.print(pr, prTable(pr))
+}
+
+func prGet(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("pr get")
+	pos, err := e.parse(fs, args, "pr")
+	if err != nil {
+		return err
+	}
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	pr, err := c.GetPR(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	return e.print(pr, prTable(pr))
+}
+
+func prList(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("pr list")
+	var opts client.ListPRsOptions
+	status := fs.String("status", "", "comma separated statuses")
+	fs.StringVar(&opts.AuthorID, "author", "", "author user id")
+	fs.StringVar(&opts.ReviewerID, "reviewer", "", "reviewer user id")
+	fs.StringVar(&opts.TeamName, "team", "", "team of the author")
+	fs.IntVar(&opts.Limit, "limit", 0, "page size")
+	fs.StringVar(&opts.Cursor, "cursor", "", "next page cursor")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+	opts.Statuses = parseStatuses(*status)
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	page, err := c.ListPRs(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return e.print(page, prsTable(page.PullRequests, page.NextCursor))
+}
+
+func prMerge(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("pr merge")
+	force := fs.Bool("force", false, "skip the merge policy, needs the ADMIN role in the author team")
+	version := fs.Int("version", 0, "fail if the pr version changed")
+	pos, err := e.parse(fs, args, "pr")
+	if err != nil {
+		return err
+	}
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	pr, err := c.MergePR(ctx, pos[0], *force, versionOption(*version)...)
+	if err != nil {
+		return err
+	}
+	return e.print(pr, prTable(pr))
+}
+
+func prReassign(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("pr reassign")
+	version := fs.Int("version", 0, "fail if the pr version changed")
+	pos, err := e.parse(fs, args, "pr", "old-reviewer")
+	if err != nil {
+		return err
+	}
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	pr, replacedBy, err := c.ReassignReviewer(ctx, pos[0], pos[1], versionOption(*version)...)
+	if err != nil {
+		return err
+	}
+
+	resp := struct {
+		PR         *client.PullRequest `json:"pr"`
+		ReplacedBy string              `json:"replaced_by"`
+	}{PR: pr, ReplacedBy: replacedBy}
+	return e.print(resp, func(w io.Writer) {
+		fmt.Fprintf(w, "%s replaced by %s\n\n", pos[1], replacedBy)
+		prTable(pr)(w)
+	})
+}
+
+// versionOption turns the --version flag into an If-Match check
+func versionOption(version int) []client.CallOption {
+	if version <= 0 {
+		return nil
+	}
+	return []client.CallOption{client.WithIfMatch(version)}
+}
+
+// parseStatuses splits a comma separated status flag, the server validates the values
+func parseStatuses(raw string) []client.PullRequestStatus {
+	var statuses []client.PullRequestStatus
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			statuses = append(statuses, client.PullRequestStatus(strings.ToUpper(s)))
+		}
+	}
+	return statuses
+}
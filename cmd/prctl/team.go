@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/pkg/client"
+)
+
+// teamFile is the format of `prctl team add -f`:
+//
+//	team_name: backend
+//	members:
+//	  - user_id: u1
+//	    username: Alice
+//	    role: ADMIN
+//	  - user_id: u2
+//	    username: Bob
+//	    is_active: false
+type teamFile struct {
+	TeamName string `yaml:"team_name"`
+	Members  []struct {
+		UserID   string `yaml:"user_id"`
+		Username string `yaml:"username"`
+		IsActive *bool  `yaml:"is_active"` // members are active unless stated otherwise
+		Role     string `yaml:"role"`
+	} `yaml:"members"`
+}
+
+// loadTeamFile reads a team definition, unknown keys are rejected to catch typos
+func loadTeamFile(path string) (client.Team, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return client.Team{}, err
+	}
+	defer f.Close()
+
+	var file teamFile
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return client.Team{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	team := client.Team{TeamName: file.TeamName, Members: make([]client.TeamMember, 0, len(file.Members))}
+	for _, m := range file.Members {
+		team.Members = append(team.Members, client.TeamMember{
+			UserID:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive == nil || *m.IsActive,
+			Role:     client.TeamMemberRole(m.Role),
+		})
+	}
+	return team, nil
+}
+
+func teamAdd(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("team add")
+	path := fs.String("f", "", "team yaml file")
+	if _, err := e.parse(fs, args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+
+	team, err := loadTeamFile(*path)
+	if err != nil {
+		return err
+	}
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	created, err := c.AddTeam(ctx, team)
+	if err != nil {
+		return err
+	}
+	return e.print(created, teamTable(created))
+}
+
+func teamGet(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("team get")
+	pos, err := e.parse(fs, args, "team")
+	if err != nil {
+		return err
+	}
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	team, err := c.GetTeam(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	return e.print(team, teamTable(team))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/pkg/client"
+)
+
+// userSetActive builds `user activate` and `user deactivate`
+func userSetActive(active bool) command {
+	return func(ctx context.Context, e *env, args []string) error {
+		fs := e.flags("user")
+		pos, err := e.parse(fs, args, "user")
+		if err != nil {
+			return err
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+		user, err := c.SetIsActive(ctx, pos[0], active)
+		if err != nil {
+			return err
+		}
+		return e.print(user, userTable(user))
+	}
+}
+
+func reviews(ctx context.Context, e *env, args []string) error {
+	fs := e.flags("reviews")
+	var opts client.ReviewsOptions
+	fs.BoolVar(&opts.Pending, "pending", false, "only prs the user has not responded to")
+	status := fs.String("status", "", "comma separated statuses, OPEN by default")
+	fs.IntVar(&opts.Limit, "limit", 0, "page size")
+	fs.StringVar(&opts.Cursor, "cursor", "", "next page cursor")
+	pos, err := e.parse(fs, args, "user")
+	if err != nil {
+		return err
+	}
+	opts.Statuses = parseStatuses(*status)
+
+	c, err := e.client()
+	if err != nil {
+		return err
+	}
+	page, err := c.GetReviews(ctx, pos[0], opts)
+	if err != nil {
+		return err
+	}
+	return e.print(page, prsTable(page.PullRequests, page.NextCursor))
+}
@@ -10,11 +10,27 @@ import (
 
 // Config holds all application configuration settings
 type Config struct {
-	Env        string `env:"ENV" env-default:"local"`
-	HTTPServer HTTPServer
-	PG         PG
+	Env string `env:"ENV" env-default:"local"`
+	// postgres or memory, memory keeps data in process and loses it on restart
+	Storage     string `env:"STORAGE" env-default:"postgres"`
+	HTTPServer  HTTPServer
+	GRPCServer  GRPCServer
+	PG          PG
+	Notify      Notify
+	SLA         SLA
+	Auth        Auth
+	OIDC        OIDC
+	Idempotency Idempotency
+	Events      Events
+	Shutdown    Shutdown
 }
 
+// supported storage backends
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
 // HTTPServer holds http server-specific configuration
 type HTTPServer struct {
 	Address     string        `env:"HTTP_ADDRESS" env-default:"localhost:8080"`
@@ -22,9 +38,98 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `env:"HTTP_IDLE_TIMEOUT" env-default:"60s"`
 }
 
+// GRPCServer holds grpc server configuration
+type GRPCServer struct {
+	// empty disables the grpc api
+	Address string `env:"GRPC_ADDRESS" env-default:"localhost:9090"`
+}
+
 // PG holds postgresql database configuration
 type PG struct {
-	URL string `env:"PG_URL" env-required:"true"`
+	// required when storage is postgres
+	URL string `env:"PG_URL"`
+}
+
+// Notify holds reviewer notification configuration
+type Notify struct {
+	// org/team -> incoming webhook url, format: acme/team1:url1,team2:url2,
+	// bare team names apply to the default organization only
+	Webhooks map[string]string `env:"NOTIFY_WEBHOOKS"`
+	// webhook used for teams without their own url
+	DefaultWebhook string `env:"NOTIFY_DEFAULT_WEBHOOK"`
+	// org/user id -> chat member id used for @mentions, format: acme/u1:U123,u2:U456,
+	// bare user ids apply to the default organization only
+	Mentions map[string]string `env:"NOTIFY_MENTIONS"`
+	// go text/templates for the assignment and sla reminder message bodies
+	Template         string        `env:"NOTIFY_TEMPLATE"`
+	ReminderTemplate string        `env:"NOTIFY_REMINDER_TEMPLATE"`
+	Timeout          time.Duration `env:"NOTIFY_TIMEOUT" env-default:"5s"`
+}
+
+// SLA holds review sla scheduler configuration, thresholds are set per team via api
+type SLA struct {
+	Enabled      bool          `env:"SLA_ENABLED" env-default:"true"`
+	ScanInterval time.Duration `env:"SLA_SCAN_INTERVAL" env-default:"1m"`
+}
+
+// Auth holds api token authentication configuration
+type Auth struct {
+	// disabling gives every request full access, meant for local development only
+	Enabled bool `env:"AUTH_ENABLED" env-default:"true"`
+	// static team:admin token used to mint the first tokens, empty disables it
+	BootstrapToken string `env:"AUTH_BOOTSTRAP_TOKEN"`
+}
+
+// OIDC holds sso jwt verification configuration, jwts are accepted when a jwks source is set
+type OIDC struct {
+	Issuer   string `env:"OIDC_ISSUER"`
+	Audience string `env:"OIDC_AUDIENCE"`
+	// jwks is read from a local file or downloaded from a url
+	JWKSFile    string        `env:"OIDC_JWKS_FILE"`
+	JWKSURL     string        `env:"OIDC_JWKS_URL"`
+	JWKSRefresh time.Duration `env:"OIDC_JWKS_REFRESH" env-default:"10m"`
+	// claim holding users.id of the caller
+	UserClaim string `env:"OIDC_USER_CLAIM" env-default:"sub"`
+	// claim holding the organization of the caller, absent claim means the default one
+	OrgClaim string `env:"OIDC_ORG_CLAIM" env-default:"org"`
+	// scopes granted to sso users, team admin rights come from user roles
+	Scopes []string      `env:"OIDC_SCOPES" env-default:"pr:write"`
+	Leeway time.Duration `env:"OIDC_LEEWAY" env-default:"30s"`
+}
+
+// Idempotency holds Idempotency-Key handling configuration
+type Idempotency struct {
+	// how long a key and its stored response are kept
+	TTL time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	// how often expired keys are deleted from storage
+	SweepInterval time.Duration `env:"IDEMPOTENCY_SWEEP_INTERVAL" env-default:"1h"`
+}
+
+// Events holds the live event stream configuration
+type Events struct {
+	// events kept in memory for Last-Event-ID resume
+	History int `env:"EVENTS_HISTORY" env-default:"1000"`
+	// comment sent to idle streams so that proxies keep the connection open
+	Heartbeat time.Duration `env:"EVENTS_HEARTBEAT" env-default:"15s"`
+}
+
+// Shutdown holds graceful shutdown configuration
+type Shutdown struct {
+	// how long the process keeps serving with readiness off before closing listeners,
+	// should exceed the readiness probe period of the load balancer
+	Delay time.Duration `env:"SHUTDOWN_DELAY" env-default:"0s"`
+	// deadline for draining requests, stopping workers and closing the pool
+	Timeout time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"15s"`
+}
+
+// Enabled reports whether a jwks source is configured
+func (o OIDC) Enabled() bool {
+	return o.JWKSFile != "" || o.JWKSURL != ""
+}
+
+// Enabled reports whether any webhook is configured
+func (n Notify) Enabled() bool {
+	return len(n.Webhooks) > 0 || n.DefaultWebhook != ""
 }
 
 // Load reads configuration from env file and environment variables
@@ -42,5 +147,20 @@ func Load() *Config {
 		log.Fatalf("FATAL: cannot read environment configuration: %v", err)
 	}
 
+	switch cfg.Storage {
+	case StoragePostgres:
+		if cfg.PG.URL == "" {
+			log.Fatalf("FATAL: PG_URL is required for STORAGE=%s", StoragePostgres)
+		}
+	case StorageMemory:
+	default:
+		log.Fatalf("FATAL: STORAGE must be %s or %s, got %q", StoragePostgres, StorageMemory, cfg.Storage)
+	}
+
+	// without a bootstrap token or sso nobody can mint the first api token
+	if cfg.Auth.Enabled && cfg.Auth.BootstrapToken == "" && !cfg.OIDC.Enabled() {
+		log.Fatalf("FATAL: AUTH_ENABLED=true requires AUTH_BOOTSTRAP_TOKEN or OIDC_JWKS_FILE/OIDC_JWKS_URL")
+	}
+
 	return cfg
 }
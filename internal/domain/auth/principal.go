@@ -0,0 +1,55 @@
+// Package auth describes the caller of domain operations
+package auth
+
+import (
+	"context"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// key for principal in context
+type principalKey struct{}
+
+// Principal describes who performs the current operation
+type Principal struct {
+	TokenID string
+	UserID  string // empty for tokens not bound to a user
+	Scopes  []entity.Scope
+}
+
+// HasScope reports whether any of the principal scopes grants the given one
+func (p Principal) HasScope(scope entity.Scope) bool {
+	for _, s := range p.Scopes {
+		if s.Implies(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the principal may perform privileged operations
+func (p Principal) IsAdmin() bool {
+	return p.HasScope(entity.ScopeTeamAdmin)
+}
+
+// IsService reports whether the principal is a bot or operator token not bound to a user,
+// the anonymous principal has no scopes and is never a service
+func (p Principal) IsService() bool {
+	return p.UserID == "" && len(p.Scopes) > 0
+}
+
+// System returns the principal of background jobs run by the service itself
+func System() Principal {
+	return Principal{TokenID: "system", Scopes: []entity.Scope{entity.ScopeTeamAdmin}}
+}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal from ctx, anonymous if none is set
+func FromContext(ctx context.Context) Principal {
+	p, _ := ctx.Value(principalKey{}).(Principal)
+	return p
+}
@@ -1,15 +1,72 @@
 // Package entity defines core domain models
 package entity
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type PRStatus string
 
+type SLAKind string
+
+type ReviewState string
+
+type ReviewAction string
+
+type AssignmentAction string
+
+type Role string
+
 const (
+	StatusDraft  PRStatus = "DRAFT"
 	StatusOpen   PRStatus = "OPEN"
 	StatusMerged PRStatus = "MERGED"
+	StatusClosed PRStatus = "CLOSED"
 )
 
+const (
+	ReviewPending          ReviewState = "PENDING"
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewState = "COMMENTED"
+)
+
+const (
+	ActionApprove        ReviewAction = "APPROVE"
+	ActionRequestChanges ReviewAction = "REQUEST_CHANGES"
+	ActionComment        ReviewAction = "COMMENT"
+)
+
+const (
+	ActionAssigned   AssignmentAction = "ASSIGNED"
+	ActionUnassigned AssignmentAction = "UNASSIGNED"
+)
+
+const (
+	RoleAdmin  Role = "ADMIN"
+	RoleMember Role = "MEMBER"
+)
+
+const (
+	SLAReminder   SLAKind = "REMINDER"
+	SLAEscalation SLAKind = "ESCALATION"
+)
+
+// IsValid reports whether the status is known
+func (s PRStatus) IsValid() bool {
+	switch s {
+	case StatusDraft, StatusOpen, StatusMerged, StatusClosed:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether the role is known
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleMember
+}
+
 type Team struct {
 	Name string `db:"name" json:"team_name"`
 }
@@ -19,14 +76,137 @@ type User struct {
 	Username string `db:"username" json:"username"`
 	TeamName string `db:"team_name" json:"team_name"`
 	IsActive bool   `db:"is_active" json:"is_active"`
+	Role     Role   `db:"role" json:"role"`
 }
 
 type PullRequest struct {
-	ID        string     `db:"id" json:"pull_request_id"`
-	Name      string     `db:"name" json:"pull_request_name"`
-	AuthorID  string     `db:"author_id" json:"author_id"`
-	Status    PRStatus   `db:"status" json:"status"`
-	Reviewers []User     `db:"-" json:"assigned_reviewers"`
-	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
-	MergedAt  *time.Time `db:"merged_at" json:"mergedAt,omitempty"`
+	ID        string             `db:"id" json:"pull_request_id"`
+	Name      string             `db:"name" json:"pull_request_name"`
+	AuthorID  string             `db:"author_id" json:"author_id"`
+	Status    PRStatus           `db:"status" json:"status"`
+	Version   int                `db:"version" json:"version"`
+	Reviewers []User             `db:"-" json:"assigned_reviewers"`
+	Reviews   []Review           `db:"-" json:"reviews,omitempty"`
+	Author    *User              `db:"-" json:"author,omitempty"`
+	CreatedAt time.Time          `db:"created_at" json:"createdAt"`
+	MergedAt  *time.Time         `db:"merged_at" json:"mergedAt,omitempty"`
+	ClosedAt  *time.Time         `db:"closed_at" json:"closedAt,omitempty"`
+	History   *AssignmentHistory `db:"-" json:"assignment_history,omitempty"` // single pr reads only
+}
+
+// MarshalJSON writes assigned reviewers as user ids, the api contract has no user objects there
+func (pr PullRequest) MarshalJSON() ([]byte, error) {
+	type plain PullRequest // drops this method
+	reviewerIDs := make([]string, len(pr.Reviewers))
+	for i, u := range pr.Reviewers {
+		reviewerIDs[i] = u.ID
+	}
+	return json.Marshal(struct {
+		plain
+		Reviewers []string `json:"assigned_reviewers"`
+	}{plain(pr), reviewerIDs})
+}
+
+// Review is the review state of a single assigned reviewer
+type Review struct {
+	PRID            string      `db:"pr_id" json:"pull_request_id"`
+	ReviewerID      string      `db:"reviewer_id" json:"reviewer_id"`
+	State           ReviewState `db:"state" json:"state"`
+	AssignedAt      time.Time   `db:"assigned_at" json:"assignedAt"`
+	FirstResponseAt *time.Time  `db:"first_response_at" json:"firstResponseAt,omitempty"`
+	UpdatedAt       time.Time   `db:"updated_at" json:"updatedAt"`
+}
+
+// AssignmentEvent records a reviewer being assigned to or removed from a pr
+type AssignmentEvent struct {
+	ReviewerID string           `db:"reviewer_id" json:"reviewer_id"`
+	Action     AssignmentAction `db:"action" json:"action"`
+	CreatedAt  time.Time        `db:"created_at" json:"createdAt"`
+}
+
+// AssignmentHistory summarizes reviewer changes of a pr without loading the whole log
+type AssignmentHistory struct {
+	Assignments   int               `json:"assignments"`
+	Unassignments int               `json:"unassignments"`
+	LastChangeAt  *time.Time        `json:"lastChangeAt,omitempty"`
+	Events        []AssignmentEvent `json:"events"` // last event of every reviewer in chronological order
+}
+
+// IsValid reports whether the action is supported
+func (a ReviewAction) IsValid() bool {
+	switch a {
+	case ActionApprove, ActionRequestChanges, ActionComment:
+		return true
+	}
+	return false
+}
+
+// NextState returns the review state after applying the action,
+// a comment does not override an earlier approval or change request
+func (a ReviewAction) NextState(current ReviewState) ReviewState {
+	switch a {
+	case ActionApprove:
+		return ReviewApproved
+	case ActionRequestChanges:
+		return ReviewChangesRequested
+	}
+	if current == ReviewPending {
+		return ReviewCommented
+	}
+	return current
+}
+
+// TeamSettings holds per-team review policy, zero values disable the check
+type TeamSettings struct {
+	TeamName      string        `db:"team_name" json:"team_name"`
+	ReminderAfter time.Duration `db:"reminder_after" json:"reminder_after"`
+	EscalateAfter time.Duration `db:"escalate_after" json:"escalate_after"`
+	AutoReassign  bool          `db:"auto_reassign" json:"auto_reassign"`
+	MergePolicy   MergePolicy   `db:"-" json:"merge_policy"`
+}
+
+// MaxReviewers is the number of reviewers assigned to a pull request
+const MaxReviewers = 2
+
+// MergePolicy defines conditions a pull request must meet before merge
+type MergePolicy struct {
+	MinApprovals            int  `db:"min_approvals" json:"min_approvals"`
+	RequireAllApproved      bool `db:"require_all_approved" json:"require_all_approved"`
+	BlockOnChangesRequested bool `db:"block_on_changes_requested" json:"block_on_changes_requested"`
+}
+
+// SLABreach describes an open pull request that exceeded a team sla
+type SLABreach struct {
+	OrgID        string
+	PR           *PullRequest
+	Kind         SLAKind
+	AutoReassign bool
+}
+
+// PRFilter narrows pull request listings, zero values mean no restriction
+type PRFilter struct {
+	Statuses    []PRStatus
+	AuthorID    string
+	ReviewerID  string
+	OnlyPending bool   // only prs the reviewer has not responded to, requires ReviewerID
+	TeamName    string // team of the author
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	Desc        bool      // newest first, ordering is always by created_at, id
+	After       *PRCursor // position of the last item of the previous page
+	Limit       int
+}
+
+// PRCursor is a keyset pagination position in created_at, id order
+type PRCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// PRPage is a single page of a pull request listing
+type PRPage struct {
+	Items []*PullRequest
+	Next  *PRCursor // nil on the last page
 }
@@ -1,13 +1,78 @@
 // Package entity defines core domain models
 package entity
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
-	ErrNotFound    = errors.New("resource not found")
-	ErrTeamExists  = errors.New("team already exists")
-	ErrPRMerged    = errors.New("pull request is merged")
-	ErrNoCandidate = errors.New("no active candidate available")
-	ErrNotAssigned = errors.New("reviewer is not assigned to this PR")
-	ErrPRExists    = errors.New("pull request with this ID already exists")
+	ErrNotFound              = errors.New("resource not found")
+	ErrTeamExists            = errors.New("team already exists")
+	ErrUserExists            = errors.New("user with this ID already exists")
+	ErrPRMerged              = errors.New("pull request is merged")
+	ErrNoCandidate           = errors.New("no active candidate available")
+	ErrNotAssigned           = errors.New("reviewer is not assigned to this PR")
+	ErrPRExists              = errors.New("pull request with this ID already exists")
+	ErrInvalidReviewAction   = errors.New("review action must be one of APPROVE, REQUEST_CHANGES, COMMENT")
+	ErrMergeBlocked          = errors.New("merge is blocked by team policy")
+	ErrForbidden             = errors.New("operation is not allowed for the caller")
+	ErrUnauthorized          = errors.New("missing or invalid credentials")
+	ErrInvalidOrg            = errors.New("invalid organization id")
+	ErrInvalidScope          = errors.New("scopes must be a non-empty list of read, pr:write, team:admin")
+	ErrInvalidTransition     = errors.New("pull request status transition is not allowed")
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be at most 255 characters and the body at most 1MB")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used with a different request")
+	ErrRequestInProgress     = errors.New("a request with this idempotency key is still being processed")
+	ErrPRNotOpen             = errors.New("pull request is not open")
+	ErrConflict              = errors.New("pull request was modified concurrently")
+	ErrInvalidVersion        = errors.New("If-Match must be a positive pull request version")
+	ErrInvalidSettings       = errors.New("invalid team settings")
+	ErrInvalidSLADuration    = errors.New("sla durations must be non-negative")
+	ErrEscalationTooEarly    = errors.New("escalate_after must not be less than reminder_after")
+	ErrInvalidMergePolicy    = fmt.Errorf("merge_policy.min_approvals must be between 0 and %d, the number of assigned reviewers", MaxReviewers)
 )
+
+// MergeBlockedError lists the merge policy conditions that are not met
+type MergeBlockedError struct {
+	Unmet []string
+}
+
+func (e *MergeBlockedError) Error() string {
+	return ErrMergeBlocked.Error() + ": " + strings.Join(e.Unmet, "; ")
+}
+
+// Unwrap allows errors.Is(err, ErrMergeBlocked)
+func (e *MergeBlockedError) Unwrap() error {
+	return ErrMergeBlocked
+}
+
+// SettingsViolation is a broken team settings invariant,
+// the field is named as in the api, e.g. merge_policy.min_approvals
+type SettingsViolation struct {
+	Field string
+	Err   error
+}
+
+// InvalidSettingsError lists every broken invariant of the team settings
+type InvalidSettingsError struct {
+	Violations []SettingsViolation
+}
+
+func (e *InvalidSettingsError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Field + ": " + v.Err.Error()
+	}
+	return ErrInvalidSettings.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Unwrap allows errors.Is(err, ErrInvalidSettings) and checks for a single invariant
+func (e *InvalidSettingsError) Unwrap() []error {
+	errs := []error{ErrInvalidSettings}
+	for _, v := range e.Violations {
+		errs = append(errs, v.Err)
+	}
+	return errs
+}
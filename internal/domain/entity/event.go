@@ -0,0 +1,22 @@
+// Package entity defines core domain models
+package entity
+
+import "time"
+
+type EventType string
+
+const (
+	EventAssigned   EventType = "ASSIGNED"
+	EventUnassigned EventType = "UNASSIGNED"
+	EventMerged     EventType = "MERGED"
+)
+
+// Event is a change of a pull request delivered to one of its reviewers
+type Event struct {
+	ID        uint64       `json:"-"` // set by the event bus, increases with every event
+	OrgID     string       `json:"-"`
+	UserID    string       `json:"user_id"` // recipient
+	Type      EventType    `json:"type"`
+	PR        *PullRequest `json:"pr"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
@@ -0,0 +1,14 @@
+// Package entity defines core domain models
+package entity
+
+import "time"
+
+// IdempotencyRecord is a stored request identified by a client supplied key
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	Body        []byte
+	CreatedAt   time.Time
+	CompletedAt *time.Time // nil while the first request is still being processed
+}
@@ -0,0 +1,53 @@
+// Package entity defines core domain models
+package entity
+
+import "time"
+
+type Scope string
+
+const (
+	ScopeRead      Scope = "read"
+	ScopePRWrite   Scope = "pr:write"
+	ScopeTeamAdmin Scope = "team:admin"
+)
+
+// IsValid reports whether the scope is known
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeRead, ScopePRWrite, ScopeTeamAdmin:
+		return true
+	}
+	return false
+}
+
+// Implies reports whether holding s grants other,
+// team:admin grants everything and pr:write grants read
+func (s Scope) Implies(other Scope) bool {
+	switch s {
+	case ScopeTeamAdmin:
+		return true
+	case ScopePRWrite:
+		return other == ScopePRWrite || other == ScopeRead
+	}
+	return s == other
+}
+
+// APIToken is an issued api credential, the secret itself is never stored
+type APIToken struct {
+	ID        string     `db:"id" json:"token_id"`
+	OrgID     string     `db:"org_id" json:"org_id,omitempty"` // empty for credentials not bound to an organization
+	Name      string     `db:"name" json:"name"`
+	UserID    string     `db:"user_id" json:"user_id,omitempty"`
+	Scopes    []Scope    `db:"scopes" json:"scopes"`
+	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
+	ExpiresAt *time.Time `db:"expires_at" json:"expiresAt,omitempty"`
+	RevokedAt *time.Time `db:"revoked_at" json:"revokedAt,omitempty"`
+}
+
+// IsActive reports whether the token can be used at the given moment
+func (t *APIToken) IsActive(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
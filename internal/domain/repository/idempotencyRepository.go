@@ -0,0 +1,20 @@
+// Package repository handles data persistence and retrieval
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// IdempotencyRepository stores keys of one caller, principal identifies the caller within the organization
+type IdempotencyRepository interface {
+	// Reserve claims the key for a new request, if the key is already taken
+	// the existing record is returned and reserved is false
+	Reserve(ctx context.Context, principal, key, requestHash string, ttl time.Duration) (existing *entity.IdempotencyRecord, reserved bool, err error)
+	Complete(ctx context.Context, principal, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, principal, key string) error
+	// DeleteExpired removes keys older than ttl in all organizations, returns the number removed
+	DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error)
+}
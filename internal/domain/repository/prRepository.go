@@ -10,8 +10,13 @@ import (
 type PRRepository interface {
 	Create(ctx context.Context, pr *entity.PullRequest) error
 	GetByID(ctx context.Context, id string) (*entity.PullRequest, error)
+	GetByIDForUpdate(ctx context.Context, id string) (*entity.PullRequest, error)
 	UpdateStatus(ctx context.Context, id string, status entity.PRStatus) (*entity.PullRequest, error)
+	UpdateStatusAndReviewers(ctx context.Context, id string, status entity.PRStatus, reviewerIDs []string) (*entity.PullRequest, error)
 	SetReviewers(ctx context.Context, prID string, reviewerIDs []string) error
-	GetReviewsByUserID(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	GetReviewsByUserID(ctx context.Context, userID string, filter entity.PRFilter) (*entity.PRPage, error)
 	GetReviewersByPRID(ctx context.Context, prID string) ([]*entity.User, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, state entity.ReviewState) (*entity.Review, error)
+	List(ctx context.Context, filter entity.PRFilter) (*entity.PRPage, error)
+	GetAssignmentHistory(ctx context.Context, prID string) (*entity.AssignmentHistory, error)
 }
@@ -0,0 +1,816 @@
+// Package repositorytest holds the contract test suite every storage backend must pass
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// Repositories is a single storage backend under test
+type Repositories struct {
+	Transactor  repository.Transactor
+	Teams       repository.TeamRepository
+	Users       repository.UserRepository
+	PRs         repository.PRRepository
+	SLA         repository.SLARepository
+	Tokens      repository.TokenRepository
+	Idempotency repository.IdempotencyRepository
+}
+
+// Run runs the contract suite, newRepos must return a backend with no data on every call
+func Run(t *testing.T, newRepos func(t *testing.T) Repositories) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r Repositories)
+	}{
+		{"TeamCreate", testTeamCreate},
+		{"TeamSettings", testTeamSettings},
+		{"Users", testUsers},
+		{"PRCreate", testPRCreate},
+		{"PRReviewers", testPRReviewers},
+		{"PRSubmitReview", testPRSubmitReview},
+		{"PRUpdateStatus", testPRUpdateStatus},
+		{"PRUpdateStatusAndReviewers", testPRUpdateStatusAndReviewers},
+		{"PRList", testPRList},
+		{"TransactorRollback", testTransactorRollback},
+		{"OrgIsolation", testOrgIsolation},
+		{"SLA", testSLA},
+		{"SLAOpenedAt", testSLAOpenedAt},
+		{"Tokens", testTokens},
+		{"Idempotency", testIdempotency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepos(t))
+		})
+	}
+}
+
+// seedTeam creates a team with active members u1..uN
+func seedTeam(t *testing.T, ctx context.Context, r Repositories, name string, userIDs ...string) {
+	t.Helper()
+	users := make([]*entity.User, len(userIDs))
+	for i, id := range userIDs {
+		users[i] = &entity.User{ID: id, Username: "name-" + id, IsActive: true}
+	}
+	if err := r.Teams.Create(ctx, &entity.Team{Name: name}, users); err != nil {
+		t.Fatalf("create team %s: %v", name, err)
+	}
+}
+
+// seedPR creates an open pr with the given reviewers
+func seedPR(t *testing.T, ctx context.Context, r Repositories, id, authorID string, reviewerIDs ...string) {
+	t.Helper()
+	pr := &entity.PullRequest{ID: id, Name: "pr " + id, AuthorID: authorID, Status: entity.StatusOpen}
+	for _, rid := range reviewerIDs {
+		pr.Reviewers = append(pr.Reviewers, entity.User{ID: rid})
+	}
+	if err := r.PRs.Create(ctx, pr); err != nil {
+		t.Fatalf("create pr %s: %v", id, err)
+	}
+}
+
+func mustGetPR(t *testing.T, ctx context.Context, r Repositories, id string) *entity.PullRequest {
+	t.Helper()
+	pr, err := r.PRs.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("get pr %s: %v", id, err)
+	}
+	return pr
+}
+
+func reviewerIDs(pr *entity.PullRequest) []string {
+	ids := make([]string, len(pr.Reviewers))
+	for i, u := range pr.Reviewers {
+		ids[i] = u.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testTeamCreate(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2")
+
+	team, err := r.Teams.GetByName(ctx, "backend")
+	if err != nil || team.Name != "backend" {
+		t.Fatalf("GetByName = %v, %v", team, err)
+	}
+
+	user, err := r.Users.GetByID(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if user.TeamName != "backend" || user.Role != entity.RoleMember || !user.IsActive {
+		t.Errorf("member = %+v, want active MEMBER of backend", user)
+	}
+
+	tests := []struct {
+		name    string
+		team    string
+		users   []*entity.User
+		wantErr error
+	}{
+		{"duplicate team", "backend", nil, entity.ErrTeamExists},
+		{"member of another team", "frontend", []*entity.User{{ID: "u1", Username: "x"}}, entity.ErrUserExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Transactor.Do(ctx, func(ctx context.Context) error {
+				return r.Teams.Create(ctx, &entity.Team{Name: tt.team}, tt.users)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// the failed transaction must not leave the team behind
+	if _, err := r.Teams.GetByName(ctx, "frontend"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("GetByName(frontend) = %v, want ErrNotFound", err)
+	}
+}
+
+func testTeamSettings(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1")
+
+	settings, err := r.Teams.GetSettings(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetSettings: %v", err)
+	}
+	if settings.TeamName != "backend" || settings.ReminderAfter != 0 || settings.MergePolicy.MinApprovals != 0 {
+		t.Errorf("default settings = %+v", settings)
+	}
+
+	want := entity.TeamSettings{
+		TeamName:      "backend",
+		ReminderAfter: time.Hour,
+		EscalateAfter: 2 * time.Hour,
+		AutoReassign:  true,
+		MergePolicy:   entity.MergePolicy{MinApprovals: 2, BlockOnChangesRequested: true},
+	}
+	for range 2 { // saving twice replaces the row
+		if err := r.Teams.SaveSettings(ctx, &want); err != nil {
+			t.Fatalf("SaveSettings: %v", err)
+		}
+	}
+
+	got, err := r.Teams.GetSettings(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetSettings: %v", err)
+	}
+	if *got != want {
+		t.Errorf("GetSettings = %+v, want %+v", *got, want)
+	}
+}
+
+func testUsers(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2", "u3")
+
+	if err := r.Users.Create(ctx, &entity.User{ID: "u4", Username: "d", TeamName: "backend", IsActive: true, Role: entity.RoleAdmin}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		user    *entity.User
+		wantErr error
+	}{
+		{"duplicate id", &entity.User{ID: "u1", Username: "x", TeamName: "backend"}, entity.ErrUserExists},
+		{"unknown team", &entity.User{ID: "u9", Username: "x", TeamName: "nope"}, entity.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Users.Create(ctx, tt.user); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	user, err := r.Users.SetIsActive(ctx, "u2", false)
+	if err != nil || user.IsActive || user.ID != "u2" {
+		t.Fatalf("SetIsActive = %+v, %v", user, err)
+	}
+	if _, err := r.Users.SetIsActive(ctx, "missing", true); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("SetIsActive(missing) = %v, want ErrNotFound", err)
+	}
+	if _, err := r.Users.GetByID(ctx, "missing"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("GetByID(missing) = %v, want ErrNotFound", err)
+	}
+
+	// inactive and excluded users are not candidates
+	candidates, err := r.Users.GetActiveCandidatesByTeam(ctx, "backend", "u1")
+	if err != nil {
+		t.Fatalf("GetActiveCandidatesByTeam: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, c := range candidates {
+		got[c.ID] = true
+	}
+	if len(got) != 2 || !got["u3"] || !got["u4"] {
+		t.Errorf("candidates = %v, want u3 and u4", got)
+	}
+
+	// members are listed with inactive ones in id order
+	members, err := r.Users.ListByTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("ListByTeam: %v", err)
+	}
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.ID
+	}
+	if !equalIDs(ids, []string{"u1", "u2", "u3", "u4"}) || members[1].IsActive {
+		t.Errorf("ListByTeam = %v, want u1..u4 with inactive u2", ids)
+	}
+	if members, err := r.Users.ListByTeam(ctx, "nope"); err != nil || len(members) != 0 {
+		t.Errorf("ListByTeam(nope) = %v, %v, want empty", members, err)
+	}
+}
+
+func testPRCreate(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2", "u3")
+	seedPR(t, ctx, r, "pr-1", "u1", "u2", "u3")
+
+	pr := mustGetPR(t, ctx, r, "pr-1")
+	if pr.Version != 1 || pr.Status != entity.StatusOpen || pr.AuthorID != "u1" || pr.CreatedAt.IsZero() {
+		t.Errorf("pr = %+v", pr)
+	}
+	if pr.Author == nil || pr.Author.ID != "u1" {
+		t.Errorf("author = %+v, want u1", pr.Author)
+	}
+	if got := reviewerIDs(pr); !equalIDs(got, []string{"u2", "u3"}) {
+		t.Errorf("reviewers = %v, want [u2 u3]", got)
+	}
+	for _, review := range pr.Reviews {
+		if review.State != entity.ReviewPending || review.FirstResponseAt != nil {
+			t.Errorf("initial review = %+v, want PENDING without response", review)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pr      *entity.PullRequest
+		wantErr error
+	}{
+		{"duplicate id", &entity.PullRequest{ID: "pr-1", Name: "x", AuthorID: "u1", Status: entity.StatusOpen}, entity.ErrPRExists},
+		{"unknown author", &entity.PullRequest{ID: "pr-2", Name: "x", AuthorID: "nope", Status: entity.StatusOpen}, entity.ErrNotFound},
+		{"unknown reviewer", &entity.PullRequest{ID: "pr-3", Name: "x", AuthorID: "u1", Status: entity.StatusOpen, Reviewers: []entity.User{{ID: "nope"}}}, entity.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Transactor.Do(ctx, func(ctx context.Context) error {
+				return r.PRs.Create(ctx, tt.pr)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := r.PRs.GetByID(ctx, "pr-3"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("GetByID(pr-3) = %v, want ErrNotFound after rollback", err)
+	}
+}
+
+func testPRReviewers(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2", "u3", "u4")
+	seedPR(t, ctx, r, "pr-1", "u1", "u2", "u3")
+
+	if _, err := r.PRs.SubmitReview(ctx, "pr-1", "u2", entity.ReviewApproved); err != nil {
+		t.Fatalf("SubmitReview: %v", err)
+	}
+	before := mustGetPR(t, ctx, r, "pr-1")
+
+	// replace u3 with u4, u2 keeps the approval
+	if err := r.PRs.SetReviewers(ctx, "pr-1", []string{"u2", "u4"}); err != nil {
+		t.Fatalf("SetReviewers: %v", err)
+	}
+
+	pr := mustGetPR(t, ctx, r, "pr-1")
+	if pr.Version != before.Version+1 {
+		t.Errorf("version = %d, want %d", pr.Version, before.Version+1)
+	}
+	states := make(map[string]entity.ReviewState)
+	for _, review := range pr.Reviews {
+		states[review.ReviewerID] = review.State
+	}
+	want := map[string]entity.ReviewState{"u2": entity.ReviewApproved, "u4": entity.ReviewPending}
+	if len(states) != len(want) || states["u2"] != want["u2"] || states["u4"] != want["u4"] {
+		t.Errorf("review states = %v, want %v", states, want)
+	}
+
+	reviewers, err := r.PRs.GetReviewersByPRID(ctx, "pr-1")
+	if err != nil || len(reviewers) != 2 {
+		t.Fatalf("GetReviewersByPRID = %v, %v", reviewers, err)
+	}
+
+	// u3 comes back and leaves again, the history keeps one event per reviewer
+	for _, ids := range [][]string{{"u2", "u3"}, {"u2", "u4"}} {
+		if err := r.PRs.SetReviewers(ctx, "pr-1", ids); err != nil {
+			t.Fatalf("SetReviewers(%v): %v", ids, err)
+		}
+	}
+
+	history, err := r.PRs.GetAssignmentHistory(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetAssignmentHistory: %v", err)
+	}
+	if history.Assignments != 5 || history.Unassignments != 3 || history.LastChangeAt == nil {
+		t.Errorf("history = %+v, want 5 assignments and 3 unassignments", history)
+	}
+	var last []string
+	for _, e := range history.Events {
+		last = append(last, e.ReviewerID+" "+string(e.Action))
+	}
+	if strings.Join(last, ", ") != "u2 ASSIGNED, u3 UNASSIGNED, u4 ASSIGNED" {
+		t.Errorf("last events = %v", last)
+	}
+
+	if history, err := r.PRs.GetAssignmentHistory(ctx, "nope"); err != nil || history.Assignments != 0 || len(history.Events) != 0 || history.LastChangeAt != nil {
+		t.Errorf("GetAssignmentHistory(nope) = %+v, %v, want an empty history", history, err)
+	}
+
+	if err := r.PRs.SetReviewers(ctx, "pr-1", []string{"nope"}); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("SetReviewers(unknown user) = %v, want ErrNotFound", err)
+	}
+}
+
+func testPRSubmitReview(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2")
+	seedPR(t, ctx, r, "pr-1", "u1", "u2")
+
+	first, err := r.PRs.SubmitReview(ctx, "pr-1", "u2", entity.ReviewCommented)
+	if err != nil {
+		t.Fatalf("SubmitReview: %v", err)
+	}
+	if first.State != entity.ReviewCommented || first.FirstResponseAt == nil || first.PRID != "pr-1" {
+		t.Errorf("review = %+v", first)
+	}
+
+	second, err := r.PRs.SubmitReview(ctx, "pr-1", "u2", entity.ReviewApproved)
+	if err != nil {
+		t.Fatalf("SubmitReview: %v", err)
+	}
+	if second.State != entity.ReviewApproved || !second.FirstResponseAt.Equal(*first.FirstResponseAt) {
+		t.Errorf("second review = %+v, first response must be kept", second)
+	}
+
+	if got := mustGetPR(t, ctx, r, "pr-1").Version; got != 3 {
+		t.Errorf("version = %d, want 3 after two reviews", got)
+	}
+
+	if _, err := r.PRs.SubmitReview(ctx, "pr-1", "u1", entity.ReviewApproved); !errors.Is(err, entity.ErrNotAssigned) {
+		t.Errorf("SubmitReview(author) = %v, want ErrNotAssigned", err)
+	}
+}
+
+func testPRUpdateStatus(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2")
+	seedPR(t, ctx, r, "pr-1", "u1", "u2")
+
+	tests := []struct {
+		status     entity.PRStatus
+		wantMerged bool
+		wantClosed bool
+	}{
+		{entity.StatusClosed, false, true},
+		{entity.StatusOpen, false, false},
+		{entity.StatusMerged, true, false},
+	}
+	for i, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			pr, err := r.PRs.UpdateStatus(ctx, "pr-1", tt.status)
+			if err != nil {
+				t.Fatalf("UpdateStatus: %v", err)
+			}
+			if pr.Status != tt.status || pr.Version != i+2 {
+				t.Errorf("pr status %s version %d, want %s version %d", pr.Status, pr.Version, tt.status, i+2)
+			}
+			if (pr.MergedAt != nil) != tt.wantMerged || (pr.ClosedAt != nil) != tt.wantClosed {
+				t.Errorf("mergedAt %v closedAt %v", pr.MergedAt, pr.ClosedAt)
+			}
+			if got := reviewerIDs(pr); !equalIDs(got, []string{"u2"}) {
+				t.Errorf("reviewers = %v, want [u2]", got)
+			}
+			if pr.Author == nil || pr.Author.ID != "u1" {
+				t.Errorf("author = %+v, want u1", pr.Author)
+			}
+		})
+	}
+
+	if _, err := r.PRs.UpdateStatus(ctx, "missing", entity.StatusMerged); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("UpdateStatus(missing) = %v, want ErrNotFound", err)
+	}
+}
+
+func testPRUpdateStatusAndReviewers(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2", "u3")
+	if err := r.PRs.Create(ctx, &entity.PullRequest{ID: "pr-1", Name: "draft", AuthorID: "u1", Status: entity.StatusDraft}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	pr, err := r.PRs.UpdateStatusAndReviewers(ctx, "pr-1", entity.StatusOpen, []string{"u2", "u3"})
+	if err != nil {
+		t.Fatalf("UpdateStatusAndReviewers: %v", err)
+	}
+	// one logical change, one version
+	if pr.Status != entity.StatusOpen || pr.Version != 2 {
+		t.Errorf("pr status %s version %d, want OPEN version 2", pr.Status, pr.Version)
+	}
+	if got := reviewerIDs(pr); !equalIDs(got, []string{"u2", "u3"}) {
+		t.Errorf("reviewers = %v, want [u2 u3]", got)
+	}
+	if pr.Author == nil || pr.Author.ID != "u1" {
+		t.Errorf("author = %+v, want u1", pr.Author)
+	}
+
+	if _, err := r.PRs.UpdateStatusAndReviewers(ctx, "pr-1", entity.StatusClosed, []string{"ghost"}); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("UpdateStatusAndReviewers(unknown reviewer) = %v, want ErrNotFound", err)
+	}
+	if got := mustGetPR(t, ctx, r, "pr-1"); got.Status != entity.StatusOpen || got.Version != 2 {
+		t.Errorf("pr after a failed update = %s version %d, want unchanged", got.Status, got.Version)
+	}
+	if _, err := r.PRs.UpdateStatusAndReviewers(ctx, "missing", entity.StatusOpen, []string{"u2"}); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("UpdateStatusAndReviewers(missing) = %v, want ErrNotFound", err)
+	}
+}
+
+func testPRList(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2", "u3")
+	seedTeam(t, ctx, r, "frontend", "f1")
+
+	seedPR(t, ctx, r, "pr-1", "u1", "u2")
+	seedPR(t, ctx, r, "pr-2", "u1", "u3")
+	seedPR(t, ctx, r, "pr-3", "f1")
+	seedPR(t, ctx, r, "pr-4", "u2", "u3")
+	if _, err := r.PRs.UpdateStatus(ctx, "pr-2", entity.StatusMerged); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if _, err := r.PRs.SubmitReview(ctx, "pr-4", "u3", entity.ReviewApproved); err != nil {
+		t.Fatalf("SubmitReview: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter entity.PRFilter
+		want   []string
+	}{
+		{"all ascending", entity.PRFilter{Limit: 10}, []string{"pr-1", "pr-2", "pr-3", "pr-4"}},
+		{"all descending", entity.PRFilter{Limit: 10, Desc: true}, []string{"pr-4", "pr-3", "pr-2", "pr-1"}},
+		{"status", entity.PRFilter{Limit: 10, Statuses: []entity.PRStatus{entity.StatusMerged}}, []string{"pr-2"}},
+		{"author", entity.PRFilter{Limit: 10, AuthorID: "u1"}, []string{"pr-1", "pr-2"}},
+		{"reviewer", entity.PRFilter{Limit: 10, ReviewerID: "u3"}, []string{"pr-2", "pr-4"}},
+		{"pending reviewer", entity.PRFilter{Limit: 10, ReviewerID: "u3", OnlyPending: true}, []string{"pr-2"}},
+		{"team", entity.PRFilter{Limit: 10, TeamName: "frontend"}, []string{"pr-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := r.PRs.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			got := make([]string, len(page.Items))
+			for i, pr := range page.Items {
+				got[i] = pr.ID
+			}
+			if !equalIDs(got, tt.want) {
+				t.Errorf("List = %v, want %v", got, tt.want)
+			}
+			if page.Next != nil {
+				t.Errorf("unexpected next cursor %+v", page.Next)
+			}
+		})
+	}
+
+	// walking pages of two returns every pr once
+	var walked []string
+	filter := entity.PRFilter{Limit: 2}
+	for {
+		page, err := r.PRs.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		for _, pr := range page.Items {
+			walked = append(walked, pr.ID)
+		}
+		if page.Next == nil {
+			break
+		}
+		filter.After = page.Next
+	}
+	if !equalIDs(walked, []string{"pr-1", "pr-2", "pr-3", "pr-4"}) {
+		t.Errorf("paged walk = %v", walked)
+	}
+
+	page, err := r.PRs.GetReviewsByUserID(ctx, "u2", entity.PRFilter{Limit: 10})
+	if err != nil || len(page.Items) != 1 || page.Items[0].ID != "pr-1" {
+		t.Errorf("GetReviewsByUserID = %+v, %v", page, err)
+	}
+}
+
+func testTransactorRollback(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2")
+	seedPR(t, ctx, r, "pr-1", "u1", "u2")
+
+	errBoom := errors.New("boom")
+	err := r.Transactor.Do(ctx, func(ctx context.Context) error {
+		locked, err := r.PRs.GetByIDForUpdate(ctx, "pr-1")
+		if err != nil {
+			return err
+		}
+		if _, err := r.PRs.UpdateStatus(ctx, locked.ID, entity.StatusMerged); err != nil {
+			return err
+		}
+		if _, err := r.Users.SetIsActive(ctx, "u2", false); err != nil {
+			return err
+		}
+		// nested calls join the outer transaction
+		return r.Transactor.Do(ctx, func(ctx context.Context) error {
+			seedTeam(t, ctx, r, "frontend", "f1")
+			return errBoom
+		})
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Do = %v, want errBoom", err)
+	}
+
+	pr := mustGetPR(t, ctx, r, "pr-1")
+	if pr.Status != entity.StatusOpen || pr.Version != 1 {
+		t.Errorf("pr after rollback = %s version %d", pr.Status, pr.Version)
+	}
+	if user, _ := r.Users.GetByID(ctx, "u2"); user == nil || !user.IsActive {
+		t.Errorf("user after rollback = %+v", user)
+	}
+	if _, err := r.Teams.GetByName(ctx, "frontend"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("team after rollback = %v, want ErrNotFound", err)
+	}
+
+	// a panic rolls back as well
+	func() {
+		defer func() { _ = recover() }()
+		_ = r.Transactor.Do(ctx, func(ctx context.Context) error {
+			if _, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusClosed); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+	if pr := mustGetPR(t, ctx, r, "pr-1"); pr.Status != entity.StatusOpen {
+		t.Errorf("pr after panic = %s, want OPEN", pr.Status)
+	}
+
+	// a successful transaction commits
+	err = r.Transactor.Do(ctx, func(ctx context.Context) error {
+		_, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusMerged)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if pr := mustGetPR(t, ctx, r, "pr-1"); pr.Status != entity.StatusMerged {
+		t.Errorf("pr after commit = %s, want MERGED", pr.Status)
+	}
+}
+
+func testOrgIsolation(t *testing.T, r Repositories) {
+	acme := tenant.WithOrg(context.Background(), "acme")
+	other := tenant.WithOrg(context.Background(), "other")
+
+	// the same names are independent in different organizations
+	seedTeam(t, acme, r, "backend", "u1", "u2")
+	seedTeam(t, other, r, "backend", "u1")
+	seedPR(t, acme, r, "pr-1", "u1", "u2")
+
+	if _, err := r.PRs.GetByID(other, "pr-1"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("GetByID from other org = %v, want ErrNotFound", err)
+	}
+	if _, err := r.Users.GetByID(other, "u2"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("GetByID(u2) from other org = %v, want ErrNotFound", err)
+	}
+	page, err := r.PRs.List(other, entity.PRFilter{Limit: 10})
+	if err != nil || len(page.Items) != 0 {
+		t.Errorf("List from other org = %+v, %v", page, err)
+	}
+	seedPR(t, other, r, "pr-1", "u1")
+}
+
+func testSLA(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2")
+	seedTeam(t, ctx, r, "frontend", "f1", "f2")
+	seedPR(t, ctx, r, "pr-1", "u1", "u2")
+	seedPR(t, ctx, r, "pr-2", "f1", "f2")
+
+	if err := r.Teams.SaveSettings(ctx, &entity.TeamSettings{TeamName: "backend", ReminderAfter: time.Hour, AutoReassign: true}); err != nil {
+		t.Fatalf("SaveSettings: %v", err)
+	}
+
+	if breaches, err := r.SLA.ListBreaches(ctx, entity.SLAReminder, time.Now()); err != nil || len(breaches) != 0 {
+		t.Fatalf("ListBreaches before threshold = %v, %v", breaches, err)
+	}
+
+	later := time.Now().Add(2 * time.Hour)
+	breaches, err := r.SLA.ListBreaches(ctx, entity.SLAReminder, later)
+	if err != nil || len(breaches) != 1 {
+		t.Fatalf("ListBreaches = %v, %v", breaches, err)
+	}
+	if b := breaches[0]; b.PR.ID != "pr-1" || b.OrgID != tenant.Default || !b.AutoReassign || b.Kind != entity.SLAReminder {
+		t.Errorf("breach = %+v", b)
+	}
+
+	// escalation is disabled for the team
+	if breaches, _ := r.SLA.ListBreaches(ctx, entity.SLAEscalation, later); len(breaches) != 0 {
+		t.Errorf("escalations = %v, want none", breaches)
+	}
+
+	for _, want := range []bool{true, false} {
+		if sent, err := r.SLA.MarkSent(ctx, "pr-1", entity.SLAReminder); err != nil || sent != want {
+			t.Fatalf("MarkSent = %v, %v, want %v", sent, err, want)
+		}
+	}
+	if breaches, _ := r.SLA.ListBreaches(ctx, entity.SLAReminder, later); len(breaches) != 0 {
+		t.Errorf("breaches after MarkSent = %v, want none", breaches)
+	}
+}
+
+// an old draft marked ready does not breach, the sla runs from the pr entering OPEN
+func testSLAOpenedAt(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1", "u2")
+	if err := r.Teams.SaveSettings(ctx, &entity.TeamSettings{TeamName: "backend", ReminderAfter: time.Hour}); err != nil {
+		t.Fatalf("SaveSettings: %v", err)
+	}
+	if err := r.PRs.Create(ctx, &entity.PullRequest{ID: "pr-1", Name: "draft", AuthorID: "u1", Status: entity.StatusDraft}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	// a draft never breaches, however old it is
+	if breaches, err := r.SLA.ListBreaches(ctx, entity.SLAReminder, time.Now().Add(2*time.Hour)); err != nil || len(breaches) != 0 {
+		t.Fatalf("ListBreaches of a draft = %v, %v", breaches, err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	opened := time.Now()
+	if err := r.PRs.SetReviewers(ctx, "pr-1", []string{"u2"}); err != nil {
+		t.Fatalf("SetReviewers: %v", err)
+	}
+	if _, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusOpen); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	// the draft is older than the threshold at this moment, the open pr is not
+	if breaches, err := r.SLA.ListBreaches(ctx, entity.SLAReminder, opened.Add(time.Hour-20*time.Millisecond)); err != nil || len(breaches) != 0 {
+		t.Errorf("ListBreaches right after ready = %v, %v, want none", breaches, err)
+	}
+	if breaches, err := r.SLA.ListBreaches(ctx, entity.SLAReminder, opened.Add(2*time.Hour)); err != nil || len(breaches) != 1 {
+		t.Errorf("ListBreaches after the threshold = %v, %v, want pr-1", breaches, err)
+	}
+
+	// a reviewer who responded stops the clock
+	if _, err := r.PRs.SubmitReview(ctx, "pr-1", "u2", entity.ReviewApproved); err != nil {
+		t.Fatalf("SubmitReview: %v", err)
+	}
+	if breaches, _ := r.SLA.ListBreaches(ctx, entity.SLAReminder, opened.Add(2*time.Hour)); len(breaches) != 0 {
+		t.Errorf("ListBreaches after the review = %v, want none", breaches)
+	}
+}
+
+func testTokens(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r, "backend", "u1")
+
+	now := time.Now().Truncate(time.Second)
+	tokens := []*entity.APIToken{
+		{ID: "t1", OrgID: tenant.Default, Name: "bot", Scopes: []entity.Scope{entity.ScopeRead}, CreatedAt: now},
+		{ID: "t2", OrgID: tenant.Default, Name: "alice", UserID: "u1", Scopes: []entity.Scope{entity.ScopePRWrite}, CreatedAt: now.Add(time.Second)},
+	}
+	for i, token := range tokens {
+		if err := r.Tokens.Create(ctx, token, tokenHash(token.ID)); err != nil {
+			t.Fatalf("Create token %d: %v", i, err)
+		}
+	}
+
+	got, err := r.Tokens.GetByHash(ctx, tokenHash("t2"))
+	if err != nil || got.ID != "t2" || got.UserID != "u1" || len(got.Scopes) != 1 || got.Scopes[0] != entity.ScopePRWrite {
+		t.Fatalf("GetByHash = %+v, %v", got, err)
+	}
+	if _, err := r.Tokens.GetByHash(ctx, tokenHash("nope")); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("GetByHash(nope) = %v, want ErrNotFound", err)
+	}
+
+	list, err := r.Tokens.List(ctx)
+	if err != nil || len(list) != 2 || list[0].ID != "t2" {
+		t.Fatalf("List = %v, %v, want newest first", list, err)
+	}
+
+	revoked, err := r.Tokens.Revoke(ctx, "t1")
+	if err != nil || revoked.RevokedAt == nil {
+		t.Fatalf("Revoke = %+v, %v", revoked, err)
+	}
+	again, err := r.Tokens.Revoke(ctx, "t1")
+	if err != nil || !again.RevokedAt.Equal(*revoked.RevokedAt) {
+		t.Errorf("second Revoke = %+v, %v, want first timestamp kept", again, err)
+	}
+	if _, err := r.Tokens.Revoke(tenant.WithOrg(ctx, "other"), "t1"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("Revoke from other org = %v, want ErrNotFound", err)
+	}
+}
+
+// tokenHash pads a name to the length of a sha256 hex digest
+func tokenHash(name string) string {
+	return name + strings.Repeat("0", 64-len(name))
+}
+
+func testIdempotency(t *testing.T, r Repositories) {
+	ctx := context.Background()
+	const ttl = time.Hour
+	// request hashes are sha256 hex digests
+	h1, h2 := strings.Repeat("1", 64), strings.Repeat("2", 64)
+	const p1, p2 = "user:u1", "token:t2"
+
+	existing, reserved, err := r.Idempotency.Reserve(ctx, p1, "k1", h1, ttl)
+	if err != nil || !reserved || existing != nil {
+		t.Fatalf("first Reserve = %v, %v, %v", existing, reserved, err)
+	}
+
+	existing, reserved, err = r.Idempotency.Reserve(ctx, p1, "k1", h1, ttl)
+	if err != nil || reserved || existing == nil || existing.CompletedAt != nil || existing.RequestHash != h1 {
+		t.Fatalf("Reserve in progress = %+v, %v, %v", existing, reserved, err)
+	}
+
+	if err := r.Idempotency.Complete(ctx, p1, "k1", 201, []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	existing, _, err = r.Idempotency.Reserve(ctx, p1, "k1", h1, ttl)
+	if err != nil || existing == nil || existing.StatusCode != 201 || string(existing.Body) != `{"ok":true}` || existing.CompletedAt == nil {
+		t.Fatalf("Reserve completed = %+v, %v", existing, err)
+	}
+
+	// completed keys survive release, unfinished ones are forgotten
+	if err := r.Idempotency.Release(ctx, p1, "k1"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, reserved, _ := r.Idempotency.Reserve(ctx, p1, "k1", h1, ttl); reserved {
+		t.Error("completed key was released")
+	}
+
+	if _, _, err := r.Idempotency.Reserve(ctx, p1, "k2", h2, ttl); err != nil {
+		t.Fatalf("Reserve k2: %v", err)
+	}
+	if err := r.Idempotency.Release(ctx, p1, "k2"); err != nil {
+		t.Fatalf("Release k2: %v", err)
+	}
+	if _, reserved, err := r.Idempotency.Reserve(ctx, p1, "k2", h2, ttl); err != nil || !reserved {
+		t.Errorf("Reserve after release = %v, %v, want reserved", reserved, err)
+	}
+
+	// keys are scoped to the organization
+	if _, reserved, err := r.Idempotency.Reserve(tenant.WithOrg(ctx, "other"), p1, "k1", h1, ttl); err != nil || !reserved {
+		t.Errorf("Reserve in other org = %v, %v, want reserved", reserved, err)
+	}
+	// and to the caller
+	if _, reserved, err := r.Idempotency.Reserve(ctx, p2, "k1", h2, ttl); err != nil || !reserved {
+		t.Errorf("Reserve by other principal = %v, %v, want reserved", reserved, err)
+	}
+
+	// the sweep removes keys of every organization older than ttl
+	if n, err := r.Idempotency.DeleteExpired(ctx, ttl); err != nil || n != 0 {
+		t.Errorf("DeleteExpired of fresh keys = %d, %v, want 0", n, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n, err := r.Idempotency.DeleteExpired(ctx, time.Millisecond); err != nil || n != 4 {
+		t.Errorf("DeleteExpired = %d, %v, want 4", n, err)
+	}
+	if _, reserved, err := r.Idempotency.Reserve(ctx, p1, "k1", h2, ttl); err != nil || !reserved {
+		t.Errorf("Reserve after sweep = %v, %v, want reserved", reserved, err)
+	}
+}
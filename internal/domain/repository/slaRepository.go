@@ -0,0 +1,14 @@
+// Package repository handles data persistence and retrieval
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+type SLARepository interface {
+	ListBreaches(ctx context.Context, kind entity.SLAKind, now time.Time) ([]*entity.SLABreach, error)
+	MarkSent(ctx context.Context, prID string, kind entity.SLAKind) (bool, error)
+}
@@ -10,4 +10,6 @@ import (
 type TeamRepository interface {
 	Create(ctx context.Context, team *entity.Team, users []*entity.User) error
 	GetByName(ctx context.Context, name string) (*entity.Team, error)
+	GetSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error)
+	SaveSettings(ctx context.Context, settings *entity.TeamSettings) error
 }
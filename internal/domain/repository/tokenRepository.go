@@ -0,0 +1,15 @@
+// Package repository handles data persistence and retrieval
+package repository
+
+import (
+	"context"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+type TokenRepository interface {
+	Create(ctx context.Context, token *entity.APIToken, tokenHash string) error
+	GetByHash(ctx context.Context, tokenHash string) (*entity.APIToken, error)
+	List(ctx context.Context) ([]*entity.APIToken, error)
+	Revoke(ctx context.Context, id string) (*entity.APIToken, error)
+}
@@ -0,0 +1,140 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+)
+
+// Authorizer applies role rules to the caller of a use case,
+// service principals (bots and operator tokens) pass the checks of ordinary users
+// and need the team:admin scope in place of the ADMIN role
+type Authorizer struct {
+	userRepo repository.UserRepository
+}
+
+// NewAuthorizer is the constructor for authorizer
+func NewAuthorizer(userRepo repository.UserRepository) *Authorizer {
+	return &Authorizer{userRepo: userRepo}
+}
+
+// CanCreateTeam allows creating teams with members to admin service principals only,
+// a user already belongs to a team and cannot bootstrap another one
+func (a *Authorizer) CanCreateTeam(ctx context.Context) error {
+	p := auth.FromContext(ctx)
+	if p.IsService() && p.IsAdmin() {
+		return nil
+	}
+	return entity.ErrForbidden
+}
+
+// CanManageTeam allows changing team membership and settings to admins of that team
+func (a *Authorizer) CanManageTeam(ctx context.Context, teamName string) error {
+	return a.requireTeamAdmin(ctx, auth.FromContext(ctx), teamName)
+}
+
+// CanSetIsActive allows users to change their own status,
+// deactivating others requires being an admin of their team
+func (a *Authorizer) CanSetIsActive(ctx context.Context, target *entity.User) error {
+	p := auth.FromContext(ctx)
+	if p.UserID != "" && p.UserID == target.ID {
+		return nil
+	}
+	return a.requireTeamAdmin(ctx, p, target.TeamName)
+}
+
+// CanCreate allows users to open pull requests only as their author
+func (a *Authorizer) CanCreate(ctx context.Context, authorID string) error {
+	p := auth.FromContext(ctx)
+	if p.IsService() || (p.UserID != "" && p.UserID == authorID) {
+		return nil
+	}
+	return entity.ErrForbidden
+}
+
+// CanReview allows reviewers to submit only their own review
+func (a *Authorizer) CanReview(ctx context.Context, reviewerID string) error {
+	p := auth.FromContext(ctx)
+	if p.IsService() || (p.UserID != "" && p.UserID == reviewerID) {
+		return nil
+	}
+	return entity.ErrForbidden
+}
+
+// CanChangeStatus allows marking ready, closing and reopening to the pr author,
+// admins of the author team and bots
+func (a *Authorizer) CanChangeStatus(ctx context.Context, pr *entity.PullRequest) error {
+	p := auth.FromContext(ctx)
+	if p.IsService() || (p.UserID != "" && p.UserID == pr.AuthorID) {
+		return nil
+	}
+	return a.requireAuthorTeamAdmin(ctx, p, pr)
+}
+
+// CanMerge allows merging to the pr author and bots
+func (a *Authorizer) CanMerge(ctx context.Context, pr *entity.PullRequest) error {
+	p := auth.FromContext(ctx)
+	if p.IsService() || (p.UserID != "" && p.UserID == pr.AuthorID) {
+		return nil
+	}
+	return entity.ErrForbidden
+}
+
+// CanForceMerge allows skipping the merge policy to admins of the author team
+func (a *Authorizer) CanForceMerge(ctx context.Context, pr *entity.PullRequest) error {
+	return a.requireAuthorTeamAdmin(ctx, auth.FromContext(ctx), pr)
+}
+
+// CanReassign allows reviewers to hand off only their own review
+func (a *Authorizer) CanReassign(ctx context.Context, oldReviewerID string) error {
+	p := auth.FromContext(ctx)
+	if p.IsService() || (p.UserID != "" && p.UserID == oldReviewerID) {
+		return nil
+	}
+	return entity.ErrForbidden
+}
+
+// requireAuthorTeamAdmin checks that the caller is an admin of the team of the pr author
+func (a *Authorizer) requireAuthorTeamAdmin(ctx context.Context, p auth.Principal, pr *entity.PullRequest) error {
+	if p.IsService() && p.IsAdmin() {
+		return nil
+	}
+	if p.UserID == "" {
+		return entity.ErrForbidden
+	}
+
+	author, err := a.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return fmt.Errorf("failed to load author of PR %s: %w", pr.ID, err)
+	}
+	return a.requireTeamAdmin(ctx, p, author.TeamName)
+}
+
+// requireTeamAdmin checks that the caller is an admin member of the team,
+// a service principal has to hold the team:admin scope
+func (a *Authorizer) requireTeamAdmin(ctx context.Context, p auth.Principal, teamName string) error {
+	if p.IsService() && p.IsAdmin() {
+		return nil
+	}
+	if p.UserID == "" {
+		return entity.ErrForbidden
+	}
+
+	caller, err := a.userRepo.GetByID(ctx, p.UserID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return entity.ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+
+	if caller.TeamName != teamName || caller.Role != entity.RoleAdmin {
+		return entity.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/memory"
+)
+
+func TestAuthorizer_Roles(t *testing.T) {
+	store := memory.NewStore()
+	teamRepo, userRepo := memory.NewTeamRepository(store), memory.NewUserRepository(store)
+
+	ctx := auth.WithPrincipal(context.Background(), auth.System())
+	users := []*entity.User{
+		{ID: "u1", Username: "alice", IsActive: true, Role: entity.RoleAdmin},
+		{ID: "u2", Username: "bob", IsActive: true},
+	}
+	if err := teamRepo.Create(ctx, &entity.Team{Name: "backend"}, users); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := teamRepo.Create(ctx, &entity.Team{Name: "frontend"}, []*entity.User{{ID: "u3", Username: "carol", IsActive: true, Role: entity.RoleAdmin}}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	authz := services.NewAuthorizer(userRepo)
+	pr := &entity.PullRequest{ID: "pr-1", AuthorID: "u2"}
+	bob := &entity.User{ID: "u2", TeamName: "backend"}
+
+	// sso users and user tokens hold pr:write, rights beyond it come from the team role
+	tests := []struct {
+		name    string
+		caller  auth.Principal
+		check   func(ctx context.Context) error
+		wantErr error
+	}{
+		{"user deactivates self", asPrincipal("u2"), func(ctx context.Context) error { return authz.CanSetIsActive(ctx, bob) }, nil},
+		{"team admin deactivates member", asPrincipal("u1"), func(ctx context.Context) error { return authz.CanSetIsActive(ctx, bob) }, nil},
+		{"admin of another team deactivates member", asPrincipal("u3"), func(ctx context.Context) error { return authz.CanSetIsActive(ctx, bob) }, entity.ErrForbidden},
+		{"team admin manages team", asPrincipal("u1"), func(ctx context.Context) error { return authz.CanManageTeam(ctx, "backend") }, nil},
+		{"member manages team", asPrincipal("u2"), func(ctx context.Context) error { return authz.CanManageTeam(ctx, "backend") }, entity.ErrForbidden},
+		{"bot manages team", botPrincipal(entity.ScopePRWrite), func(ctx context.Context) error { return authz.CanManageTeam(ctx, "backend") }, entity.ErrForbidden},
+		{"admin bot manages team", botPrincipal(entity.ScopeTeamAdmin), func(ctx context.Context) error { return authz.CanManageTeam(ctx, "backend") }, nil},
+		{"bot creates team", botPrincipal(entity.ScopePRWrite), authz.CanCreateTeam, entity.ErrForbidden},
+		{"admin bot creates team", botPrincipal(entity.ScopeTeamAdmin), authz.CanCreateTeam, nil},
+		{"team admin force merges", asPrincipal("u1"), func(ctx context.Context) error { return authz.CanForceMerge(ctx, pr) }, nil},
+		{"author force merges", asPrincipal("u2"), func(ctx context.Context) error { return authz.CanForceMerge(ctx, pr) }, entity.ErrForbidden},
+		{"admin of another team force merges", asPrincipal("u3"), func(ctx context.Context) error { return authz.CanForceMerge(ctx, pr) }, entity.ErrForbidden},
+		{"member token with team:admin scope force merges", auth.Principal{TokenID: "t", UserID: "u2", Scopes: []entity.Scope{entity.ScopeTeamAdmin}},
+			func(ctx context.Context) error { return authz.CanForceMerge(ctx, pr) }, entity.ErrForbidden},
+		{"admin bot force merges", botPrincipal(entity.ScopeTeamAdmin), func(ctx context.Context) error { return authz.CanForceMerge(ctx, pr) }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check(auth.WithPrincipal(context.Background(), tt.caller))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// asPrincipal returns a user-bound principal with the pr:write scope
+func asPrincipal(userID string) auth.Principal {
+	return auth.Principal{TokenID: "t-" + userID, UserID: userID, Scopes: []entity.Scope{entity.ScopePRWrite}}
+}
+
+// botPrincipal returns a service principal with the given scope
+func botPrincipal(scope entity.Scope) auth.Principal {
+	return auth.Principal{TokenID: "bot", Scopes: []entity.Scope{scope}}
+}
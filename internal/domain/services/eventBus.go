@@ -0,0 +1,178 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// subscriberBuffer is the number of undelivered events after which a slow subscriber is disconnected
+const subscriberBuffer = 64
+
+// EventPublisher delivers pull request events to live subscribers
+type EventPublisher interface {
+	Publish(events ...entity.Event)
+}
+
+// NopPublisher is used when nobody subscribes to events
+type NopPublisher struct{}
+
+// Publish does nothing
+func (NopPublisher) Publish(_ ...entity.Event) {}
+
+// Subscription is a live feed of the events of one user
+type Subscription struct {
+	// retained events after the resume id, oldest first
+	Missed []entity.Event
+	// events after the resume id were evicted or published by another process,
+	// the subscriber has to reload its state
+	Gap bool
+	// id of the last published event at subscription time
+	LastID uint64
+	// closed when the subscription is cancelled or the subscriber falls behind
+	Events <-chan entity.Event
+
+	cancel func()
+}
+
+// Close stops the delivery of events
+func (s *Subscription) Close() {
+	s.cancel()
+}
+
+// subscriber is a registered receiver of the events of one user
+type subscriber struct {
+	orgID  string
+	userID string
+	ch     chan entity.Event
+}
+
+// EventBus is an in-process event bus retaining the last events for resume,
+// events are not shared between instances of the service
+type EventBus struct {
+	mu      sync.Mutex
+	lastID  uint64
+	history []entity.Event
+	size    int
+	subs    map[*subscriber]struct{}
+	closed  bool
+}
+
+// NewEventBus creates a bus retaining up to history events for resume
+func NewEventBus(history int) *EventBus {
+	return &EventBus{
+		// ids start at the startup time so that a restarted process never repeats them
+		lastID:  uint64(time.Now().UnixMicro()),
+		history: make([]entity.Event, 0, history),
+		size:    history,
+		subs:    make(map[*subscriber]struct{}),
+	}
+}
+
+// Publish assigns ids to the events, retains them and hands them to subscribers
+// of their recipients, it never blocks on a subscriber
+func (b *EventBus) Publish(events ...entity.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, e := range events {
+		b.lastID++
+		e.ID = b.lastID
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = time.Now()
+		}
+
+		if b.size > 0 {
+			if len(b.history) == b.size {
+				copy(b.history, b.history[1:])
+				b.history = b.history[:b.size-1]
+			}
+			b.history = append(b.history, e)
+		}
+
+		for s := range b.subs {
+			if s.orgID != e.OrgID || s.userID != e.UserID {
+				continue
+			}
+			select {
+			case s.ch <- e:
+			default:
+				// the subscriber resumes from history after reconnecting with its last id
+				b.unsubscribe(s)
+			}
+		}
+	}
+}
+
+// Subscribe registers a receiver of the events of a user, a non-zero lastEventID
+// returns retained events published after it
+func (b *EventBus) Subscribe(orgID, userID string, lastEventID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &Subscription{LastID: b.lastID}
+	if lastEventID != 0 {
+		oldest := b.lastID + 1
+		if len(b.history) > 0 {
+			oldest = b.history[0].ID
+		}
+		sub.Gap = lastEventID+1 < oldest || lastEventID > b.lastID
+
+		for _, e := range b.history {
+			if e.ID > lastEventID && e.OrgID == orgID && e.UserID == userID {
+				sub.Missed = append(sub.Missed, e)
+			}
+		}
+	}
+
+	s := &subscriber{orgID: orgID, userID: userID, ch: make(chan entity.Event, subscriberBuffer)}
+	sub.Events = s.ch
+	if b.closed {
+		close(s.ch)
+		sub.cancel = func() {}
+		return sub
+	}
+	b.subs[s] = struct{}{}
+	sub.cancel = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(s)
+	}
+	return sub
+}
+
+// Close disconnects all subscribers so that streams end before the server drains,
+// later subscriptions are closed right away
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for s := range b.subs {
+		b.unsubscribe(s)
+	}
+}
+
+// unsubscribe removes a subscriber and closes its channel, the caller holds the lock
+func (b *EventBus) unsubscribe(s *subscriber) {
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.ch)
+	}
+}
+
+// reviewerEvents builds one event of the given type per reviewer of a pr
+func reviewerEvents(ctx context.Context, eventType entity.EventType, pr *entity.PullRequest, reviewers []entity.User) []entity.Event {
+	orgID := tenant.FromContext(ctx)
+	now := time.Now()
+
+	events := make([]entity.Event, 0, len(reviewers))
+	for _, rev := range reviewers {
+		events = append(events, entity.Event{OrgID: orgID, UserID: rev.ID, Type: eventType, PR: pr, CreatedAt: now})
+	}
+	return events
+}
@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+)
+
+func event(userID string, eventType entity.EventType) entity.Event {
+	return entity.Event{OrgID: "default", UserID: userID, Type: eventType, PR: &entity.PullRequest{ID: "pr-1"}}
+}
+
+func TestEventBus_Deliver(t *testing.T) {
+	bus := services.NewEventBus(10)
+	sub := bus.Subscribe("default", "u1", 0)
+	defer sub.Close()
+
+	bus.Publish(event("u2", entity.EventAssigned), event("u1", entity.EventAssigned))
+	other := entity.Event{OrgID: "acme", UserID: "u1", Type: entity.EventMerged}
+	bus.Publish(other)
+
+	got := <-sub.Events
+	if got.UserID != "u1" || got.Type != entity.EventAssigned || got.ID != sub.LastID+2 {
+		t.Fatalf("delivered %+v, want the second event for u1", got)
+	}
+	select {
+	case e := <-sub.Events:
+		t.Fatalf("event of another user or org delivered: %+v", e)
+	default:
+	}
+
+	sub.Close()
+	if _, ok := <-sub.Events; ok {
+		t.Fatal("events channel is open after close")
+	}
+}
+
+func TestEventBus_Resume(t *testing.T) {
+	bus := services.NewEventBus(3)
+	start := bus.Subscribe("default", "u1", 0)
+	start.Close()
+
+	bus.Publish(event("u1", entity.EventAssigned), event("u2", entity.EventAssigned), event("u1", entity.EventMerged))
+
+	sub := bus.Subscribe("default", "u1", start.LastID)
+	defer sub.Close()
+	if sub.Gap || len(sub.Missed) != 2 || sub.Missed[0].Type != entity.EventAssigned || sub.Missed[1].Type != entity.EventMerged {
+		t.Fatalf("resume: gap %t, missed %+v", sub.Gap, sub.Missed)
+	}
+
+	// the first event is evicted from the history of three
+	bus.Publish(event("u2", entity.EventUnassigned))
+	evicted := bus.Subscribe("default", "u1", start.LastID)
+	defer evicted.Close()
+	if !evicted.Gap {
+		t.Error("resume from an evicted id is not a gap")
+	}
+
+	// ids of another process are unknown
+	future := bus.Subscribe("default", "u1", evicted.LastID+100)
+	defer future.Close()
+	if !future.Gap || len(future.Missed) != 0 {
+		t.Errorf("resume from a future id: gap %t, missed %d", future.Gap, len(future.Missed))
+	}
+}
+
+func TestEventBus_SlowSubscriber(t *testing.T) {
+	bus := services.NewEventBus(0)
+	sub := bus.Subscribe("default", "u1", 0)
+	defer sub.Close()
+
+	for range 100 {
+		bus.Publish(event("u1", entity.EventAssigned))
+	}
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	if received == 0 || received >= 100 {
+		t.Fatalf("slow subscriber received %d events before disconnect", received)
+	}
+}
+
+func TestEventBus_Close(t *testing.T) {
+	bus := services.NewEventBus(10)
+	sub := bus.Subscribe("default", "u1", 0)
+	defer sub.Close()
+
+	bus.Close()
+	if _, ok := <-sub.Events; ok {
+		t.Fatal("events channel is open after the bus is closed")
+	}
+
+	late := bus.Subscribe("default", "u1", 0)
+	defer late.Close()
+	if _, ok := <-late.Events; ok {
+		t.Fatal("subscription to a closed bus is open")
+	}
+}
@@ -0,0 +1,34 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// EventService streams pull request events of a user
+type EventService interface {
+	Subscribe(ctx context.Context, userID string, lastEventID uint64) (*Subscription, error)
+}
+
+// EventUseCase implements the eventservice interface on top of the in-process bus
+type EventUseCase struct {
+	bus      *EventBus
+	userRepo repository.UserRepository
+}
+
+// NewEventUseCase is the constructor for eventusecase
+func NewEventUseCase(bus *EventBus, userRepo repository.UserRepository) *EventUseCase {
+	return &EventUseCase{bus: bus, userRepo: userRepo}
+}
+
+// Subscribe starts a feed of the events of a user of the current organization,
+// the caller must close the subscription
+func (uc *EventUseCase) Subscribe(ctx context.Context, userID string, lastEventID uint64) (*Subscription, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return uc.bus.Subscribe(tenant.FromContext(ctx), userID, lastEventID), nil
+}
@@ -0,0 +1,50 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+)
+
+// IdempotencySweeper periodically removes expired idempotency keys, expired keys are
+// already ignored by Reserve but would otherwise stay in storage forever
+type IdempotencySweeper struct {
+	repo     repository.IdempotencyRepository
+	ttl      time.Duration
+	interval time.Duration
+}
+
+// NewIdempotencySweeper is the constructor for idempotencysweeper
+func NewIdempotencySweeper(repo repository.IdempotencyRepository, ttl, interval time.Duration) *IdempotencySweeper {
+	return &IdempotencySweeper{repo: repo, ttl: ttl, interval: interval}
+}
+
+// Run sweeps on every tick until the context is canceled
+func (s *IdempotencySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce removes the keys older than ttl in all organizations
+func (s *IdempotencySweeper) RunOnce(ctx context.Context) {
+	deleted, err := s.repo.DeleteExpired(ctx, s.ttl)
+	if err != nil {
+		slog.Error("Failed to delete expired idempotency keys", "error", err)
+		return
+	}
+	if deleted > 0 {
+		slog.Info("Expired idempotency keys deleted", "count", deleted)
+	}
+}
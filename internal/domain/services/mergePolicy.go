@@ -0,0 +1,49 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// evaluateMergePolicy returns human readable descriptions of the unmet policy conditions,
+// the required number of approvals is capped at the number of assigned reviewers,
+// so a small team is not blocked forever, but approvals can't be required from nobody
+func evaluateMergePolicy(policy entity.MergePolicy, pr *entity.PullRequest) []string {
+	if len(pr.Reviewers) == 0 {
+		if policy.MinApprovals > 0 || policy.RequireAllApproved {
+			return []string{"approvals required, but no reviewers are assigned"}
+		}
+		return nil
+	}
+
+	var approvals int
+	var notApproved, changesRequested []string
+
+	for _, review := range pr.Reviews {
+		switch review.State {
+		case entity.ReviewApproved:
+			approvals++
+		case entity.ReviewChangesRequested:
+			changesRequested = append(changesRequested, review.ReviewerID)
+			notApproved = append(notApproved, review.ReviewerID)
+		default:
+			notApproved = append(notApproved, review.ReviewerID)
+		}
+	}
+
+	var unmet []string
+	if required := min(policy.MinApprovals, len(pr.Reviewers)); approvals < required {
+		unmet = append(unmet, fmt.Sprintf("at least %d approvals required, got %d", required, approvals))
+	}
+	if policy.RequireAllApproved && len(notApproved) > 0 {
+		unmet = append(unmet, "all assigned reviewers must approve, not approved by: "+strings.Join(notApproved, ", "))
+	}
+	if policy.BlockOnChangesRequested && len(changesRequested) > 0 {
+		unmet = append(unmet, "changes requested by: "+strings.Join(changesRequested, ", "))
+	}
+
+	return unmet
+}
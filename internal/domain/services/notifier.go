@@ -0,0 +1,102 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// Notifier delivers assignment and sla messages to reviewers through an external channel
+type Notifier interface {
+	NotifyAssigned(ctx context.Context, pr *entity.PullRequest, reviewers []entity.User) error
+	NotifyReminder(ctx context.Context, pr *entity.PullRequest, reviewers []entity.User, kind entity.SLAKind) error
+}
+
+// NopNotifier is used when no notification channel is configured
+type NopNotifier struct{}
+
+// NotifyAssigned does nothing
+func (NopNotifier) NotifyAssigned(_ context.Context, _ *entity.PullRequest, _ []entity.User) error {
+	return nil
+}
+
+// NotifyReminder does nothing
+func (NopNotifier) NotifyReminder(_ context.Context, _ *entity.PullRequest, _ []entity.User, _ entity.SLAKind) error {
+	return nil
+}
+
+// AsyncNotifier delivers assignment notifications in background so that a slow or
+// unavailable chat service never delays or fails the api call, Drain waits for them on shutdown
+type AsyncNotifier struct {
+	next Notifier
+
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	drained bool
+}
+
+// NewAsyncNotifier wraps next with background delivery
+func NewAsyncNotifier(next Notifier) *AsyncNotifier {
+	return &AsyncNotifier{next: next}
+}
+
+// NotifyAssigned starts the delivery and returns, once draining has begun it delivers inline
+func (n *AsyncNotifier) NotifyAssigned(ctx context.Context, pr *entity.PullRequest, reviewers []entity.User) error {
+	n.mu.Lock()
+	if n.drained {
+		n.mu.Unlock()
+		return n.next.NotifyAssigned(ctx, pr, reviewers)
+	}
+	n.wg.Add(1)
+	n.mu.Unlock()
+
+	// detach from request cancellation, the response may be sent before delivery
+	bgCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer n.wg.Done()
+		if err := n.next.NotifyAssigned(bgCtx, pr, reviewers); err != nil {
+			slog.Error("Failed to notify reviewers", "pr_id", pr.ID, "error", err)
+		}
+	}()
+	return nil
+}
+
+// NotifyReminder delivers inline, the sla scheduler already runs in background
+func (n *AsyncNotifier) NotifyReminder(ctx context.Context, pr *entity.PullRequest, reviewers []entity.User, kind entity.SLAKind) error {
+	return n.next.NotifyReminder(ctx, pr, reviewers, kind)
+}
+
+// Drain waits for the deliveries in flight until ctx is done
+func (n *AsyncNotifier) Drain(ctx context.Context) error {
+	n.mu.Lock()
+	n.drained = true
+	n.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// notifyAssigned sends the notification, a failed delivery never fails the api call,
+// the notifier is wrapped in AsyncNotifier so that it does not delay the call either
+func notifyAssigned(ctx context.Context, n Notifier, pr *entity.PullRequest, reviewers []entity.User) {
+	if n == nil || pr == nil || len(reviewers) == 0 {
+		return
+	}
+
+	if err := n.NotifyAssigned(ctx, pr, reviewers); err != nil {
+		slog.Error("Failed to notify reviewers", "pr_id", pr.ID, "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+)
+
+// blockingNotifier holds every delivery until released
+type blockingNotifier struct {
+	services.NopNotifier
+	release   chan struct{}
+	delivered chan string
+}
+
+func (n *blockingNotifier) NotifyAssigned(_ context.Context, pr *entity.PullRequest, _ []entity.User) error {
+	<-n.release
+	n.delivered <- pr.ID
+	return nil
+}
+
+func TestAsyncNotifier_Drain(t *testing.T) {
+	next := &blockingNotifier{release: make(chan struct{}), delivered: make(chan string, 2)}
+	n := services.NewAsyncNotifier(next)
+	reviewers := []entity.User{{ID: "u2"}}
+
+	// the caller does not wait for the delivery, nor for a canceled request context
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := n.NotifyAssigned(ctx, &entity.PullRequest{ID: "pr-1"}, reviewers); err != nil {
+		t.Fatalf("NotifyAssigned: %v", err)
+	}
+	cancel()
+
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	if err := n.Drain(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Drain with a delivery in flight = %v, want deadline exceeded", err)
+	}
+
+	close(next.release)
+	if err := n.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if id := <-next.delivered; id != "pr-1" {
+		t.Errorf("delivered %s, want pr-1", id)
+	}
+
+	// once draining has begun deliveries are inline
+	if err := n.NotifyAssigned(context.Background(), &entity.PullRequest{ID: "pr-2"}, reviewers); err != nil {
+		t.Fatalf("NotifyAssigned after drain: %v", err)
+	}
+	select {
+	case id := <-next.delivered:
+		if id != "pr-2" {
+			t.Errorf("delivered %s, want pr-2", id)
+		}
+	default:
+		t.Error("notification after drain was not delivered inline")
+	}
+}
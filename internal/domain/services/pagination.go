@@ -0,0 +1,18 @@
+// Package services implements business logic and domain rules
+package services
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// normalizePageSize applies the default page size and caps too large requests
+func normalizePageSize(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	if limit > maxPageSize {
+		return maxPageSize
+	}
+	return limit
+}
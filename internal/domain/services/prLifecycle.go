@@ -0,0 +1,102 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// prTransitions lists allowed pull request status transitions, merged is final
+var prTransitions = map[entity.PRStatus][]entity.PRStatus{
+	entity.StatusDraft:  {entity.StatusOpen, entity.StatusClosed},
+	entity.StatusOpen:   {entity.StatusMerged, entity.StatusClosed},
+	entity.StatusClosed: {entity.StatusOpen},
+}
+
+// canTransition reports whether the status change is allowed
+func canTransition(from, to entity.PRStatus) bool {
+	return slices.Contains(prTransitions[from], to)
+}
+
+// MarkReady moves a draft to open and assigns reviewers
+func (uc *PRUseCase) MarkReady(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error) {
+	return uc.transition(ctx, prID, entity.StatusDraft, entity.StatusOpen, expectedVersion)
+}
+
+// Close abandons a draft or open pr without merging, closing twice is a no-op
+func (uc *PRUseCase) Close(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error) {
+	return uc.transition(ctx, prID, "", entity.StatusClosed, expectedVersion)
+}
+
+// Reopen moves a closed pr back to open
+func (uc *PRUseCase) Reopen(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error) {
+	return uc.transition(ctx, prID, entity.StatusClosed, entity.StatusOpen, expectedVersion)
+}
+
+// transition changes the pr status, an empty from accepts any allowed source status;
+// a pr that becomes open without reviewers gets them assigned
+func (uc *PRUseCase) transition(ctx context.Context, prID string, from, to entity.PRStatus, expectedVersion int) (*entity.PullRequest, error) {
+	var updatedPR *entity.PullRequest
+	var assigned []entity.User
+
+	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
+		pr, err := uc.prRepo.GetByIDForUpdate(txCtx, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(pr, expectedVersion); err != nil {
+			return err
+		}
+
+		// only the author, an admin of the author team or a bot may move the pr
+		if err := uc.authz.CanChangeStatus(txCtx, pr); err != nil {
+			return err
+		}
+
+		// repeated close is idempotent like merge
+		if to == entity.StatusClosed && pr.Status == entity.StatusClosed {
+			updatedPR = pr
+			return nil
+		}
+
+		if (from != "" && pr.Status != from) || !canTransition(pr.Status, to) {
+			return fmt.Errorf("%s -> %s: %w", pr.Status, to, entity.ErrInvalidTransition)
+		}
+
+		if to != entity.StatusOpen || len(pr.Reviewers) > 0 {
+			// the repository returns the full entity with the author and reviewers
+			updatedPR, err = uc.prRepo.UpdateStatus(txCtx, prID, to)
+			return err
+		}
+
+		author, err := uc.userRepo.GetByID(txCtx, pr.AuthorID)
+		if err != nil {
+			return fmt.Errorf("failed to load author of PR %s: %w", prID, err)
+		}
+		if assigned, err = uc.selectInitialReviewers(txCtx, author); err != nil {
+			return err
+		}
+
+		reviewerIDs := make([]string, len(assigned))
+		for i, rev := range assigned {
+			reviewerIDs[i] = rev.ID
+		}
+
+		// reviewers and status are one change with a single version bump
+		updatedPR, err = uc.prRepo.UpdateStatusAndReviewers(txCtx, prID, to, reviewerIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// notify reviewers assigned by this transition after commit
+	notifyAssigned(ctx, uc.notifier, updatedPR, assigned)
+	uc.events.Publish(reviewerEvents(ctx, entity.EventAssigned, updatedPR, assigned)...)
+
+	return updatedPR, nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
@@ -13,31 +14,53 @@ import (
 
 // PRService defines the interface for pull request operations
 type PRService interface {
-	Create(ctx context.Context, prID, prName, authorID string) (*entity.PullRequest, error)
-	Merge(ctx context.Context, prID string) (*entity.PullRequest, error)
-	Reassign(ctx context.Context, prID, oldReviewerID string) (*entity.PullRequest, string, error)
+	Create(ctx context.Context, prID, prName, authorID string, draft bool) (*entity.PullRequest, error)
+	Merge(ctx context.Context, prID string, force bool, expectedVersion int) (*entity.PullRequest, error)
+	MarkReady(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error)
+	Close(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error)
+	Reopen(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error)
+	Reassign(ctx context.Context, prID, oldReviewerID string, expectedVersion int) (*entity.PullRequest, string, error)
+	ReplaceReviewers(ctx context.Context, prID string, oldReviewerIDs []string) (*ReviewerReplacement, error)
+	PublishReplacement(ctx context.Context, replacement *ReviewerReplacement)
+	SubmitReview(ctx context.Context, prID, reviewerID string, action entity.ReviewAction, expectedVersion int) (*entity.Review, error)
+	List(ctx context.Context, filter entity.PRFilter) (*entity.PRPage, error)
+	Get(ctx context.Context, prID string) (*entity.PullRequest, error)
 }
 
 // PRUseCase implements the prservice interface
 type PRUseCase struct {
 	prRepo     repository.PRRepository
 	userRepo   repository.UserRepository
+	teamRepo   repository.TeamRepository
 	transactor repository.Transactor
 	assigner   *Assigner
+	notifier   Notifier
+	events     EventPublisher
+	authz      *Authorizer
 }
 
 // NewPRUseCase is the constructor for prusecase
-func NewPRUseCase(prRepo repository.PRRepository, userRepo repository.UserRepository, transactor repository.Transactor, assigner *Assigner) *PRUseCase {
+func NewPRUseCase(prRepo repository.PRRepository, userRepo repository.UserRepository, teamRepo repository.TeamRepository, transactor repository.Transactor, assigner *Assigner, notifier Notifier, events EventPublisher, authz *Authorizer) *PRUseCase {
 	return &PRUseCase{
 		prRepo:     prRepo,
 		userRepo:   userRepo,
+		teamRepo:   teamRepo,
 		transactor: transactor,
 		assigner:   assigner,
+		notifier:   notifier,
+		events:     events,
+		authz:      authz,
 	}
 }
 
-// Create handles the creation of a pr and initial reviewer assignment
-func (uc *PRUseCase) Create(ctx context.Context, prID, prName, authorID string) (*entity.PullRequest, error) {
+// Create handles the creation of a pr and initial reviewer assignment,
+// drafts get no reviewers until they are marked ready
+func (uc *PRUseCase) Create(ctx context.Context, prID, prName, authorID string, draft bool) (*entity.PullRequest, error) {
+	// users open pull requests on their own behalf only
+	if err := uc.authz.CanCreate(ctx, authorID); err != nil {
+		return nil, err
+	}
+
 	var createdPR *entity.PullRequest
 
 	// wrap all database operations in a transaction
@@ -51,91 +74,182 @@ func (uc *PRUseCase) Create(ctx context.Context, prID, prName, authorID string)
 			return err
 		}
 
-		// get active candidates from the author's team, excluding the author
-		candidates, err := uc.userRepo.GetActiveCandidatesByTeam(txCtx, author.TeamName, authorID)
-		if err != nil {
-			return err
+		status := entity.StatusDraft
+		var reviewers []entity.User
+		if !draft {
+			status = entity.StatusOpen
+			if reviewers, err = uc.selectInitialReviewers(txCtx, author); err != nil {
+				return err
+			}
 		}
 
-		// select up to 2 reviewers randomly
-		reviewers := uc.assigner.SelectReviewers(candidates, 2)
-
 		// build the new pull request entity
 		pr := &entity.PullRequest{
 			ID:        prID,
 			Name:      prName,
 			AuthorID:  authorID,
-			Status:    entity.StatusOpen,
+			Status:    status,
 			Reviewers: reviewers,
 			CreatedAt: time.Now(),
 		}
 
 		// save the pr and its reviewers to the database
 		if err := uc.prRepo.Create(txCtx, pr); err != nil {
+			if errors.Is(err, entity.ErrPRExists) {
+				// keep the repository op out of the api message
+				return entity.ErrPRExists
+			}
 			return err
 		}
 
 		createdPR = pr
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// notify assigned reviewers only after the transaction is committed
+	notifyAssigned(ctx, uc.notifier, createdPR, createdPR.Reviewers)
+	uc.events.Publish(reviewerEvents(ctx, entity.EventAssigned, createdPR, createdPR.Reviewers)...)
 
-	return createdPR, err
+	return createdPR, nil
 }
 
-// Merge sets the pr status to merged
-func (uc *PRUseCase) Merge(ctx context.Context, prID string) (*entity.PullRequest, error) {
-	// check if pr exists
-	pr, err := uc.prRepo.GetByID(ctx, prID)
+// selectInitialReviewers picks up to entity.MaxReviewers active reviewers from the author's team
+func (uc *PRUseCase) selectInitialReviewers(ctx context.Context, author *entity.User) ([]entity.User, error) {
+	// get active candidates from the author's team, excluding the author
+	candidates, err := uc.userRepo.GetActiveCandidatesByTeam(ctx, author.TeamName, author.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	// exit early if already merged (idempotency)
-	if pr.Status == entity.StatusMerged {
-		return pr, nil
-	}
+	// select reviewers randomly
+	return uc.assigner.SelectReviewers(candidates, entity.MaxReviewers), nil
+}
+
+// Merge sets the pr status to merged once the author team's merge policy is met,
+// force skips the policy check and is allowed for admins of the author team only
+func (uc *PRUseCase) Merge(ctx context.Context, prID string, force bool, expectedVersion int) (*entity.PullRequest, error) {
+	var mergedPR *entity.PullRequest
+	var merged bool // false when the pr was merged before
+
+	// policy check and status update must see the same review state
+	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
+		// check if pr exists and lock it against concurrent writers
+		pr, err := uc.prRepo.GetByIDForUpdate(txCtx, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(pr, expectedVersion); err != nil {
+			return err
+		}
 
-	// update the status to merged
-	mergedPR, err := uc.prRepo.UpdateStatus(ctx, prID, entity.StatusMerged)
+		// only the author or a bot may merge, a forced merge is up to the team admins
+		if force {
+			err = uc.authz.CanForceMerge(txCtx, pr)
+		} else {
+			err = uc.authz.CanMerge(txCtx, pr)
+		}
+		if err != nil {
+			return err
+		}
+
+		// exit early if already merged (idempotency)
+		if pr.Status == entity.StatusMerged {
+			mergedPR = pr
+			return nil
+		}
+
+		// drafts and closed prs cannot be merged
+		if !canTransition(pr.Status, entity.StatusMerged) {
+			return fmt.Errorf("%s -> %s: %w", pr.Status, entity.StatusMerged, entity.ErrInvalidTransition)
+		}
+
+		if force {
+			slog.Warn("Merging PR bypassing team merge policy", "pr_id", prID)
+		} else if err := uc.checkMergePolicy(txCtx, pr); err != nil {
+			return err
+		}
+
+		// update the status to merged, the repository returns the full entity
+		mergedPR, err = uc.prRepo.UpdateStatus(txCtx, prID, entity.StatusMerged)
+		merged = err == nil
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// load reviewers for the final response entity
-	// since update status might not return the full pr struct
-	reviewersPtrs, err := uc.prRepo.GetReviewersByPRID(ctx, prID)
+	if merged {
+		uc.events.Publish(reviewerEvents(ctx, entity.EventMerged, mergedPR, mergedPR.Reviewers)...)
+	}
+
+	return mergedPR, nil
+}
+
+// checkVersion compares the pr version with the one the caller has seen,
+// zero means the caller did not send a version and skips the check
+func checkVersion(pr *entity.PullRequest, expected int) error {
+	if expected != 0 && pr.Version != expected {
+		return fmt.Errorf("%w: expected version %d, current %d", entity.ErrConflict, expected, pr.Version)
+	}
+	return nil
+}
+
+// checkMergePolicy evaluates the merge policy of the author's team
+func (uc *PRUseCase) checkMergePolicy(ctx context.Context, pr *entity.PullRequest) error {
+	author, err := uc.userRepo.GetByID(ctx, pr.AuthorID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load reviewers for merged PR %s: %w", prID, err)
+		return fmt.Errorf("failed to load author of PR %s: %w", pr.ID, err)
 	}
 
-	// convert from pointer slice to value slice
-	reviewers := make([]entity.User, len(reviewersPtrs))
-	for i, r := range reviewersPtrs {
-		reviewers[i] = *r
+	settings, err := uc.teamRepo.GetSettings(ctx, author.TeamName)
+	if err != nil {
+		return err
 	}
 
-	mergedPR.Reviewers = reviewers // attach reviewers to the response entity
-	return mergedPR, nil
+	if unmet := evaluateMergePolicy(settings.MergePolicy, pr); len(unmet) > 0 {
+		return &entity.MergeBlockedError{Unmet: unmet}
+	}
+
+	return nil
 }
 
 // Reassign replaces one reviewer with a random new one from the same team
-func (uc *PRUseCase) Reassign(ctx context.Context, prID, oldReviewerID string) (*entity.PullRequest, string, error) {
+func (uc *PRUseCase) Reassign(ctx context.Context, prID, oldReviewerID string, expectedVersion int) (*entity.PullRequest, string, error) {
+	// reviewers may hand off only their own review
+	if err := uc.authz.CanReassign(ctx, oldReviewerID); err != nil {
+		return nil, "", err
+	}
+
 	var newReviewerID string
 	var updatedPR *entity.PullRequest
+	var removed entity.User
 
 	// wrap all operations in a transaction
 	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
-		// load pr
-		pr, err := uc.prRepo.GetByID(txCtx, prID)
+		// load pr and lock it, concurrent reassigns would otherwise overwrite each other's reviewers
+		pr, err := uc.prRepo.GetByIDForUpdate(txCtx, prID)
 		if err != nil {
 			return err
 		}
 
+		if err := checkVersion(pr, expectedVersion); err != nil {
+			return err
+		}
+
 		// cannot reassign if merged
 		if pr.Status == entity.StatusMerged {
 			return entity.ErrPRMerged
 		}
 
+		// drafts have no reviewers and closed prs are frozen
+		if pr.Status != entity.StatusOpen {
+			return entity.ErrPRNotOpen
+		}
+
 		// validate the old reviewer is currently assigned
 		var oldReviewer *entity.User
 		var oldReviewerIndex int
@@ -151,34 +265,13 @@ func (uc *PRUseCase) Reassign(ctx context.Context, prID, oldReviewerID string) (
 		if oldReviewer == nil {
 			return entity.ErrNotAssigned
 		}
+		removed = *oldReviewer
 
-		// collect current reviewer ids to exclude them from candidates
-		currentReviewerIDs := make(map[string]bool)
-		for _, rev := range pr.Reviewers {
-			currentReviewerIDs[rev.ID] = true
-		}
-		delete(currentReviewerIDs, oldReviewerID) // exclude the one we are replacing from the check for existing reviewers
-
-		// get active candidates from the old reviewer's team (excluding the old reviewer)
-		candidates, err := uc.userRepo.GetActiveCandidatesByTeam(txCtx, oldReviewer.TeamName, oldReviewerID)
+		// current reviewers and the author can not take the slot
+		newReviewer, err := uc.pickReplacement(txCtx, *oldReviewer, excludedReviewers(pr))
 		if err != nil {
 			return err
 		}
-
-		// filter candidates to exclude existing reviewers and the pr author
-		filteredCandidates := make([]*entity.User, 0)
-		for _, c := range candidates {
-			if !currentReviewerIDs[c.ID] && c.ID != pr.AuthorID {
-				filteredCandidates = append(filteredCandidates, c)
-			}
-		}
-
-		if len(filteredCandidates) == 0 {
-			return entity.ErrNoCandidate // no one available to replace the reviewer
-		}
-
-		// select one random replacement
-		newReviewer := uc.assigner.SelectReviewers(filteredCandidates, 1)[0]
 		newReviewerID = newReviewer.ID
 
 		// build the new list of reviewer ids
@@ -201,6 +294,235 @@ func (uc *PRUseCase) Reassign(ctx context.Context, prID, oldReviewerID string) (
 		updatedPR, err = uc.prRepo.GetByID(txCtx, prID)
 		return err
 	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	uc.events.Publish(reviewerEvents(ctx, entity.EventUnassigned, updatedPR, []entity.User{removed})...)
+
+	// notify only the newly assigned reviewer
+	for _, rev := range updatedPR.Reviewers {
+		if rev.ID == newReviewerID {
+			notifyAssigned(ctx, uc.notifier, updatedPR, []entity.User{rev})
+			uc.events.Publish(reviewerEvents(ctx, entity.EventAssigned, updatedPR, []entity.User{rev})...)
+			break
+		}
+	}
+
+	return updatedPR, newReviewerID, nil
+}
+
+// ReviewerReplacement is the outcome of ReplaceReviewers, its notifications and events
+// are sent by PublishReplacement once the surrounding transaction commits
+type ReviewerReplacement struct {
+	PR       *entity.PullRequest
+	Replaced map[string]string // old reviewer id -> new reviewer id
+	removed  []entity.User
+	added    []entity.User
+}
+
+// ReplaceReviewers replaces several reviewers in one transaction, none of the replaced
+// reviewers is picked for another slot, reviewers without a candidate stay assigned;
+// it joins the caller's transaction and publishes nothing, so the caller passes
+// the result to PublishReplacement after commit
+func (uc *PRUseCase) ReplaceReviewers(ctx context.Context, prID string, oldReviewerIDs []string) (*ReviewerReplacement, error) {
+	for _, id := range oldReviewerIDs {
+		if err := uc.authz.CanReassign(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	replaced := make(map[string]string, len(oldReviewerIDs))
+	var updatedPR *entity.PullRequest
+	var removed []entity.User
+
+	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
+		pr, err := uc.prRepo.GetByIDForUpdate(txCtx, prID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == entity.StatusMerged {
+			return entity.ErrPRMerged
+		}
+		if pr.Status != entity.StatusOpen {
+			return entity.ErrPRNotOpen
+		}
+
+		replace := make(map[string]bool, len(oldReviewerIDs))
+		for _, id := range oldReviewerIDs {
+			replace[id] = true
+		}
+
+		// every current reviewer stays excluded, so a replaced one never comes back
+		excluded := excludedReviewers(pr)
+		newReviewers := make([]string, 0, len(pr.Reviewers))
+		for _, rev := range pr.Reviewers {
+			if !replace[rev.ID] {
+				newReviewers = append(newReviewers, rev.ID)
+				continue
+			}
+
+			newReviewer, err := uc.pickReplacement(txCtx, rev, excluded)
+			if errors.Is(err, entity.ErrNoCandidate) {
+				newReviewers = append(newReviewers, rev.ID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			excluded[newReviewer.ID] = true
+			replaced[rev.ID] = newReviewer.ID
+			removed = append(removed, rev)
+			newReviewers = append(newReviewers, newReviewer.ID)
+		}
+
+		if len(replaced) == 0 {
+			return entity.ErrNoCandidate
+		}
+
+		if err := uc.prRepo.SetReviewers(txCtx, prID, newReviewers); err != nil {
+			return err
+		}
+
+		updatedPR, err = uc.prRepo.GetByID(txCtx, prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	added := make([]entity.User, 0, len(replaced))
+	for _, rev := range updatedPR.Reviewers {
+		for _, newID := range replaced {
+			if rev.ID == newID {
+				added = append(added, rev)
+			}
+		}
+	}
+
+	return &ReviewerReplacement{PR: updatedPR, Replaced: replaced, removed: removed, added: added}, nil
+}
+
+// PublishReplacement notifies the new reviewers and publishes the reviewer changes,
+// call it only after the transaction of ReplaceReviewers has committed
+func (uc *PRUseCase) PublishReplacement(ctx context.Context, replacement *ReviewerReplacement) {
+	uc.events.Publish(reviewerEvents(ctx, entity.EventUnassigned, replacement.PR, replacement.removed)...)
+	notifyAssigned(ctx, uc.notifier, replacement.PR, replacement.added)
+	uc.events.Publish(reviewerEvents(ctx, entity.EventAssigned, replacement.PR, replacement.added)...)
+}
+
+// excludedReviewers returns the users who can not replace a reviewer of the pr:
+// its current reviewers and the author
+func excludedReviewers(pr *entity.PullRequest) map[string]bool {
+	excluded := map[string]bool{pr.AuthorID: true}
+	for _, rev := range pr.Reviewers {
+		excluded[rev.ID] = true
+	}
+	return excluded
+}
+
+// pickReplacement selects a random active member of the old reviewer's team who is not excluded
+func (uc *PRUseCase) pickReplacement(ctx context.Context, oldReviewer entity.User, excluded map[string]bool) (*entity.User, error) {
+	candidates, err := uc.userRepo.GetActiveCandidatesByTeam(ctx, oldReviewer.TeamName, oldReviewer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.User, 0, len(candidates))
+	for _, c := range candidates {
+		if !excluded[c.ID] {
+			filtered = append(filtered, c)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, entity.ErrNoCandidate // no one available to replace the reviewer
+	}
+
+	selected := uc.assigner.SelectReviewers(filtered, 1)[0]
+	return &selected, nil
+}
+
+// SubmitReview records an approval, change request or comment of an assigned reviewer
+func (uc *PRUseCase) SubmitReview(ctx context.Context, prID, reviewerID string, action entity.ReviewAction, expectedVersion int) (*entity.Review, error) {
+	if !action.IsValid() {
+		return nil, entity.ErrInvalidReviewAction
+	}
+
+	// a review counts towards the merge policy, nobody may submit it for another reviewer
+	if err := uc.authz.CanReview(ctx, reviewerID); err != nil {
+		return nil, err
+	}
+
+	var review *entity.Review
+
+	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
+		pr, err := uc.prRepo.GetByIDForUpdate(txCtx, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(pr, expectedVersion); err != nil {
+			return err
+		}
+
+		// reviews are frozen after merge
+		if pr.Status == entity.StatusMerged {
+			return entity.ErrPRMerged
+		}
+		if pr.Status != entity.StatusOpen {
+			return entity.ErrPRNotOpen
+		}
+
+		// find the current state of the reviewer
+		var current *entity.Review
+		for i := range pr.Reviews {
+			if pr.Reviews[i].ReviewerID == reviewerID {
+				current = &pr.Reviews[i]
+				break
+			}
+		}
+
+		if current == nil {
+			return entity.ErrNotAssigned
+		}
+
+		review, err = uc.prRepo.SubmitReview(txCtx, prID, reviewerID, action.NextState(current.State))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// List returns a page of pull requests matching the filter
+func (uc *PRUseCase) List(ctx context.Context, filter entity.PRFilter) (*entity.PRPage, error) {
+	filter.Limit = normalizePageSize(filter.Limit)
+	return uc.prRepo.List(ctx, filter)
+}
+
+// Get returns a single pr with reviewers, author and assignment history summary
+func (uc *PRUseCase) Get(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+
+	// one transaction, but not one snapshot: under read committed the history
+	// may already include a change committed after the pr row was read
+	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
+		var err error
+		if pr, err = uc.prRepo.GetByID(txCtx, prID); err != nil {
+			return err
+		}
+
+		pr.History, err = uc.prRepo.GetAssignmentHistory(txCtx, prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return updatedPR, newReviewerID, err
+	return pr, nil
 }
@@ -0,0 +1,283 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/memory"
+)
+
+// newPRService builds the pr use case on top of an in-memory store with one team
+func newPRService(t *testing.T, policy entity.MergePolicy) (*services.PRUseCase, context.Context) {
+	t.Helper()
+	return newPRServiceForTeam(t, policy, []*entity.User{
+		{ID: "u1", Username: "alice", IsActive: true},
+		{ID: "u2", Username: "bob", IsActive: true},
+		{ID: "u3", Username: "carol", IsActive: true},
+		{ID: "u4", Username: "dave", IsActive: true},
+		// inactive, so never assigned as a reviewer
+		{ID: "u5", Username: "eve", Role: entity.RoleAdmin},
+	})
+}
+
+// newPRServiceForTeam builds the pr use case with the given members of the backend team
+func newPRServiceForTeam(t *testing.T, policy entity.MergePolicy, users []*entity.User) (*services.PRUseCase, context.Context) {
+	t.Helper()
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepository(store)
+	userRepo := memory.NewUserRepository(store)
+	prRepo := memory.NewPRRepository(store)
+
+	ctx := auth.WithPrincipal(context.Background(), auth.System())
+	if err := teamRepo.Create(ctx, &entity.Team{Name: "backend"}, users); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := teamRepo.SaveSettings(ctx, &entity.TeamSettings{TeamName: "backend", MergePolicy: policy}); err != nil {
+		t.Fatalf("save settings: %v", err)
+	}
+
+	authz := services.NewAuthorizer(userRepo)
+	uc := services.NewPRUseCase(prRepo, userRepo, teamRepo, store, services.NewAssigner(), services.NopNotifier{}, services.NopPublisher{}, authz)
+	return uc, ctx
+}
+
+func TestPRUseCase_CreateAssignsTwoReviewers(t *testing.T) {
+	uc, ctx := newPRService(t, entity.MergePolicy{})
+
+	pr, err := uc.Create(ctx, "pr-1", "feature", "u1", false)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if pr.Status != entity.StatusOpen || len(pr.Reviewers) != 2 {
+		t.Fatalf("pr = %+v, want OPEN with 2 reviewers", pr)
+	}
+	for _, rev := range pr.Reviewers {
+		if rev.ID == "u1" {
+			t.Error("author was assigned as reviewer")
+		}
+	}
+
+	if _, err := uc.Create(ctx, "pr-1", "again", "u1", false); !errors.Is(err, entity.ErrPRExists) {
+		t.Errorf("duplicate Create = %v, want ErrPRExists", err)
+	}
+}
+
+func TestPRUseCase_Reassign(t *testing.T) {
+	uc, ctx := newPRService(t, entity.MergePolicy{})
+
+	pr, err := uc.Create(ctx, "pr-1", "feature", "u1", false)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	old := pr.Reviewers[0].ID
+
+	tests := []struct {
+		name     string
+		reviewer string
+		version  int
+		wantErr  error
+	}{
+		{"stale version", old, pr.Version + 1, entity.ErrConflict},
+		{"not assigned", "u1", 0, entity.ErrNotAssigned},
+		{"replaced", old, pr.Version, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, newID, err := uc.Reassign(ctx, "pr-1", tt.reviewer, tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reassign = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if newID == old || newID == "u1" || updated.Version != pr.Version+1 {
+				t.Errorf("replaced by %s, version %d", newID, updated.Version)
+			}
+		})
+	}
+}
+
+func TestPRUseCase_MergePolicy(t *testing.T) {
+	uc, ctx := newPRService(t, entity.MergePolicy{MinApprovals: 1})
+
+	pr, err := uc.Create(ctx, "pr-1", "feature", "u1", false)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := uc.Merge(ctx, "pr-1", false, 0); !errors.Is(err, entity.ErrMergeBlocked) {
+		t.Fatalf("Merge without approvals = %v, want ErrMergeBlocked", err)
+	}
+
+	if _, err := uc.SubmitReview(ctx, "pr-1", pr.Reviewers[0].ID, entity.ActionApprove, 0); err != nil {
+		t.Fatalf("SubmitReview: %v", err)
+	}
+	merged, err := uc.Merge(ctx, "pr-1", false, 0)
+	if err != nil || merged.Status != entity.StatusMerged {
+		t.Fatalf("Merge = %+v, %v", merged, err)
+	}
+
+	if _, _, err := uc.Reassign(ctx, "pr-1", pr.Reviewers[0].ID, 0); !errors.Is(err, entity.ErrPRMerged) {
+		t.Errorf("Reassign after merge = %v, want ErrPRMerged", err)
+	}
+}
+
+// a team smaller than the policy caps the required approvals, a pr without reviewers can't meet them
+func TestPRUseCase_MergePolicySmallTeam(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    entity.MergePolicy
+		reviewers int  // active members besides the author
+		approve   bool // the only reviewer approves before the merge
+		wantErr   error
+	}{
+		{"one reviewer approved", entity.MergePolicy{MinApprovals: 2}, 1, true, nil},
+		{"one reviewer pending", entity.MergePolicy{MinApprovals: 2}, 1, false, entity.ErrMergeBlocked},
+		{"no reviewers, approvals required", entity.MergePolicy{MinApprovals: 1}, 0, false, entity.ErrMergeBlocked},
+		{"no reviewers, all must approve", entity.MergePolicy{RequireAllApproved: true}, 0, false, entity.ErrMergeBlocked},
+		{"no reviewers, no approvals required", entity.MergePolicy{BlockOnChangesRequested: true}, 0, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := []*entity.User{{ID: "u1", Username: "alice", IsActive: true}}
+			if tt.reviewers > 0 {
+				users = append(users, &entity.User{ID: "u2", Username: "bob", IsActive: true})
+			}
+			uc, ctx := newPRServiceForTeam(t, tt.policy, users)
+
+			pr, err := uc.Create(ctx, "pr-1", "feature", "u1", false)
+			if err != nil || len(pr.Reviewers) != tt.reviewers {
+				t.Fatalf("Create = %+v, %v, want %d reviewers", pr, err, tt.reviewers)
+			}
+			if tt.approve {
+				if _, err := uc.SubmitReview(ctx, "pr-1", "u2", entity.ActionApprove, 0); err != nil {
+					t.Fatalf("SubmitReview: %v", err)
+				}
+			}
+
+			if _, err := uc.Merge(ctx, "pr-1", false, 0); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Merge = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPRUseCase_Lifecycle(t *testing.T) {
+	uc, ctx := newPRService(t, entity.MergePolicy{})
+
+	draft, err := uc.Create(ctx, "pr-1", "feature", "u1", true)
+	if err != nil || draft.Status != entity.StatusDraft || len(draft.Reviewers) != 0 {
+		t.Fatalf("Create draft = %+v, %v", draft, err)
+	}
+
+	t.Run("wrong status", func(t *testing.T) {
+		if _, err := uc.Reopen(ctx, "pr-1", 0); !errors.Is(err, entity.ErrInvalidTransition) {
+			t.Errorf("Reopen of a draft = %v, want ErrInvalidTransition", err)
+		}
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		if _, err := uc.MarkReady(ctx, "pr-1", draft.Version+1); !errors.Is(err, entity.ErrConflict) {
+			t.Errorf("MarkReady with a stale version = %v, want ErrConflict", err)
+		}
+	})
+
+	t.Run("reviewers assigned on ready", func(t *testing.T) {
+		ready, err := uc.MarkReady(ctx, "pr-1", draft.Version)
+		if err != nil {
+			t.Fatalf("MarkReady: %v", err)
+		}
+		if ready.Status != entity.StatusOpen || len(ready.Reviewers) != 2 {
+			t.Fatalf("ready pr = %+v, want OPEN with 2 reviewers", ready)
+		}
+		// assignment and status change are one modification
+		if ready.Version != draft.Version+1 {
+			t.Errorf("version = %d, want %d", ready.Version, draft.Version+1)
+		}
+		if ready.Author == nil || ready.Author.ID != "u1" {
+			t.Errorf("author = %+v, want u1", ready.Author)
+		}
+	})
+
+	t.Run("reopen after merge", func(t *testing.T) {
+		if _, err := uc.Merge(ctx, "pr-1", false, 0); err != nil {
+			t.Fatalf("Merge: %v", err)
+		}
+		if _, err := uc.Reopen(ctx, "pr-1", 0); !errors.Is(err, entity.ErrInvalidTransition) {
+			t.Errorf("Reopen after merge = %v, want ErrInvalidTransition", err)
+		}
+		if _, err := uc.Close(ctx, "pr-1", 0); !errors.Is(err, entity.ErrInvalidTransition) {
+			t.Errorf("Close after merge = %v, want ErrInvalidTransition", err)
+		}
+	})
+}
+
+// asUser returns a context of a user-bound token with the pr:write scope
+func asUser(userID string) context.Context {
+	return auth.WithPrincipal(context.Background(), asPrincipal(userID))
+}
+
+func TestPRUseCase_Authorization(t *testing.T) {
+	uc, _ := newPRService(t, entity.MergePolicy{})
+
+	if _, err := uc.Create(asUser("u2"), "pr-1", "feature", "u1", false); !errors.Is(err, entity.ErrForbidden) {
+		t.Fatalf("Create for another author = %v, want ErrForbidden", err)
+	}
+	pr, err := uc.Create(asUser("u1"), "pr-1", "feature", "u1", false)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	reviewer := pr.Reviewers[0].ID
+	outsider := "" // team member who is not assigned
+	for _, id := range []string{"u2", "u3", "u4"} {
+		if id != pr.Reviewers[0].ID && id != pr.Reviewers[1].ID {
+			outsider = id
+		}
+	}
+
+	// steps share the pr and run in order
+	steps := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{"author approves for a reviewer", func() error {
+			_, err := uc.SubmitReview(asUser("u1"), "pr-1", reviewer, entity.ActionApprove, 0)
+			return err
+		}, entity.ErrForbidden},
+		{"outsider approves for a reviewer", func() error {
+			_, err := uc.SubmitReview(asUser(outsider), "pr-1", reviewer, entity.ActionApprove, 0)
+			return err
+		}, entity.ErrForbidden},
+		{"reviewer approves", func() error {
+			_, err := uc.SubmitReview(asUser(reviewer), "pr-1", reviewer, entity.ActionApprove, 0)
+			return err
+		}, nil},
+		{"outsider closes", func() error {
+			_, err := uc.Close(asUser(outsider), "pr-1", 0)
+			return err
+		}, entity.ErrForbidden},
+		{"team admin closes", func() error {
+			_, err := uc.Close(asUser("u5"), "pr-1", 0)
+			return err
+		}, nil},
+		{"reviewer reopens", func() error {
+			_, err := uc.Reopen(asUser(reviewer), "pr-1", 0)
+			return err
+		}, entity.ErrForbidden},
+		{"author reopens", func() error {
+			_, err := uc.Reopen(asUser("u1"), "pr-1", 0)
+			return err
+		}, nil},
+	}
+	for _, step := range steps {
+		if err := step.call(); !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s = %v, want %v", step.name, err, step.wantErr)
+		}
+	}
+}
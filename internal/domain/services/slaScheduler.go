@@ -0,0 +1,167 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// SLAScheduler periodically scans open pull requests and enforces team review slas
+type SLAScheduler struct {
+	slaRepo    repository.SLARepository
+	prRepo     repository.PRRepository
+	transactor repository.Transactor
+	prService  PRService
+	notifier   Notifier
+	interval   time.Duration
+}
+
+// NewSLAScheduler is the constructor for slascheduler
+func NewSLAScheduler(slaRepo repository.SLARepository, prRepo repository.PRRepository, transactor repository.Transactor, prService PRService, notifier Notifier, interval time.Duration) *SLAScheduler {
+	return &SLAScheduler{
+		slaRepo:    slaRepo,
+		prRepo:     prRepo,
+		transactor: transactor,
+		prService:  prService,
+		notifier:   notifier,
+		interval:   interval,
+	}
+}
+
+// Run scans on every tick until the context is canceled
+func (s *SLAScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RunOnce(ctx, time.Now()); err != nil {
+				slog.Error("SLA scan failed", "error", err)
+			}
+		}
+	}
+}
+
+// RunOnce emits reminders first and escalations second for all breached slas
+func (s *SLAScheduler) RunOnce(ctx context.Context, now time.Time) error {
+	// escalations reassign on behalf of the service itself
+	ctx = auth.WithPrincipal(ctx, auth.System())
+
+	for _, kind := range []entity.SLAKind{entity.SLAReminder, entity.SLAEscalation} {
+		breaches, err := s.slaRepo.ListBreaches(ctx, kind, now)
+		if err != nil {
+			return err
+		}
+
+		for _, breach := range breaches {
+			// a failure on one pr must not block the rest of the scan
+			if err := s.handle(ctx, breach); err != nil {
+				slog.Error("Failed to handle SLA breach", "pr_id", breach.PR.ID, "kind", breach.Kind, "error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// handle emits the event for a single breach and optionally reassigns stale reviewers
+func (s *SLAScheduler) handle(ctx context.Context, breach *entity.SLABreach) error {
+	// the scan covers all organizations, everything below acts in the breach one
+	ctx = tenant.WithOrg(ctx, breach.OrgID)
+
+	var pr *entity.PullRequest
+	var stale []entity.User
+	var replacement *ReviewerReplacement
+
+	// the claim commits together with the reassignment: another instance waits for it and skips
+	// the breach, while a failed reassignment rolls the claim back and is retried on the next scan
+	err := s.transactor.Do(ctx, func(txCtx context.Context) error {
+		claimed, err := s.slaRepo.MarkSent(txCtx, breach.PR.ID, breach.Kind)
+		if err != nil || !claimed {
+			return err
+		}
+
+		// reload the pr with reviewers and their review state
+		if pr, err = s.prRepo.GetByIDForUpdate(txCtx, breach.PR.ID); err != nil {
+			return fmt.Errorf("failed to load PR %s: %w", breach.PR.ID, err)
+		}
+		stale = staleReviewers(pr)
+
+		if len(stale) == 0 || breach.Kind != entity.SLAEscalation || !breach.AutoReassign {
+			return nil
+		}
+
+		// replace all stale reviewers at once, so none of them is picked for another slot
+		ids := make([]string, 0, len(stale))
+		for _, rev := range stale {
+			ids = append(ids, rev.ID)
+		}
+		replacement, err = s.prService.ReplaceReviewers(txCtx, pr.ID, ids)
+		if errors.Is(err, entity.ErrNoCandidate) {
+			return nil // logged per reviewer below, the escalation is not retried
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reassign stale reviewers: %w", err)
+		}
+		return nil
+	})
+	if err != nil || pr == nil {
+		return err
+	}
+
+	slog.Info("Review SLA breached", "pr_id", pr.ID, "kind", breach.Kind, "created_at", pr.CreatedAt, "stale_reviewers", len(stale))
+
+	if len(stale) == 0 {
+		return nil // everyone has already responded
+	}
+
+	if err := s.notifier.NotifyReminder(ctx, pr, stale, breach.Kind); err != nil {
+		slog.Error("Failed to send SLA notification", "pr_id", pr.ID, "kind", breach.Kind, "error", err)
+	}
+
+	// the reassignment is committed now, so its reviewers and subscribers may learn about it
+	var replaced map[string]string
+	if replacement != nil {
+		s.prService.PublishReplacement(ctx, replacement)
+		replaced = replacement.Replaced
+	}
+
+	for _, rev := range stale {
+		if newReviewerID, ok := replaced[rev.ID]; ok {
+			slog.Info("Stale reviewer reassigned", "pr_id", pr.ID, "old_reviewer_id", rev.ID, "new_reviewer_id", newReviewerID)
+		} else if breach.Kind == entity.SLAEscalation && breach.AutoReassign {
+			slog.Warn("No candidate to replace stale reviewer", "pr_id", pr.ID, "reviewer_id", rev.ID)
+		}
+	}
+
+	return nil
+}
+
+// staleReviewers returns the reviewers who have not responded to the pr yet
+func staleReviewers(pr *entity.PullRequest) []entity.User {
+	responded := make(map[string]bool)
+	for _, review := range pr.Reviews {
+		if review.FirstResponseAt != nil {
+			responded[review.ReviewerID] = true
+		}
+	}
+
+	reviewers := make([]entity.User, 0, len(pr.Reviewers))
+	for _, rev := range pr.Reviewers {
+		if !responded[rev.ID] {
+			reviewers = append(reviewers, rev)
+		}
+	}
+	return reviewers
+}
@@ -0,0 +1,280 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/memory"
+)
+
+// reminderRecorder keeps sla reminders sent by the scheduler and reviewers told about an assignment
+type reminderRecorder struct {
+	services.NopNotifier
+	mu        sync.Mutex
+	reminders []entity.SLAKind
+	assigned  []string
+}
+
+func (r *reminderRecorder) NotifyReminder(_ context.Context, _ *entity.PullRequest, _ []entity.User, kind entity.SLAKind) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reminders = append(r.reminders, kind)
+	return nil
+}
+
+func (r *reminderRecorder) NotifyAssigned(_ context.Context, _ *entity.PullRequest, reviewers []entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rev := range reviewers {
+		r.assigned = append(r.assigned, rev.ID)
+	}
+	return nil
+}
+
+// eventRecorder keeps events published to sse subscribers
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []entity.Event
+}
+
+func (r *eventRecorder) Publish(events ...entity.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+}
+
+// failingReplace fails the first replacements like an unavailable database,
+// afterReplace fails once the replacement itself succeeded, like a failed commit
+type failingReplace struct {
+	services.PRService
+	failures     int
+	afterReplace bool
+}
+
+func (f *failingReplace) ReplaceReviewers(ctx context.Context, prID string, oldReviewerIDs []string) (*services.ReviewerReplacement, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("connection refused")
+	}
+	replacement, err := f.PRService.ReplaceReviewers(ctx, prID, oldReviewerIDs)
+	if err == nil && f.afterReplace {
+		f.afterReplace = false
+		return nil, errors.New("connection reset")
+	}
+	return replacement, err
+}
+
+// slaFixture is a team with one open pr whose reviewers are stale
+type slaFixture struct {
+	ctx       context.Context
+	prRepo    *memory.PRRepository
+	prService services.PRService
+	scheduler *services.SLAScheduler
+	notifier  *reminderRecorder
+	events    *eventRecorder
+	reviewers []string // initially assigned
+}
+
+// newSLAFixture creates a team of the author u1 and active members u2..u<members>
+func newSLAFixture(t *testing.T, members int, settings entity.TeamSettings, wrap func(services.PRService) services.PRService) *slaFixture {
+	t.Helper()
+	store := memory.NewStore()
+	teamRepo, userRepo, prRepo := memory.NewTeamRepository(store), memory.NewUserRepository(store), memory.NewPRRepository(store)
+
+	ctx := auth.WithPrincipal(context.Background(), auth.System())
+	users := make([]*entity.User, 0, members)
+	for i := 1; i <= members; i++ {
+		users = append(users, &entity.User{ID: fmt.Sprintf("u%d", i), Username: fmt.Sprintf("user%d", i), IsActive: true})
+	}
+	if err := teamRepo.Create(ctx, &entity.Team{Name: "backend"}, users); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	settings.TeamName = "backend"
+	if err := teamRepo.SaveSettings(ctx, &settings); err != nil {
+		t.Fatalf("save settings: %v", err)
+	}
+
+	notifier, events := &reminderRecorder{}, &eventRecorder{}
+	var prService services.PRService = services.NewPRUseCase(prRepo, userRepo, teamRepo, store, services.NewAssigner(),
+		notifier, events, services.NewAuthorizer(userRepo))
+	if wrap != nil {
+		prService = wrap(prService)
+	}
+	pr, err := prService.Create(ctx, "pr-1", "Add search", "u1", false)
+	if err != nil {
+		t.Fatalf("create pr: %v", err)
+	}
+
+	f := &slaFixture{
+		ctx:       ctx,
+		prRepo:    prRepo,
+		prService: prService,
+		scheduler: services.NewSLAScheduler(memory.NewSLARepository(store), prRepo, store, prService, notifier, time.Minute),
+		notifier:  notifier,
+		events:    events,
+	}
+	for _, rev := range pr.Reviewers {
+		f.reviewers = append(f.reviewers, rev.ID)
+	}
+	// only what the scheduler causes is checked
+	notifier.assigned, events.events = nil, nil
+	return f
+}
+
+// assigned returns the current reviewers of the pr
+func (f *slaFixture) assigned(t *testing.T) map[string]bool {
+	t.Helper()
+	pr, err := f.prRepo.GetByID(f.ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("get pr: %v", err)
+	}
+	ids := make(map[string]bool, len(pr.Reviewers))
+	for _, rev := range pr.Reviewers {
+		ids[rev.ID] = true
+	}
+	return ids
+}
+
+func TestSLAScheduler_RunOnce(t *testing.T) {
+	escalate := entity.TeamSettings{EscalateAfter: time.Hour, AutoReassign: true}
+
+	tests := []struct {
+		name          string
+		members       int
+		settings      entity.TeamSettings
+		responded     int // reviewers who approve before the scan
+		after         time.Duration
+		wantReminders []entity.SLAKind
+		wantReplaced  int
+	}{
+		{"not due yet", 6, entity.TeamSettings{ReminderAfter: time.Hour}, 0, 30 * time.Minute, nil, 0},
+		{"reminder", 6, entity.TeamSettings{ReminderAfter: time.Hour}, 0, 2 * time.Hour, []entity.SLAKind{entity.SLAReminder}, 0},
+		{"reminder and escalation", 6, entity.TeamSettings{ReminderAfter: time.Hour, EscalateAfter: 2 * time.Hour}, 0, 3 * time.Hour,
+			[]entity.SLAKind{entity.SLAReminder, entity.SLAEscalation}, 0},
+		{"escalation replaces every stale reviewer", 6, escalate, 0, 2 * time.Hour, []entity.SLAKind{entity.SLAEscalation}, 2},
+		{"responded reviewer is kept", 6, escalate, 1, 2 * time.Hour, []entity.SLAKind{entity.SLAEscalation}, 1},
+		// one spare member: the second slot must not go to the reviewer just removed from the first
+		{"replaced reviewer is not picked again", 4, escalate, 0, 2 * time.Hour, []entity.SLAKind{entity.SLAEscalation}, 1},
+		{"no candidates", 3, escalate, 0, 2 * time.Hour, []entity.SLAKind{entity.SLAEscalation}, 0},
+		{"everyone responded", 6, escalate, 2, 2 * time.Hour, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSLAFixture(t, tt.members, tt.settings, nil)
+			for _, id := range f.reviewers[:tt.responded] {
+				if _, err := f.prService.SubmitReview(f.ctx, "pr-1", id, entity.ActionApprove, 0); err != nil {
+					t.Fatalf("review: %v", err)
+				}
+			}
+
+			// the second scan finds every event already sent
+			now := time.Now().Add(tt.after)
+			for range 2 {
+				if err := f.scheduler.RunOnce(context.Background(), now); err != nil {
+					t.Fatalf("RunOnce: %v", err)
+				}
+			}
+
+			if got, want := fmt.Sprint(f.notifier.reminders), fmt.Sprint(tt.wantReminders); got != want {
+				t.Errorf("reminders = %s, want %s", got, want)
+			}
+
+			assigned := f.assigned(t)
+			replaced := 0
+			for _, id := range f.reviewers {
+				if !assigned[id] {
+					replaced++
+				}
+			}
+			if replaced != tt.wantReplaced || len(assigned) != len(f.reviewers) {
+				t.Errorf("replaced %d of %v, now assigned %v, want %d replaced", replaced, f.reviewers, assigned, tt.wantReplaced)
+			}
+			for _, id := range f.reviewers[:tt.responded] {
+				if !assigned[id] {
+					t.Errorf("reviewer %s responded but was replaced", id)
+				}
+			}
+
+			// nobody is assigned to the pr twice: one assignment per reviewer in the history
+			history, err := f.prRepo.GetAssignmentHistory(f.ctx, "pr-1")
+			if err != nil {
+				t.Fatalf("history: %v", err)
+			}
+			if history.Assignments != len(history.Events) {
+				t.Errorf("%d assignments of %d reviewers, last events %+v", history.Assignments, len(history.Events), history.Events)
+			}
+		})
+	}
+}
+
+func TestSLAScheduler_RetriesFailedEscalation(t *testing.T) {
+	failing := &failingReplace{failures: 1}
+	f := newSLAFixture(t, 6, entity.TeamSettings{EscalateAfter: time.Hour, AutoReassign: true}, func(s services.PRService) services.PRService {
+		failing.PRService = s
+		return failing
+	})
+	now := time.Now().Add(2 * time.Hour)
+
+	// the failed reassignment rolls back the claim of the escalation
+	if err := f.scheduler.RunOnce(context.Background(), now); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(f.notifier.reminders) != 0 {
+		t.Fatalf("escalation sent although the reassignment failed: %v", f.notifier.reminders)
+	}
+
+	if err := f.scheduler.RunOnce(context.Background(), now); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	assigned := f.assigned(t)
+	for _, id := range f.reviewers {
+		if assigned[id] {
+			t.Errorf("stale reviewer %s is still assigned after the retry", id)
+		}
+	}
+	if len(f.notifier.reminders) != 1 {
+		t.Errorf("reminders = %v, want one escalation", f.notifier.reminders)
+	}
+}
+
+func TestSLAScheduler_PublishesReplacementAfterCommit(t *testing.T) {
+	failing := &failingReplace{afterReplace: true}
+	f := newSLAFixture(t, 6, entity.TeamSettings{EscalateAfter: time.Hour, AutoReassign: true}, func(s services.PRService) services.PRService {
+		failing.PRService = s
+		return failing
+	})
+	now := time.Now().Add(2 * time.Hour)
+
+	// the reassignment ran but its transaction rolled back: nobody hears about it
+	if err := f.scheduler.RunOnce(context.Background(), now); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(f.notifier.assigned) != 0 || len(f.events.events) != 0 {
+		t.Fatalf("rolled back reassignment published: assigned %v, events %+v", f.notifier.assigned, f.events.events)
+	}
+	if assigned := f.assigned(t); !assigned[f.reviewers[0]] || !assigned[f.reviewers[1]] {
+		t.Fatalf("reviewers changed by a rolled back reassignment: %v", assigned)
+	}
+
+	// the retry commits and then publishes both replacements
+	if err := f.scheduler.RunOnce(context.Background(), now); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if len(f.notifier.assigned) != 2 {
+		t.Errorf("assigned notifications = %v, want two new reviewers", f.notifier.assigned)
+	}
+	kinds := make(map[entity.EventType]int)
+	for _, e := range f.events.events {
+		kinds[e.Type]++
+	}
+	if kinds[entity.EventAssigned] != 2 || kinds[entity.EventUnassigned] != 2 {
+		t.Errorf("events = %v, want two assigned and two unassigned", kinds)
+	}
+}
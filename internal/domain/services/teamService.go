@@ -12,24 +12,34 @@ import (
 
 type TeamService interface {
 	CreateTeamWithUsers(ctx context.Context, team *entity.Team, users []*entity.User) error
+	GetTeam(ctx context.Context, teamName string) (*entity.Team, []*entity.User, error)
+	GetSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error)
+	UpdateSettings(ctx context.Context, settings *entity.TeamSettings) (*entity.TeamSettings, error)
 }
 
 // TeamUseCase implements the TeamService interface
 type TeamUseCase struct {
 	repo       repository.TeamRepository
+	userRepo   repository.UserRepository
 	transactor repository.Transactor
+	authz      *Authorizer
 }
 
 // NewTeamUseCase is the constructor for TeamUseCase
-func NewTeamUseCase(repo repository.TeamRepository, transactor repository.Transactor) *TeamUseCase {
+func NewTeamUseCase(repo repository.TeamRepository, userRepo repository.UserRepository, transactor repository.Transactor, authz *Authorizer) *TeamUseCase {
 	return &TeamUseCase{
 		repo:       repo,
+		userRepo:   userRepo,
 		transactor: transactor,
+		authz:      authz,
 	}
 }
 
 // CreateTeamWithUsers ensures team and users are created atomically or rolled back
 func (uc *TeamUseCase) CreateTeamWithUsers(ctx context.Context, team *entity.Team, users []*entity.User) error {
+	if err := uc.authz.CanCreateTeam(ctx); err != nil {
+		return err
+	}
 
 	// start a transaction
 	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
@@ -45,7 +55,81 @@ func (uc *TeamUseCase) CreateTeamWithUsers(ctx context.Context, team *entity.Tea
 			// return error 409 conflict
 			return entity.ErrTeamExists
 		}
+		if errors.Is(err, entity.ErrUserExists) {
+			// member id is already taken in the organization
+			return entity.ErrUserExists
+		}
 	}
 
 	return err
 }
+
+// GetTeam returns an existing team with all its members
+func (uc *TeamUseCase) GetTeam(ctx context.Context, teamName string) (*entity.Team, []*entity.User, error) {
+	team, err := uc.repo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members, err := uc.userRepo.ListByTeam(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return team, members, nil
+}
+
+// GetSettings returns the review settings of an existing team
+func (uc *TeamUseCase) GetSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	if _, err := uc.repo.GetByName(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetSettings(ctx, teamName)
+}
+
+// UpdateSettings replaces the review settings of an existing team,
+// all broken invariants are reported at once with an InvalidSettingsError
+func (uc *TeamUseCase) UpdateSettings(ctx context.Context, settings *entity.TeamSettings) (*entity.TeamSettings, error) {
+	if err := checkSettings(settings); err != nil {
+		return nil, err
+	}
+
+	err := uc.transactor.Do(ctx, func(txCtx context.Context) error {
+		if _, err := uc.repo.GetByName(txCtx, settings.TeamName); err != nil {
+			return err
+		}
+		if err := uc.authz.CanManageTeam(txCtx, settings.TeamName); err != nil {
+			return err
+		}
+		return uc.repo.SaveSettings(txCtx, settings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// checkSettings validates the invariants of team settings, a zero duration disables its check
+func checkSettings(settings *entity.TeamSettings) error {
+	var violations []entity.SettingsViolation
+	if settings.ReminderAfter < 0 {
+		violations = append(violations, entity.SettingsViolation{Field: "reminder_after", Err: entity.ErrInvalidSLADuration})
+	}
+	if settings.EscalateAfter < 0 {
+		violations = append(violations, entity.SettingsViolation{Field: "escalate_after", Err: entity.ErrInvalidSLADuration})
+	}
+	if settings.ReminderAfter > 0 && settings.EscalateAfter > 0 && settings.EscalateAfter < settings.ReminderAfter {
+		violations = append(violations, entity.SettingsViolation{Field: "escalate_after", Err: entity.ErrEscalationTooEarly})
+	}
+	// more approvals than assigned reviewers would block every merge
+	if settings.MergePolicy.MinApprovals < 0 || settings.MergePolicy.MinApprovals > entity.MaxReviewers {
+		violations = append(violations, entity.SettingsViolation{Field: "merge_policy.min_approvals", Err: entity.ErrInvalidMergePolicy})
+	}
+
+	if len(violations) > 0 {
+		return &entity.InvalidSettingsError{Violations: violations}
+	}
+	return nil
+}
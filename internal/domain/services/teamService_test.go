@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/memory"
+)
+
+func TestTeamUseCase_UpdateSettingsMinApprovals(t *testing.T) {
+	store := memory.NewStore()
+	teamRepo, userRepo := memory.NewTeamRepository(store), memory.NewUserRepository(store)
+	uc := services.NewTeamUseCase(teamRepo, userRepo, store, services.NewAuthorizer(userRepo))
+
+	ctx := auth.WithPrincipal(context.Background(), auth.System())
+	if err := uc.CreateTeamWithUsers(ctx, &entity.Team{Name: "backend"}, []*entity.User{{ID: "u1", Username: "alice", IsActive: true}}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	tests := []struct {
+		minApprovals int
+		wantErr      error
+	}{
+		{0, nil},
+		{entity.MaxReviewers, nil},
+		// a pr never gets more approvals than assigned reviewers
+		{entity.MaxReviewers + 1, entity.ErrInvalidMergePolicy},
+		{-1, entity.ErrInvalidMergePolicy},
+	}
+	for _, tt := range tests {
+		_, err := uc.UpdateSettings(ctx, &entity.TeamSettings{TeamName: "backend", MergePolicy: entity.MergePolicy{MinApprovals: tt.minApprovals}})
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("min_approvals %d: err = %v, want %v", tt.minApprovals, err, tt.wantErr)
+		}
+	}
+
+	settings, err := uc.GetSettings(ctx, "backend")
+	if err != nil || settings.MergePolicy.MinApprovals != entity.MaxReviewers {
+		t.Errorf("settings = %+v, %v, want the last valid policy", settings, err)
+	}
+}
+
+func TestTeamUseCase_UpdateSettingsInvariants(t *testing.T) {
+	store := memory.NewStore()
+	teamRepo, userRepo := memory.NewTeamRepository(store), memory.NewUserRepository(store)
+	uc := services.NewTeamUseCase(teamRepo, userRepo, store, services.NewAuthorizer(userRepo))
+
+	ctx := auth.WithPrincipal(context.Background(), auth.System())
+	if err := uc.CreateTeamWithUsers(ctx, &entity.Team{Name: "backend"}, []*entity.User{{ID: "u1", Username: "alice", IsActive: true}}); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		settings   entity.TeamSettings
+		wantFields []string
+	}{
+		{"valid", entity.TeamSettings{ReminderAfter: time.Hour, EscalateAfter: 2 * time.Hour}, nil},
+		{"escalation disabled", entity.TeamSettings{ReminderAfter: time.Hour}, nil},
+		{"negative reminder", entity.TeamSettings{ReminderAfter: -time.Hour}, []string{"reminder_after"}},
+		{"escalation before reminder", entity.TeamSettings{ReminderAfter: 2 * time.Hour, EscalateAfter: time.Hour}, []string{"escalate_after"}},
+		{
+			"all broken at once",
+			entity.TeamSettings{EscalateAfter: -time.Hour, MergePolicy: entity.MergePolicy{MinApprovals: entity.MaxReviewers + 1}},
+			[]string{"escalate_after", "merge_policy.min_approvals"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			settings.TeamName = "backend"
+			_, err := uc.UpdateSettings(ctx, &settings)
+
+			var invalid *entity.InvalidSettingsError
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("UpdateSettings = %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &invalid) || !errors.Is(err, entity.ErrInvalidSettings) {
+				t.Fatalf("UpdateSettings = %v, want InvalidSettingsError", err)
+			}
+			fields := make([]string, len(invalid.Violations))
+			for i, v := range invalid.Violations {
+				fields[i] = v.Field
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package services implements business logic and domain rules
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// tokenPrefix makes issued secrets easy to recognize in logs and secret scanners
+const tokenPrefix = "prt_"
+
+// bootstrapTokenID identifies the static token from configuration
+const bootstrapTokenID = "bootstrap"
+
+// TokenService defines the interface for api token operations
+type TokenService interface {
+	Mint(ctx context.Context, name, userID string, scopes []entity.Scope, ttl time.Duration) (*entity.APIToken, string, error)
+	List(ctx context.Context) ([]*entity.APIToken, error)
+	Revoke(ctx context.Context, id string) (*entity.APIToken, error)
+	Authenticate(ctx context.Context, secret string) (*entity.APIToken, error)
+}
+
+// TokenUseCase implements the TokenService interface
+type TokenUseCase struct {
+	repo           repository.TokenRepository
+	userRepo       repository.UserRepository
+	bootstrapToken string
+}
+
+// NewTokenUseCase is the constructor for tokenusecase, a non-empty bootstrap token
+// is accepted with team:admin scope so that the first tokens can be minted
+func NewTokenUseCase(repo repository.TokenRepository, userRepo repository.UserRepository, bootstrapToken string) *TokenUseCase {
+	return &TokenUseCase{
+		repo:           repo,
+		userRepo:       userRepo,
+		bootstrapToken: bootstrapToken,
+	}
+}
+
+// Mint issues a new token bound to the current organization and returns its secret, which is shown only once
+func (uc *TokenUseCase) Mint(ctx context.Context, name, userID string, scopes []entity.Scope, ttl time.Duration) (*entity.APIToken, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", entity.ErrInvalidScope
+	}
+	for _, s := range scopes {
+		if !s.IsValid() {
+			return nil, "", entity.ErrInvalidScope
+		}
+	}
+
+	// tokens bound to a user act on behalf of that user
+	if userID != "" {
+		if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	id, err := randomString(8, hex.EncodeToString)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomString(32, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, "", err
+	}
+	secret = tokenPrefix + secret
+
+	token := &entity.APIToken{
+		ID:        id,
+		OrgID:     tenant.FromContext(ctx),
+		Name:      name,
+		UserID:    userID,
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+	}
+	if ttl > 0 {
+		expiresAt := token.CreatedAt.Add(ttl)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := uc.repo.Create(ctx, token, hashToken(secret)); err != nil {
+		return nil, "", err
+	}
+
+	return token, secret, nil
+}
+
+// List returns all issued tokens without secrets
+func (uc *TokenUseCase) List(ctx context.Context) ([]*entity.APIToken, error) {
+	return uc.repo.List(ctx)
+}
+
+// Revoke disables a token permanently
+func (uc *TokenUseCase) Revoke(ctx context.Context, id string) (*entity.APIToken, error) {
+	return uc.repo.Revoke(ctx, id)
+}
+
+// Authenticate resolves a presented secret to an active token
+func (uc *TokenUseCase) Authenticate(ctx context.Context, secret string) (*entity.APIToken, error) {
+	if secret == "" {
+		return nil, entity.ErrUnauthorized
+	}
+
+	// the bootstrap token is not bound to an organization and may act in any of them
+	if uc.bootstrapToken != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(uc.bootstrapToken)) == 1 {
+		return &entity.APIToken{
+			ID:     bootstrapTokenID,
+			Name:   bootstrapTokenID,
+			Scopes: []entity.Scope{entity.ScopeTeamAdmin},
+		}, nil
+	}
+
+	token, err := uc.repo.GetByHash(ctx, hashToken(secret))
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, entity.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.IsActive(time.Now()) {
+		return nil, entity.ErrUnauthorized
+	}
+
+	return token, nil
+}
+
+// hashToken returns the hex sha256 of a secret, only hashes are stored
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomString encodes n cryptographically random bytes
+func randomString(n int, encode func([]byte) string) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return encode(buf), nil
+}
@@ -10,35 +10,50 @@ import (
 
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error)
-	GetReviews(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	GetReviews(ctx context.Context, userID string, filter entity.PRFilter) (*entity.PRPage, error)
 }
 
 // UserUseCase implements the business logic for user operations
 type UserUseCase struct {
 	userRepo repository.UserRepository
 	prRepo   repository.PRRepository
+	authz    *Authorizer
 }
 
 // NewUserUseCase creates a new instance of userusecase with dependencies
-func NewUserUseCase(userRepo repository.UserRepository, prRepo repository.PRRepository) *UserUseCase {
+func NewUserUseCase(userRepo repository.UserRepository, prRepo repository.PRRepository, authz *Authorizer) *UserUseCase {
 	return &UserUseCase{
 		userRepo: userRepo,
 		prRepo:   prRepo,
+		authz:    authz,
 	}
 }
 
-// SetIsActive updates the user's active status
+// SetIsActive updates the user's active status, changing another user requires team admin role
 func (uc *UserUseCase) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+	target, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.authz.CanSetIsActive(ctx, target); err != nil {
+		return nil, err
+	}
+
 	updatedUser, err := uc.userRepo.SetIsActive(ctx, userID, isActive)
 	return updatedUser, err
 }
 
-// GetReviews retrieve reviews assigned to the user
-func (uc *UserUseCase) GetReviews(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
+// GetReviews retrieve a page of reviews assigned to the user, only open prs unless statuses are given
+func (uc *UserUseCase) GetReviews(ctx context.Context, userID string, filter entity.PRFilter) (*entity.PRPage, error) {
 	_, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return uc.prRepo.GetReviewsByUserID(ctx, userID)
+	if len(filter.Statuses) == 0 {
+		filter.Statuses = []entity.PRStatus{entity.StatusOpen}
+	}
+	filter.Limit = normalizePageSize(filter.Limit)
+
+	return uc.prRepo.GetReviewsByUserID(ctx, userID, filter)
 }
@@ -0,0 +1,33 @@
+// Package tenant carries the organization the current operation belongs to
+package tenant
+
+import (
+	"context"
+	"regexp"
+)
+
+// Default is the organization of single-tenant deployments and pre-existing data
+const Default = "default"
+
+// key for organization id in context
+type orgKey struct{}
+
+var orgIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// IsValid reports whether the id can be used as an organization id
+func IsValid(orgID string) bool {
+	return orgIDPattern.MatchString(orgID)
+}
+
+// WithOrg returns a copy of ctx scoped to the organization
+func WithOrg(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgKey{}, orgID)
+}
+
+// FromContext returns the organization of ctx, the default one if none is set
+func FromContext(ctx context.Context) string {
+	if orgID, ok := ctx.Value(orgKey{}).(string); ok && orgID != "" {
+		return orgID
+	}
+	return Default
+}
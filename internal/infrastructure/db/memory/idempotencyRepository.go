@@ -0,0 +1,85 @@
+// Package memory keeps all data in process memory, used by tests and local runs without postgres
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// IdempotencyRepository stores idempotency keys with their recorded responses in memory
+type IdempotencyRepository struct {
+	store *Store
+}
+
+// NewIdempotencyRepository creates new idempotency repository instance
+func NewIdempotencyRepository(store *Store) *IdempotencyRepository {
+	return &IdempotencyRepository{store: store}
+}
+
+// check for interface implementation
+var _ repository.IdempotencyRepository = (*IdempotencyRepository)(nil)
+
+// Reserve claims the key, keys older than ttl are forgotten and can be reused
+func (r *IdempotencyRepository) Reserve(ctx context.Context, principal, key, requestHash string, ttl time.Duration) (*entity.IdempotencyRecord, bool, error) {
+	defer r.store.write(ctx)()
+	data := r.store.data
+	k := idempotencyKey{tenant.FromContext(ctx), principal, key}
+	now := r.store.now()
+
+	if record, ok := data.idempotency[k]; ok && record.CreatedAt.After(now.Add(-ttl)) {
+		record.Body = slices.Clone(record.Body)
+		return &record, false, nil
+	}
+
+	data.idempotency[k] = entity.IdempotencyRecord{Key: key, RequestHash: requestHash, CreatedAt: now}
+	return nil, true, nil
+}
+
+// Complete stores the response of the request that reserved the key
+func (r *IdempotencyRepository) Complete(ctx context.Context, principal, key string, statusCode int, body []byte) error {
+	defer r.store.write(ctx)()
+	k := idempotencyKey{tenant.FromContext(ctx), principal, key}
+
+	record, ok := r.store.data.idempotency[k]
+	if !ok {
+		return nil
+	}
+
+	now := r.store.now()
+	record.StatusCode = statusCode
+	record.Body = slices.Clone(body)
+	record.CompletedAt = &now
+	r.store.data.idempotency[k] = record
+	return nil
+}
+
+// Release forgets an unfinished key so that the request can be retried
+func (r *IdempotencyRepository) Release(ctx context.Context, principal, key string) error {
+	defer r.store.write(ctx)()
+	k := idempotencyKey{tenant.FromContext(ctx), principal, key}
+
+	if record, ok := r.store.data.idempotency[k]; ok && record.CompletedAt == nil {
+		delete(r.store.data.idempotency, k)
+	}
+	return nil
+}
+
+// DeleteExpired removes keys older than ttl in all organizations
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	defer r.store.write(ctx)()
+	before := r.store.now().Add(-ttl)
+
+	var deleted int64
+	for k, record := range r.store.data.idempotency {
+		if record.CreatedAt.Before(before) {
+			delete(r.store.data.idempotency, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
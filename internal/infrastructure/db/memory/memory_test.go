@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository/repositorytest"
+)
+
+func newRepositories(_ *testing.T) repositorytest.Repositories {
+	store := NewStore()
+	return repositorytest.Repositories{
+		Transactor:  store,
+		Teams:       NewTeamRepository(store),
+		Users:       NewUserRepository(store),
+		PRs:         NewPRRepository(store),
+		SLA:         NewSLARepository(store),
+		Tokens:      NewTokenRepository(store),
+		Idempotency: NewIdempotencyRepository(store),
+	}
+}
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, newRepositories)
+}
+
+// TestConcurrentTransactions checks that read-modify-write transactions do not lose updates
+func TestConcurrentTransactions(t *testing.T) {
+	r := newRepositories(t)
+	ctx := context.Background()
+
+	users := []*entity.User{{ID: "u1", Username: "a", IsActive: true}, {ID: "u2", Username: "b", IsActive: true}}
+	if err := r.Teams.Create(ctx, &entity.Team{Name: "backend"}, users); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := r.PRs.Create(ctx, &entity.PullRequest{ID: "pr-1", Name: "x", AuthorID: "u1", Status: entity.StatusOpen, Reviewers: []entity.User{{ID: "u2"}}}); err != nil {
+		t.Fatalf("create pr: %v", err)
+	}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := r.Transactor.Do(ctx, func(ctx context.Context) error {
+				pr, err := r.PRs.GetByIDForUpdate(ctx, "pr-1")
+				if err != nil {
+					return err
+				}
+				return r.PRs.SetReviewers(ctx, pr.ID, []string{pr.Reviewers[0].ID})
+			})
+			if err != nil {
+				t.Errorf("Do: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	pr, err := r.PRs.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if pr.Version != workers+1 {
+		t.Errorf("version = %d, want %d", pr.Version, workers+1)
+	}
+}
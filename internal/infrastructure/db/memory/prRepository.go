@@ -0,0 +1,391 @@
+// Package memory keeps all data in process memory, used by tests and local runs without postgres
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// PRRepository manages pull requests in memory
+type PRRepository struct {
+	store *Store
+}
+
+// NewPRRepository creates new pr repository instance
+func NewPRRepository(store *Store) *PRRepository {
+	return &PRRepository{store: store}
+}
+
+// check for interface implementation
+var _ repository.PRRepository = (*PRRepository)(nil)
+
+// Create stores a new pull request with its initial reviewers
+func (r *PRRepository) Create(ctx context.Context, pr *entity.PullRequest) error {
+	defer r.store.write(ctx)()
+	data := r.store.data
+	orgID := tenant.FromContext(ctx)
+
+	if _, ok := data.prs[rowKey{orgID, pr.ID}]; ok {
+		return entity.ErrPRExists
+	}
+	if _, ok := data.users[rowKey{orgID, pr.AuthorID}]; !ok {
+		return entity.ErrNotFound
+	}
+
+	reviewerIDs := make([]string, len(pr.Reviewers))
+	for i, rev := range pr.Reviewers {
+		reviewerIDs[i] = rev.ID
+	}
+	if err := r.checkUsers(orgID, reviewerIDs); err != nil {
+		return err
+	}
+
+	row := &prRow{pr: entity.PullRequest{
+		ID:        pr.ID,
+		Name:      pr.Name,
+		AuthorID:  pr.AuthorID,
+		Status:    pr.Status,
+		Version:   1,
+		CreatedAt: r.store.now(),
+	}}
+	if pr.Status == entity.StatusOpen {
+		row.openedAt = &row.pr.CreatedAt
+	}
+	r.setReviewers(row, reviewerIDs)
+	data.prs[rowKey{orgID, pr.ID}] = row
+
+	pr.Version = 1
+	return nil
+}
+
+// GetByID retrieves a pull request by its unique identifier
+func (r *PRRepository) GetByID(ctx context.Context, id string) (*entity.PullRequest, error) {
+	defer r.store.read(ctx)()
+
+	row, ok := r.store.data.prs[rowKey{tenant.FromContext(ctx), id}]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+	return r.load(tenant.FromContext(ctx), row), nil
+}
+
+// GetByIDForUpdate retrieves a pull request, the transaction already holds the whole store
+func (r *PRRepository) GetByIDForUpdate(ctx context.Context, id string) (*entity.PullRequest, error) {
+	return r.GetByID(ctx, id)
+}
+
+// UpdateStatus updates the status of an existing pull request
+func (r *PRRepository) UpdateStatus(ctx context.Context, id string, status entity.PRStatus) (*entity.PullRequest, error) {
+	defer r.store.write(ctx)()
+	orgID := tenant.FromContext(ctx)
+
+	row, ok := r.store.data.prs[rowKey{orgID, id}]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	r.setStatus(row, status)
+	row.pr.Version++
+	return r.load(orgID, row), nil
+}
+
+// UpdateStatusAndReviewers replaces the reviewers and changes the status as one change,
+// so the version is bumped once
+func (r *PRRepository) UpdateStatusAndReviewers(ctx context.Context, id string, status entity.PRStatus, reviewerIDs []string) (*entity.PullRequest, error) {
+	defer r.store.write(ctx)()
+	orgID := tenant.FromContext(ctx)
+
+	row, ok := r.store.data.prs[rowKey{orgID, id}]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+	if err := r.checkUsers(orgID, reviewerIDs); err != nil {
+		return nil, err
+	}
+
+	r.setReviewers(row, reviewerIDs)
+	r.setStatus(row, status)
+	row.pr.Version++
+	return r.load(orgID, row), nil
+}
+
+// setStatus changes the status and maintains the lifecycle timestamps of the target status
+func (r *PRRepository) setStatus(row *prRow, status entity.PRStatus) {
+	now := r.store.now()
+	row.pr.Status = status
+
+	switch status {
+	case entity.StatusMerged:
+		row.pr.MergedAt = &now
+	case entity.StatusClosed:
+		row.pr.ClosedAt = &now
+	case entity.StatusOpen:
+		row.pr.ClosedAt = nil // ready or reopened, the sla starts over
+		row.openedAt = &now
+	case entity.StatusDraft:
+		row.pr.ClosedAt = nil // reopened
+	}
+}
+
+// SetReviewers updates the list of reviewers for a specific pr and bumps its version,
+// review state of reviewers that stay assigned is preserved and every change is logged
+func (r *PRRepository) SetReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+	defer r.store.write(ctx)()
+	orgID := tenant.FromContext(ctx)
+
+	row, ok := r.store.data.prs[rowKey{orgID, prID}]
+	if !ok {
+		return nil // nothing to update, like an update matching no rows
+	}
+	if err := r.checkUsers(orgID, reviewerIDs); err != nil {
+		return err
+	}
+
+	r.setReviewers(row, reviewerIDs)
+	row.pr.Version++
+	return nil
+}
+
+// checkUsers reports a missing reviewer like the foreign key does
+func (r *PRRepository) checkUsers(orgID string, userIDs []string) error {
+	for _, id := range userIDs {
+		if _, ok := r.store.data.users[rowKey{orgID, id}]; !ok {
+			return entity.ErrNotFound
+		}
+	}
+	return nil
+}
+
+// setReviewers replaces the reviewers of a row without touching its version
+func (r *PRRepository) setReviewers(row *prRow, reviewerIDs []string) {
+	now := r.store.now()
+
+	// remove reviewers that are no longer assigned and log the removal
+	kept := row.reviews[:0:0]
+	for _, review := range row.reviews {
+		if slices.Contains(reviewerIDs, review.ReviewerID) {
+			kept = append(kept, review)
+			continue
+		}
+		row.events = append(row.events, entity.AssignmentEvent{ReviewerID: review.ReviewerID, Action: entity.ActionUnassigned, CreatedAt: now})
+	}
+
+	// add new reviewers, existing ones are kept as is
+	for _, id := range reviewerIDs {
+		if slices.ContainsFunc(kept, func(review entity.Review) bool { return review.ReviewerID == id }) {
+			continue
+		}
+		kept = append(kept, entity.Review{
+			PRID:       row.pr.ID,
+			ReviewerID: id,
+			State:      entity.ReviewPending,
+			AssignedAt: now,
+			UpdatedAt:  now,
+		})
+		row.events = append(row.events, entity.AssignmentEvent{ReviewerID: id, Action: entity.ActionAssigned, CreatedAt: now})
+	}
+
+	row.reviews = kept
+}
+
+// GetReviewsByUserID returns a page of pull requests assigned to a specific reviewer
+func (r *PRRepository) GetReviewsByUserID(ctx context.Context, userID string, filter entity.PRFilter) (*entity.PRPage, error) {
+	filter.ReviewerID = userID
+	return r.List(ctx, filter)
+}
+
+// GetReviewersByPRID retrieves all users assigned to review a specific pr
+func (r *PRRepository) GetReviewersByPRID(ctx context.Context, prID string) ([]*entity.User, error) {
+	defer r.store.read(ctx)()
+	orgID := tenant.FromContext(ctx)
+
+	row, ok := r.store.data.prs[rowKey{orgID, prID}]
+	if !ok {
+		return nil, nil
+	}
+
+	var reviewers []*entity.User
+	for _, review := range row.reviews {
+		user := r.store.data.users[rowKey{orgID, review.ReviewerID}]
+		reviewers = append(reviewers, &user)
+	}
+	return reviewers, nil
+}
+
+// SubmitReview stores the reviewer's review state and first response time
+func (r *PRRepository) SubmitReview(ctx context.Context, prID, reviewerID string, state entity.ReviewState) (*entity.Review, error) {
+	defer r.store.write(ctx)()
+
+	row, ok := r.store.data.prs[rowKey{tenant.FromContext(ctx), prID}]
+	if !ok {
+		return nil, entity.ErrNotAssigned
+	}
+
+	i := slices.IndexFunc(row.reviews, func(review entity.Review) bool { return review.ReviewerID == reviewerID })
+	if i < 0 {
+		return nil, entity.ErrNotAssigned
+	}
+
+	now := r.store.now()
+	review := row.reviews[i]
+	review.State = state
+	review.UpdatedAt = now
+	if review.FirstResponseAt == nil {
+		review.FirstResponseAt = &now
+	}
+	row.reviews[i] = review
+	row.pr.Version++
+
+	return &review, nil
+}
+
+// List returns a page of pull requests matching the filter in created_at, id order
+func (r *PRRepository) List(ctx context.Context, filter entity.PRFilter) (*entity.PRPage, error) {
+	defer r.store.read(ctx)()
+	data := r.store.data
+	orgID := tenant.FromContext(ctx)
+
+	rows := make([]*prRow, 0)
+	for k, row := range data.prs {
+		if k.org == orgID && r.matches(orgID, row, filter) {
+			rows = append(rows, row)
+		}
+	}
+
+	slices.SortFunc(rows, func(a, b *prRow) int {
+		c := compareCursor(a.pr.CreatedAt, a.pr.ID, b.pr.CreatedAt, b.pr.ID)
+		if filter.Desc {
+			return -c
+		}
+		return c
+	})
+
+	page := &entity.PRPage{Items: make([]*entity.PullRequest, 0, len(rows))}
+	for _, row := range rows {
+		if filter.Limit > 0 && len(page.Items) == filter.Limit {
+			last := page.Items[len(page.Items)-1]
+			page.Next = &entity.PRCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+			break
+		}
+		page.Items = append(page.Items, r.load(orgID, row))
+	}
+
+	return page, nil
+}
+
+// matches applies listing conditions to a single pr
+func (r *PRRepository) matches(orgID string, row *prRow, filter entity.PRFilter) bool {
+	pr := row.pr
+
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, pr.Status) {
+		return false
+	}
+	if filter.AuthorID != "" && pr.AuthorID != filter.AuthorID {
+		return false
+	}
+	if filter.ReviewerID != "" {
+		assigned := slices.ContainsFunc(row.reviews, func(review entity.Review) bool {
+			return review.ReviewerID == filter.ReviewerID && (!filter.OnlyPending || review.State == entity.ReviewPending)
+		})
+		if !assigned {
+			return false
+		}
+	}
+	if filter.TeamName != "" {
+		author, ok := r.store.data.users[rowKey{orgID, pr.AuthorID}]
+		if !ok || author.TeamName != filter.TeamName {
+			return false
+		}
+	}
+	if filter.CreatedFrom != nil && pr.CreatedAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+	if filter.CreatedTo != nil && !pr.CreatedAt.Before(*filter.CreatedTo) {
+		return false
+	}
+	if filter.MergedFrom != nil && (pr.MergedAt == nil || pr.MergedAt.Before(*filter.MergedFrom)) {
+		return false
+	}
+	if filter.MergedTo != nil && (pr.MergedAt == nil || !pr.MergedAt.Before(*filter.MergedTo)) {
+		return false
+	}
+
+	// keyset condition continues right after the cursor in the chosen direction
+	if filter.After != nil {
+		c := compareCursor(pr.CreatedAt, pr.ID, filter.After.CreatedAt, filter.After.ID)
+		if (filter.Desc && c >= 0) || (!filter.Desc && c <= 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetAssignmentHistory counts reviewer changes of a pr and keeps the last event of every reviewer
+func (r *PRRepository) GetAssignmentHistory(ctx context.Context, prID string) (*entity.AssignmentHistory, error) {
+	defer r.store.read(ctx)()
+
+	history := &entity.AssignmentHistory{Events: make([]entity.AssignmentEvent, 0)}
+	row, ok := r.store.data.prs[rowKey{tenant.FromContext(ctx), prID}]
+	if !ok || len(row.events) == 0 {
+		return history, nil
+	}
+
+	last := make(map[string]int) // reviewer id -> index of the last event
+	for i, e := range row.events {
+		switch e.Action {
+		case entity.ActionAssigned:
+			history.Assignments++
+		case entity.ActionUnassigned:
+			history.Unassignments++
+		}
+		last[e.ReviewerID] = i
+	}
+	for i, e := range row.events {
+		if last[e.ReviewerID] == i {
+			history.Events = append(history.Events, e)
+		}
+	}
+
+	lastChangeAt := row.events[len(row.events)-1].CreatedAt
+	history.LastChangeAt = &lastChangeAt
+	return history, nil
+}
+
+// load builds the returned entity with reviewers, review states and the author
+func (r *PRRepository) load(orgID string, row *prRow) *entity.PullRequest {
+	pr := row.pr
+	pr.Reviewers = make([]entity.User, 0, len(row.reviews))
+	pr.Reviews = make([]entity.Review, 0, len(row.reviews))
+
+	// same order as the postgres implementation: assigned_at, reviewer id
+	reviews := slices.Clone(row.reviews)
+	slices.SortStableFunc(reviews, func(a, b entity.Review) int {
+		return compareCursor(a.AssignedAt, a.ReviewerID, b.AssignedAt, b.ReviewerID)
+	})
+
+	for _, review := range reviews {
+		pr.Reviewers = append(pr.Reviewers, r.store.data.users[rowKey{orgID, review.ReviewerID}])
+		pr.Reviews = append(pr.Reviews, review)
+	}
+
+	if author, ok := r.store.data.users[rowKey{orgID, pr.AuthorID}]; ok {
+		pr.Author = &author
+	}
+
+	return &pr
+}
+
+// compareCursor orders rows by time and then id
+func compareCursor(aTime time.Time, aID string, bTime time.Time, bID string) int {
+	if c := aTime.Compare(bTime); c != 0 {
+		return c
+	}
+	return strings.Compare(aID, bID)
+}
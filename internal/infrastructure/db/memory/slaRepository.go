@@ -0,0 +1,102 @@
+// Package memory keeps all data in process memory, used by tests and local runs without postgres
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// SLARepository tracks sent review sla events in memory
+type SLARepository struct {
+	store *Store
+}
+
+// NewSLARepository creates new sla repository instance
+func NewSLARepository(store *Store) *SLARepository {
+	return &SLARepository{store: store}
+}
+
+// check for interface implementation
+var _ repository.SLARepository = (*SLARepository)(nil)
+
+// ListBreaches finds open pull requests of all organizations whose author team sla
+// of the given kind has expired and for which no event of that kind was sent yet,
+// the sla of a reviewer who has not responded runs from the later of the pr entering OPEN
+// and the reviewer assignment
+func (r *SLARepository) ListBreaches(ctx context.Context, kind entity.SLAKind, now time.Time) ([]*entity.SLABreach, error) {
+	if kind != entity.SLAReminder && kind != entity.SLAEscalation {
+		return nil, fmt.Errorf("SLARepo.ListBreaches: unknown sla kind %q", kind)
+	}
+
+	defer r.store.read(ctx)()
+	data := r.store.data
+
+	breaches := make([]*entity.SLABreach, 0)
+	for k, row := range data.prs {
+		if row.pr.Status != entity.StatusOpen {
+			continue
+		}
+		author, ok := data.users[rowKey{k.org, row.pr.AuthorID}]
+		if !ok {
+			continue
+		}
+		settings, ok := data.settings[rowKey{k.org, author.TeamName}]
+		if !ok {
+			continue
+		}
+
+		// pick the threshold matching the sla kind
+		threshold := settings.ReminderAfter
+		if kind == entity.SLAEscalation {
+			threshold = settings.EscalateAfter
+		}
+		if threshold <= 0 || row.openedAt == nil || !slices.ContainsFunc(row.reviews, func(review entity.Review) bool {
+			return review.FirstResponseAt == nil && !later(*row.openedAt, review.AssignedAt).After(now.Add(-threshold))
+		}) {
+			continue
+		}
+		if _, sent := data.slaEvents[slaKey{k.org, row.pr.ID, kind}]; sent {
+			continue
+		}
+
+		pr := row.pr
+		breaches = append(breaches, &entity.SLABreach{OrgID: k.org, PR: &pr, Kind: kind, AutoReassign: settings.AutoReassign})
+	}
+
+	slices.SortFunc(breaches, func(a, b *entity.SLABreach) int {
+		return compareCursor(a.PR.CreatedAt, a.PR.ID, b.PR.CreatedAt, b.PR.ID)
+	})
+	return breaches, nil
+}
+
+// MarkSent records that an sla event was emitted, returns false if it was already recorded
+func (r *SLARepository) MarkSent(ctx context.Context, prID string, kind entity.SLAKind) (bool, error) {
+	defer r.store.write(ctx)()
+	data := r.store.data
+	orgID := tenant.FromContext(ctx)
+
+	if _, ok := data.prs[rowKey{orgID, prID}]; !ok {
+		return false, entity.ErrNotFound
+	}
+
+	k := slaKey{orgID, prID, kind}
+	if _, ok := data.slaEvents[k]; ok {
+		return false, nil
+	}
+	data.slaEvents[k] = r.store.now()
+	return true, nil
+}
+
+// later returns the later of two times
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
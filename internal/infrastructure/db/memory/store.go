@@ -0,0 +1,171 @@
+// Package memory keeps all data in process memory, used by tests and local runs without postgres
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+)
+
+// key for transaction in context
+type txKey struct{}
+
+// rowKey identifies a row inside an organization
+type rowKey struct {
+	org string
+	id  string
+}
+
+// prRow is a stored pull request with its reviewers and assignment log
+type prRow struct {
+	pr      entity.PullRequest // reviewers, reviews and author are not stored here
+	reviews []entity.Review    // in assignment order
+	events  []entity.AssignmentEvent
+	// when the pr last entered OPEN, the review sla runs from it, nil for a draft never opened
+	openedAt *time.Time
+}
+
+// slaKey identifies a sent sla event
+type slaKey struct {
+	org  string
+	prID string
+	kind entity.SLAKind
+}
+
+// idempotencyKey identifies a key of one caller
+type idempotencyKey struct {
+	org       string
+	principal string
+	key       string
+}
+
+// tokenRow is a stored api token with the hash of its secret
+type tokenRow struct {
+	token entity.APIToken
+	hash  string
+}
+
+// state holds all tables, it is copied as a whole for transaction snapshots
+type state struct {
+	teams       map[rowKey]entity.Team
+	settings    map[rowKey]entity.TeamSettings
+	users       map[rowKey]entity.User
+	prs         map[rowKey]*prRow
+	slaEvents   map[slaKey]time.Time
+	tokens      map[rowKey]tokenRow
+	idempotency map[idempotencyKey]entity.IdempotencyRecord
+}
+
+func newState() *state {
+	return &state{
+		teams:       make(map[rowKey]entity.Team),
+		settings:    make(map[rowKey]entity.TeamSettings),
+		users:       make(map[rowKey]entity.User),
+		prs:         make(map[rowKey]*prRow),
+		slaEvents:   make(map[slaKey]time.Time),
+		tokens:      make(map[rowKey]tokenRow),
+		idempotency: make(map[idempotencyKey]entity.IdempotencyRecord),
+	}
+}
+
+// clone returns a deep copy of mutable parts, stored entities are replaced and never changed in place
+func (s *state) clone() *state {
+	c := &state{
+		teams:       maps.Clone(s.teams),
+		settings:    maps.Clone(s.settings),
+		users:       maps.Clone(s.users),
+		prs:         make(map[rowKey]*prRow, len(s.prs)),
+		slaEvents:   maps.Clone(s.slaEvents),
+		tokens:      maps.Clone(s.tokens),
+		idempotency: maps.Clone(s.idempotency),
+	}
+	for k, row := range s.prs {
+		c.prs[k] = &prRow{
+			pr:       row.pr,
+			reviews:  slices.Clone(row.reviews),
+			events:   slices.Clone(row.events),
+			openedAt: row.openedAt,
+		}
+	}
+	return c
+}
+
+// Store is the in-memory database shared by all memory repositories,
+// it implements the transactor with snapshot and rollback semantics
+type Store struct {
+	// held by a transaction for its whole duration, so transactions are serialized
+	// and rows read inside a transaction are locked like with SELECT ... FOR UPDATE
+	mu   sync.RWMutex
+	data *state
+	now  func() time.Time
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{data: newState(), now: now}
+}
+
+// now returns the current time with the precision postgres stores
+func now() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+// check for interface implementation
+var _ repository.Transactor = (*Store)(nil)
+
+// Do executes function within transaction, changes are discarded if it fails or panics
+func (s *Store) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+
+	// check if transaction already exists
+	if s.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// restore the snapshot on error or panic
+	snapshot := s.data.clone()
+	committed := false
+	defer func() {
+		if !committed {
+			s.data = snapshot
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, s)); err != nil {
+		return err
+	}
+
+	committed = true
+	return nil
+}
+
+// inTx reports whether ctx carries a transaction of this store
+func (s *Store) inTx(ctx context.Context) bool {
+	tx, _ := ctx.Value(txKey{}).(*Store)
+	return tx == s
+}
+
+// read locks the store for reading unless the caller is inside a transaction
+func (s *Store) read(ctx context.Context) func() {
+	if s.inTx(ctx) {
+		return func() {}
+	}
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+// write locks the store for writing unless the caller is inside a transaction
+func (s *Store) write(ctx context.Context) func() {
+	if s.inTx(ctx) {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
@@ -0,0 +1,94 @@
+// Package memory keeps all data in process memory, used by tests and local runs without postgres
+package memory
+
+import (
+	"context"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// TeamRepository manages teams and their settings in memory
+type TeamRepository struct {
+	store *Store
+}
+
+// NewTeamRepository creates new team repository instance
+func NewTeamRepository(store *Store) *TeamRepository {
+	return &TeamRepository{store: store}
+}
+
+// check for interface implementation
+var _ repository.TeamRepository = (*TeamRepository)(nil)
+
+// Create creates a new team and its members, nothing is stored if any member is invalid
+func (r *TeamRepository) Create(ctx context.Context, team *entity.Team, users []*entity.User) error {
+	defer r.store.write(ctx)()
+	data := r.store.data
+	orgID := tenant.FromContext(ctx)
+
+	if _, ok := data.teams[rowKey{orgID, team.Name}]; ok {
+		return entity.ErrTeamExists
+	}
+
+	// check every member before inserting anything, like a failed statement inserts nothing
+	seen := make(map[string]bool, len(users))
+	for _, u := range users {
+		if _, ok := data.users[rowKey{orgID, u.ID}]; ok || seen[u.ID] {
+			return entity.ErrUserExists
+		}
+		seen[u.ID] = true
+	}
+
+	data.teams[rowKey{orgID, team.Name}] = entity.Team{Name: team.Name}
+	for _, u := range users {
+		data.users[rowKey{orgID, u.ID}] = entity.User{
+			ID:       u.ID,
+			Username: u.Username,
+			TeamName: team.Name,
+			IsActive: u.IsActive,
+			Role:     roleOrDefault(u.Role),
+		}
+	}
+
+	return nil
+}
+
+// GetByName retrieves team details by name
+func (r *TeamRepository) GetByName(ctx context.Context, name string) (*entity.Team, error) {
+	defer r.store.read(ctx)()
+
+	team, ok := r.store.data.teams[rowKey{tenant.FromContext(ctx), name}]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+	return &team, nil
+}
+
+// GetSettings retrieves team review settings, returning defaults if none are stored
+func (r *TeamRepository) GetSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	defer r.store.read(ctx)()
+
+	settings, ok := r.store.data.settings[rowKey{tenant.FromContext(ctx), teamName}]
+	if !ok {
+		// settings are optional, everything is disabled by default
+		return &entity.TeamSettings{TeamName: teamName}, nil
+	}
+	return &settings, nil
+}
+
+// SaveSettings creates or replaces team review settings
+func (r *TeamRepository) SaveSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	defer r.store.write(ctx)()
+	data := r.store.data
+	orgID := tenant.FromContext(ctx)
+
+	// settings reference the team like the foreign key does
+	if _, ok := data.teams[rowKey{orgID, settings.TeamName}]; !ok {
+		return entity.ErrNotFound
+	}
+
+	data.settings[rowKey{orgID, settings.TeamName}] = *settings
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package memory keeps all data in process memory, used by tests and local runs without postgres
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// TokenRepository manages api tokens in memory
+type TokenRepository struct {
+	store *Store
+}
+
+// NewTokenRepository creates new token repository instance
+func NewTokenRepository(store *Store) *TokenRepository {
+	return &TokenRepository{store: store}
+}
+
+// check for interface implementation
+var _ repository.TokenRepository = (*TokenRepository)(nil)
+
+// Create stores a new token together with the hash of its secret
+func (r *TokenRepository) Create(ctx context.Context, token *entity.APIToken, tokenHash string) error {
+	defer r.store.write(ctx)()
+	data := r.store.data
+
+	if _, ok := data.tokens[rowKey{token.OrgID, token.ID}]; ok {
+		return fmt.Errorf("TokenRepo.Create: duplicate token id %s", token.ID)
+	}
+	for _, row := range data.tokens {
+		if row.hash == tokenHash {
+			return fmt.Errorf("TokenRepo.Create: duplicate token hash")
+		}
+	}
+	if token.UserID != "" {
+		if _, ok := data.users[rowKey{token.OrgID, token.UserID}]; !ok {
+			return entity.ErrNotFound
+		}
+	}
+
+	data.tokens[rowKey{token.OrgID, token.ID}] = tokenRow{token: *token, hash: tokenHash}
+	return nil
+}
+
+// GetByHash finds a token by the hash of its secret in any organization,
+// it runs before the organization of the request is known
+func (r *TokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.APIToken, error) {
+	defer r.store.read(ctx)()
+
+	for _, row := range r.store.data.tokens {
+		if row.hash == tokenHash {
+			token := row.token
+			return &token, nil
+		}
+	}
+	return nil, entity.ErrNotFound
+}
+
+// List returns all tokens issued in the organization, newest first
+func (r *TokenRepository) List(ctx context.Context) ([]*entity.APIToken, error) {
+	defer r.store.read(ctx)()
+	orgID := tenant.FromContext(ctx)
+
+	tokens := make([]*entity.APIToken, 0)
+	for k, row := range r.store.data.tokens {
+		if k.org == orgID {
+			token := row.token
+			tokens = append(tokens, &token)
+		}
+	}
+
+	slices.SortFunc(tokens, func(a, b *entity.APIToken) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return tokens, nil
+}
+
+// Revoke marks a token as revoked, revoking twice keeps the first timestamp
+func (r *TokenRepository) Revoke(ctx context.Context, id string) (*entity.APIToken, error) {
+	defer r.store.write(ctx)()
+	k := rowKey{tenant.FromContext(ctx), id}
+
+	row, ok := r.store.data.tokens[k]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	if row.token.RevokedAt == nil {
+		now := r.store.now()
+		row.token.RevokedAt = &now
+		r.store.data.tokens[k] = row
+	}
+
+	token := row.token
+	return &token, nil
+}
@@ -0,0 +1,113 @@
+// Package memory keeps all data in process memory, used by tests and local runs without postgres
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// UserRepository manages users in memory
+type UserRepository struct {
+	store *Store
+}
+
+// NewUserRepository creates new user repository
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{store: store}
+}
+
+// check for interface implementation
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+// GetByID fetches a user by unique identifier
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*entity.User, error) {
+	defer r.store.read(ctx)()
+
+	user, ok := r.store.data.users[rowKey{tenant.FromContext(ctx), id}]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+	return &user, nil
+}
+
+// Create adds a new user to an existing team
+func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
+	defer r.store.write(ctx)()
+	data := r.store.data
+	orgID := tenant.FromContext(ctx)
+
+	if _, ok := data.users[rowKey{orgID, user.ID}]; ok {
+		return entity.ErrUserExists
+	}
+	if _, ok := data.teams[rowKey{orgID, user.TeamName}]; !ok {
+		return entity.ErrNotFound
+	}
+
+	stored := *user
+	stored.Role = roleOrDefault(user.Role)
+	data.users[rowKey{orgID, user.ID}] = stored
+	return nil
+}
+
+// GetActiveCandidatesByTeam retrieves potential reviewers from the same team in id order
+func (r *UserRepository) GetActiveCandidatesByTeam(ctx context.Context, teamName string, excludeUserID string) ([]*entity.User, error) {
+	defer r.store.read(ctx)()
+	orgID := tenant.FromContext(ctx)
+
+	users := make([]*entity.User, 0)
+	for k, u := range r.store.data.users {
+		if k.org == orgID && u.TeamName == teamName && u.IsActive && u.ID != excludeUserID {
+			user := u
+			users = append(users, &user)
+		}
+	}
+
+	// map order is random, keep results stable
+	slices.SortFunc(users, func(a, b *entity.User) int { return strings.Compare(a.ID, b.ID) })
+	return users, nil
+}
+
+// ListByTeam retrieves all members of a team including inactive ones in id order
+func (r *UserRepository) ListByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	defer r.store.read(ctx)()
+	orgID := tenant.FromContext(ctx)
+
+	users := make([]*entity.User, 0)
+	for k, u := range r.store.data.users {
+		if k.org == orgID && u.TeamName == teamName {
+			user := u
+			users = append(users, &user)
+		}
+	}
+
+	slices.SortFunc(users, func(a, b *entity.User) int { return strings.Compare(a.ID, b.ID) })
+	return users, nil
+}
+
+// SetIsActive updates user's active status
+func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+	defer r.store.write(ctx)()
+	k := rowKey{tenant.FromContext(ctx), userID}
+
+	user, ok := r.store.data.users[k]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	user.IsActive = isActive
+	r.store.data.users[k] = user
+	return &user, nil
+}
+
+// roleOrDefault stores members without an explicit role as regular members
+func roleOrDefault(role entity.Role) entity.Role {
+	if role == "" {
+		return entity.RoleMember
+	}
+	return role
+}
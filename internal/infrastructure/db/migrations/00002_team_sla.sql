@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE team_settings (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE,
+    reminder_after INTERVAL NOT NULL DEFAULT '0',
+    escalate_after INTERVAL NOT NULL DEFAULT '0',
+    auto_reassign BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE TABLE pr_sla_events (
+    pr_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('REMINDER', 'ESCALATION')),
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pr_id, kind)
+);
+
+CREATE INDEX idx_pr_status_created ON pull_requests(status, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_status_created;
+DROP TABLE IF EXISTS pr_sla_events;
+DROP TABLE IF EXISTS team_settings;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr_reviewers
+    ADD COLUMN state VARCHAR(20) NOT NULL DEFAULT 'PENDING'
+        CHECK (state IN ('PENDING', 'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    ADD COLUMN assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN first_response_at TIMESTAMPTZ,
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_pr_reviewers_reviewer_state ON pr_reviewers(reviewer_id, state);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_state;
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS first_response_at,
+    DROP COLUMN IF EXISTS assigned_at,
+    DROP COLUMN IF EXISTS state;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team_settings
+    ADD COLUMN min_approvals INTEGER NOT NULL DEFAULT 0 CHECK (min_approvals >= 0),
+    ADD COLUMN require_all_approved BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN block_on_changes_requested BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS block_on_changes_requested,
+    DROP COLUMN IF EXISTS require_all_approved,
+    DROP COLUMN IF EXISTS min_approvals;
+-- +goose StatementEnd
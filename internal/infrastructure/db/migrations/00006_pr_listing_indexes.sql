@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_status_created;
+CREATE INDEX idx_pr_status_created_id ON pull_requests(status, created_at, id);
+CREATE INDEX idx_pr_created_id ON pull_requests(created_at, id);
+CREATE INDEX idx_pr_author_created_id ON pull_requests(author_id, created_at, id);
+CREATE INDEX idx_pr_merged_at ON pull_requests(merged_at) WHERE merged_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_merged_at;
+DROP INDEX IF EXISTS idx_pr_author_created_id;
+DROP INDEX IF EXISTS idx_pr_created_id;
+DROP INDEX IF EXISTS idx_pr_status_created_id;
+CREATE INDEX idx_pr_status_created ON pull_requests(status, created_at);
+-- +goose StatementEnd
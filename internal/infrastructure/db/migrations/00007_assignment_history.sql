@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pr_assignment_events (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(255) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('ASSIGNED', 'UNASSIGNED')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pr_assignment_events_pr ON pr_assignment_events(pr_id, id);
+
+-- current assignments become the initial history
+INSERT INTO pr_assignment_events (pr_id, reviewer_id, action, created_at)
+SELECT pr_id, reviewer_id, 'ASSIGNED', assigned_at FROM pr_reviewers;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pr_assignment_events;
+-- +goose StatementEnd
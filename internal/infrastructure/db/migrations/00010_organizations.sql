@@ -0,0 +1,142 @@
+-- +goose Up
+-- +goose StatementBegin
+-- every key gets the organization as its first column, foreign keys are rebuilt accordingly
+ALTER TABLE users DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_author_id_fkey;
+ALTER TABLE pr_reviewers
+    DROP CONSTRAINT pr_reviewers_pr_id_fkey,
+    DROP CONSTRAINT pr_reviewers_reviewer_id_fkey;
+ALTER TABLE team_settings DROP CONSTRAINT team_settings_team_name_fkey;
+ALTER TABLE pr_sla_events DROP CONSTRAINT pr_sla_events_pr_id_fkey;
+ALTER TABLE pr_assignment_events
+    DROP CONSTRAINT pr_assignment_events_pr_id_fkey,
+    DROP CONSTRAINT pr_assignment_events_reviewer_id_fkey;
+ALTER TABLE api_tokens DROP CONSTRAINT api_tokens_user_id_fkey;
+
+-- existing data belongs to the default organization
+ALTER TABLE teams ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE pr_reviewers ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE team_settings ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE pr_sla_events ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE pr_assignment_events ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE api_tokens ADD COLUMN org_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+-- new rows must always name their organization
+ALTER TABLE teams ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pr_reviewers ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE team_settings ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pr_sla_events ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pr_assignment_events ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE api_tokens ALTER COLUMN org_id DROP DEFAULT;
+
+-- ids are unique within an organization only
+ALTER TABLE teams DROP CONSTRAINT teams_pkey, ADD PRIMARY KEY (org_id, name);
+ALTER TABLE users DROP CONSTRAINT users_pkey, ADD PRIMARY KEY (org_id, id);
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey, ADD PRIMARY KEY (org_id, id);
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pkey, ADD PRIMARY KEY (org_id, pr_id, reviewer_id);
+ALTER TABLE team_settings DROP CONSTRAINT team_settings_pkey, ADD PRIMARY KEY (org_id, team_name);
+ALTER TABLE pr_sla_events DROP CONSTRAINT pr_sla_events_pkey, ADD PRIMARY KEY (org_id, pr_id, kind);
+
+ALTER TABLE users
+    ADD FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, name) ON DELETE CASCADE;
+ALTER TABLE pull_requests
+    ADD FOREIGN KEY (org_id, author_id) REFERENCES users(org_id, id) ON DELETE RESTRICT;
+ALTER TABLE pr_reviewers
+    ADD FOREIGN KEY (org_id, pr_id) REFERENCES pull_requests(org_id, id) ON DELETE CASCADE,
+    ADD FOREIGN KEY (org_id, reviewer_id) REFERENCES users(org_id, id) ON DELETE RESTRICT;
+ALTER TABLE team_settings
+    ADD FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, name) ON DELETE CASCADE;
+ALTER TABLE pr_sla_events
+    ADD FOREIGN KEY (org_id, pr_id) REFERENCES pull_requests(org_id, id) ON DELETE CASCADE;
+ALTER TABLE pr_assignment_events
+    ADD FOREIGN KEY (org_id, pr_id) REFERENCES pull_requests(org_id, id) ON DELETE CASCADE,
+    ADD FOREIGN KEY (org_id, reviewer_id) REFERENCES users(org_id, id) ON DELETE RESTRICT;
+ALTER TABLE api_tokens
+    ADD FOREIGN KEY (org_id, user_id) REFERENCES users(org_id, id) ON DELETE CASCADE;
+
+-- every query filters by organization first
+DROP INDEX IF EXISTS idx_users_team_active;
+DROP INDEX IF EXISTS idx_pr_author_status;
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_id;
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_state;
+DROP INDEX IF EXISTS idx_pr_status_created_id;
+DROP INDEX IF EXISTS idx_pr_created_id;
+DROP INDEX IF EXISTS idx_pr_author_created_id;
+DROP INDEX IF EXISTS idx_pr_assignment_events_pr;
+CREATE INDEX idx_users_team_active ON users(org_id, team_name, is_active);
+CREATE INDEX idx_pr_author_status ON pull_requests(org_id, author_id, status);
+CREATE INDEX idx_pr_reviewers_reviewer_id ON pr_reviewers(org_id, reviewer_id);
+CREATE INDEX idx_pr_reviewers_reviewer_state ON pr_reviewers(org_id, reviewer_id, state);
+CREATE INDEX idx_pr_status_created_id ON pull_requests(org_id, status, created_at, id);
+CREATE INDEX idx_pr_created_id ON pull_requests(org_id, created_at, id);
+CREATE INDEX idx_pr_author_created_id ON pull_requests(org_id, author_id, created_at, id);
+CREATE INDEX idx_pr_assignment_events_pr ON pr_assignment_events(org_id, pr_id, id);
+CREATE INDEX idx_api_tokens_org_created ON api_tokens(org_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- fails on duplicate ids if several organizations were in use
+DROP INDEX IF EXISTS idx_api_tokens_org_created;
+DROP INDEX IF EXISTS idx_users_team_active;
+DROP INDEX IF EXISTS idx_pr_author_status;
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_id;
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer_state;
+DROP INDEX IF EXISTS idx_pr_status_created_id;
+DROP INDEX IF EXISTS idx_pr_created_id;
+DROP INDEX IF EXISTS idx_pr_author_created_id;
+DROP INDEX IF EXISTS idx_pr_assignment_events_pr;
+
+ALTER TABLE api_tokens DROP CONSTRAINT api_tokens_org_id_user_id_fkey;
+ALTER TABLE pr_assignment_events
+    DROP CONSTRAINT pr_assignment_events_org_id_pr_id_fkey,
+    DROP CONSTRAINT pr_assignment_events_org_id_reviewer_id_fkey;
+ALTER TABLE pr_sla_events DROP CONSTRAINT pr_sla_events_org_id_pr_id_fkey;
+ALTER TABLE team_settings DROP CONSTRAINT team_settings_org_id_team_name_fkey;
+ALTER TABLE pr_reviewers
+    DROP CONSTRAINT pr_reviewers_org_id_pr_id_fkey,
+    DROP CONSTRAINT pr_reviewers_org_id_reviewer_id_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_org_id_author_id_fkey;
+ALTER TABLE users DROP CONSTRAINT users_org_id_team_name_fkey;
+
+ALTER TABLE pr_sla_events DROP CONSTRAINT pr_sla_events_pkey, ADD PRIMARY KEY (pr_id, kind);
+ALTER TABLE team_settings DROP CONSTRAINT team_settings_pkey, ADD PRIMARY KEY (team_name);
+ALTER TABLE pr_reviewers DROP CONSTRAINT pr_reviewers_pkey, ADD PRIMARY KEY (pr_id, reviewer_id);
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey, ADD PRIMARY KEY (id);
+ALTER TABLE users DROP CONSTRAINT users_pkey, ADD PRIMARY KEY (id);
+ALTER TABLE teams DROP CONSTRAINT teams_pkey, ADD PRIMARY KEY (name);
+
+ALTER TABLE api_tokens DROP COLUMN org_id;
+ALTER TABLE pr_assignment_events DROP COLUMN org_id;
+ALTER TABLE pr_sla_events DROP COLUMN org_id;
+ALTER TABLE team_settings DROP COLUMN org_id;
+ALTER TABLE pr_reviewers DROP COLUMN org_id;
+ALTER TABLE pull_requests DROP COLUMN org_id;
+ALTER TABLE users DROP COLUMN org_id;
+ALTER TABLE teams DROP COLUMN org_id;
+
+ALTER TABLE users ADD FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE;
+ALTER TABLE pull_requests ADD FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE RESTRICT;
+ALTER TABLE pr_reviewers
+    ADD FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE,
+    ADD FOREIGN KEY (reviewer_id) REFERENCES users(id) ON DELETE RESTRICT;
+ALTER TABLE team_settings ADD FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE;
+ALTER TABLE pr_sla_events ADD FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE;
+ALTER TABLE pr_assignment_events
+    ADD FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE,
+    ADD FOREIGN KEY (reviewer_id) REFERENCES users(id) ON DELETE RESTRICT;
+ALTER TABLE api_tokens ADD FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_users_team_active ON users(team_name, is_active);
+CREATE INDEX idx_pr_author_status ON pull_requests(author_id, status);
+CREATE INDEX idx_pr_reviewers_reviewer_id ON pr_reviewers(reviewer_id);
+CREATE INDEX idx_pr_reviewers_reviewer_state ON pr_reviewers(reviewer_id, state);
+CREATE INDEX idx_pr_status_created_id ON pull_requests(status, created_at, id);
+CREATE INDEX idx_pr_created_id ON pull_requests(created_at, id);
+CREATE INDEX idx_pr_author_created_id ON pull_requests(author_id, created_at, id);
+CREATE INDEX idx_pr_assignment_events_pr ON pr_assignment_events(pr_id, id);
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- keys are chosen by clients, two callers of one organization may pick the same key
+ALTER TABLE idempotency_keys ADD COLUMN principal VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (org_id, principal, key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- keys of different callers would collide, stored responses are only a retry cache
+DELETE FROM idempotency_keys;
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (org_id, key);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS principal;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the review sla runs from the moment a pr enters OPEN, not from its creation as a draft
+ALTER TABLE pull_requests ADD COLUMN opened_at TIMESTAMPTZ;
+UPDATE pull_requests SET opened_at = created_at WHERE status <> 'DRAFT';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS opened_at;
+-- +goose StatementEnd
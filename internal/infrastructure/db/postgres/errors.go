@@ -0,0 +1,69 @@
+// Package postgres manages database connections and transactions
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// sqlstate codes of integrity constraint violations
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// uniqueConstraints maps unique and primary key constraints to domain errors
+var uniqueConstraints = map[string]error{
+	"teams_pkey":         entity.ErrTeamExists,
+	"users_pkey":         entity.ErrUserExists,
+	"pull_requests_pkey": entity.ErrPRExists,
+}
+
+// constraintError is a domain error produced from a postgres error,
+// it keeps the original error so wrapping repositories can translate it again
+type constraintError struct {
+	op     string
+	domain error
+	pg     *pgconn.PgError
+}
+
+func (e *constraintError) Error() string {
+	return e.op + ": " + e.domain.Error()
+}
+
+// Unwrap allows errors.Is on the domain error and errors.As on the postgres error
+func (e *constraintError) Unwrap() []error {
+	return []error{e.domain, e.pg}
+}
+
+// TranslateError maps constraint violations to domain errors and wraps anything else with op,
+// foreign key violations mean a referenced user, team or pr does not exist
+func TranslateError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if domain := domainError(pgErr); domain != nil {
+			return &constraintError{op: op, domain: domain, pg: pgErr}
+		}
+	}
+
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+// domainError returns the domain error for a postgres error or nil if there is none
+func domainError(pgErr *pgconn.PgError) error {
+	switch pgErr.Code {
+	case uniqueViolation:
+		return uniqueConstraints[pgErr.ConstraintName]
+	case foreignKeyViolation:
+		return entity.ErrNotFound
+	}
+	return nil
+}
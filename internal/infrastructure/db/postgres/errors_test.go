@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error // domain error, nil if the error must stay untranslated
+		wantMsg string
+	}{
+		{"duplicate team", &pgconn.PgError{Code: uniqueViolation, ConstraintName: "teams_pkey"}, entity.ErrTeamExists, "op: " + entity.ErrTeamExists.Error()},
+		{"duplicate user", &pgconn.PgError{Code: uniqueViolation, ConstraintName: "users_pkey"}, entity.ErrUserExists, "op: " + entity.ErrUserExists.Error()},
+		{"duplicate pr", &pgconn.PgError{Code: uniqueViolation, ConstraintName: "pull_requests_pkey"}, entity.ErrPRExists, "op: " + entity.ErrPRExists.Error()},
+		{"foreign key", &pgconn.PgError{Code: foreignKeyViolation, ConstraintName: "users_team_fkey"}, entity.ErrNotFound, "op: " + entity.ErrNotFound.Error()},
+		{"unknown unique constraint", &pgconn.PgError{Code: uniqueViolation, ConstraintName: "api_tokens_hash_key", Severity: "ERROR", Message: "duplicate"}, nil, "op: ERROR: duplicate (SQLSTATE 23505)"},
+		{"check violation", &pgconn.PgError{Code: "23514", Severity: "ERROR", Message: "check"}, nil, "op: ERROR: check (SQLSTATE 23514)"},
+		{"not a postgres error", errors.New("connection refused"), nil, "op: connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TranslateError("op", tt.err)
+
+			if err.Error() != tt.wantMsg {
+				t.Errorf("message = %q, want %q", err.Error(), tt.wantMsg)
+			}
+			for _, domain := range []error{entity.ErrTeamExists, entity.ErrUserExists, entity.ErrPRExists, entity.ErrNotFound} {
+				if got := errors.Is(err, domain); got != (domain == tt.wantErr) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, domain, got)
+				}
+			}
+			// the original error stays in the chain
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%v does not wrap %v", err, tt.err)
+			}
+		})
+	}
+
+	if TranslateError("op", nil) != nil {
+		t.Error("nil error translated")
+	}
+}
@@ -0,0 +1,129 @@
+// Package pgtest runs a throwaway local postgres server for integration tests
+package pgtest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// goose annotations used by the migration files
+const (
+	annotationUp             = "-- +goose Up"
+	annotationDown           = "-- +goose Down"
+	annotationStatementBegin = "-- +goose StatementBegin"
+	annotationStatementEnd   = "-- +goose StatementEnd"
+)
+
+// MigrationsDir returns the goose migrations directory of the service
+func MigrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations")
+}
+
+// Migrate applies the up sections of all goose migrations in dir in version order,
+// it mirrors `goose up` for sql migrations so tests need no goose binary
+func Migrate(ctx context.Context, conn *pgx.Conn, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return fmt.Errorf("pgtest - Migrate - glob: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("pgtest - Migrate: no migrations in %s", dir)
+	}
+	// file names start with a zero padded version
+	sort.Strings(files)
+
+	for _, file := range files {
+		statements, err := upStatements(file)
+		if err != nil {
+			return err
+		}
+
+		// every migration runs in its own transaction like goose does
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			for _, stmt := range statements {
+				if _, err := tx.Exec(ctx, stmt); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("pgtest - Migrate - %s: %w", filepath.Base(file), err)
+		}
+	}
+
+	return nil
+}
+
+// upStatements splits the up section of a migration file into statements
+func upStatements(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("pgtest - Migrate - open: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		statements []string
+		buf        strings.Builder
+		inUp       bool
+		inBlock    bool // between StatementBegin and StatementEnd
+	)
+
+	flush := func() {
+		if stmt := strings.TrimSpace(buf.String()); stmt != "" {
+			statements = append(statements, stmt)
+		}
+		buf.Reset()
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, annotationUp):
+			inUp = true
+			continue
+		case strings.HasPrefix(trimmed, annotationDown):
+			inUp = false
+			continue
+		case !inUp:
+			continue
+		case strings.HasPrefix(trimmed, annotationStatementBegin):
+			inBlock = true
+			continue
+		case strings.HasPrefix(trimmed, annotationStatementEnd):
+			inBlock = false
+			flush()
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+
+		// outside of a block every statement ends with a semicolon at the end of a line
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("pgtest - Migrate - read %s: %w", filepath.Base(file), err)
+	}
+	if inBlock {
+		return nil, fmt.Errorf("pgtest - Migrate - %s: missing %q", filepath.Base(file), annotationStatementEnd)
+	}
+	flush()
+
+	return statements, nil
+}
@@ -0,0 +1,92 @@
+package pgtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUpStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "statement block",
+			sql: `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE a (id INT);
+CREATE TABLE b (id INT);
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE b;
+`,
+			want: []string{"CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);"},
+		},
+		{
+			name: "plain statements",
+			sql: `-- +goose Up
+CREATE TABLE a (
+    id INT
+);
+CREATE INDEX a_id ON a(id);
+-- +goose Down
+DROP TABLE a;
+`,
+			want: []string{"CREATE TABLE a (\n    id INT\n);", "CREATE INDEX a_id ON a(id);"},
+		},
+		{
+			name: "unterminated block",
+			sql: `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE a (id INT);
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "00001_test.sql")
+			if err := os.WriteFile(file, []byte(tt.sql), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := upStatements(file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("upStatements error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("upStatements = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMigrationsParse checks that every migration of the service has an up section
+func TestMigrationsParse(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(MigrationsDir(), "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+
+	for _, file := range files {
+		statements, err := upStatements(file)
+		if err != nil {
+			t.Errorf("%s: %v", filepath.Base(file), err)
+			continue
+		}
+		if len(statements) == 0 {
+			t.Errorf("%s: empty up section", filepath.Base(file))
+		}
+		for _, stmt := range statements {
+			if strings.Contains(stmt, "+goose") {
+				t.Errorf("%s: annotation left in statement %q", filepath.Base(file), stmt)
+			}
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Package pgtest runs a throwaway local postgres server for integration tests
+package pgtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrUnavailable means postgres can't be started here, tests should be skipped
+var ErrUnavailable = errors.New("pgtest: local postgres is unavailable")
+
+const (
+	// binDirEnv points to the directory with initdb and postgres if they are not in PATH
+	binDirEnv = "PG_BIN_DIR"
+	// integrationEnv set to 1 makes a missing postgres a failure instead of a skip, like CI does
+	integrationEnv = "INTEGRATION"
+
+	superuser      = "postgres"
+	templateDB     = "pgtest_template"
+	startupTimeout = 30 * time.Second
+)
+
+// Server is a postgres instance listening only on a unix socket in a temporary directory
+type Server struct {
+	dir    string
+	cmd    *exec.Cmd
+	done   chan error // result of cmd.Wait
+	admin  *pgxpool.Pool
+	dbSeq  atomic.Int64
+	logOut *os.File
+}
+
+// Required reports whether the integration tests must run, with INTEGRATION=1 or in CI
+// a postgres that can't be started fails the tests instead of skipping them
+func Required() bool {
+	ci, _ := strconv.ParseBool(os.Getenv("CI"))
+	return ci || os.Getenv(integrationEnv) == "1"
+}
+
+// Start initializes a new cluster, starts postgres and migrates a template database,
+// it returns an error wrapping ErrUnavailable if there are no binaries or the user is root,
+// unless the tests are Required
+func Start(ctx context.Context) (*Server, error) {
+	initdb, postgresBin, err := findBinaries()
+	if err == nil && os.Geteuid() == 0 {
+		err = fmt.Errorf("%w: postgres refuses to run as root", ErrUnavailable)
+	}
+	if err != nil {
+		if Required() {
+			// not wrapped, so callers don't skip
+			return nil, fmt.Errorf("pgtest: integration tests are required (%s=1 or CI): %v", integrationEnv, err)
+		}
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "pgtest-")
+	if err != nil {
+		return nil, fmt.Errorf("pgtest - Start - temp dir: %w", err)
+	}
+	s := &Server{dir: dir, done: make(chan error, 1)}
+
+	if err := s.start(ctx, initdb, postgresBin); err != nil {
+		s.Stop()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Server) start(ctx context.Context, initdb, postgresBin string) error {
+	dataDir := filepath.Join(s.dir, "data")
+
+	// trust auth is fine, the socket directory is private to this process
+	out, err := exec.CommandContext(ctx, initdb,
+		"-D", dataDir, "-U", superuser, "-A", "trust", "-E", "UTF8", "--locale=C", "--no-sync",
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("pgtest - Start - initdb: %w: %s", err, out)
+	}
+
+	s.logOut, err = os.Create(filepath.Join(s.dir, "postgres.log"))
+	if err != nil {
+		return fmt.Errorf("pgtest - Start - log file: %w", err)
+	}
+
+	// no tcp listener, durability is not needed for tests
+	s.cmd = exec.Command(postgresBin,
+		"-D", dataDir,
+		"-k", s.dir,
+		"-c", "listen_addresses=",
+		"-c", "fsync=off",
+		"-c", "synchronous_commit=off",
+		"-c", "full_page_writes=off",
+	)
+	s.cmd.Stdout = s.logOut
+	s.cmd.Stderr = s.logOut
+	if err := s.cmd.Start(); err != nil {
+		return fmt.Errorf("pgtest - Start - postgres: %w", err)
+	}
+	go func() { s.done <- s.cmd.Wait() }()
+
+	if err := s.waitReady(ctx); err != nil {
+		return err
+	}
+
+	s.admin, err = pgxpool.New(ctx, s.URL("postgres"))
+	if err != nil {
+		return fmt.Errorf("pgtest - Start - connect: %w", err)
+	}
+
+	return s.createTemplate(ctx)
+}
+
+// waitReady polls the server until it accepts connections
+func (s *Server) waitReady(ctx context.Context) error {
+	deadline := time.Now().Add(startupTimeout)
+	for {
+		conn, err := pgx.Connect(ctx, s.URL("postgres"))
+		if err == nil {
+			return conn.Close(ctx)
+		}
+
+		select {
+		case exitErr := <-s.done:
+			s.done <- exitErr // keep it for Stop
+			return fmt.Errorf("pgtest - Start - postgres exited: %v, see %s", exitErr, s.logOut.Name())
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("pgtest - Start - postgres is not ready: %w", err)
+		}
+	}
+}
+
+// createTemplate migrates the template every test database is cloned from
+func (s *Server) createTemplate(ctx context.Context) error {
+	if _, err := s.admin.Exec(ctx, "CREATE DATABASE "+templateDB); err != nil {
+		return fmt.Errorf("pgtest - Start - create template: %w", err)
+	}
+
+	conn, err := pgx.Connect(ctx, s.URL(templateDB))
+	if err != nil {
+		return fmt.Errorf("pgtest - Start - connect template: %w", err)
+	}
+	// a template can't be cloned while someone is connected to it
+	defer conn.Close(ctx)
+
+	return Migrate(ctx, conn, MigrationsDir())
+}
+
+// URL returns the connection string of a database on this server
+func (s *Server) URL(database string) string {
+	q := url.Values{}
+	q.Set("host", s.dir)
+	q.Set("sslmode", "disable")
+	return (&url.URL{
+		Scheme:   "postgres",
+		User:     url.User(superuser),
+		Path:     "/" + database,
+		RawQuery: q.Encode(),
+	}).String()
+}
+
+// NewDatabase creates a migrated empty database and returns a pool connected to it,
+// the database is dropped when the test finishes
+func (s *Server) NewDatabase(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+	ctx := context.Background()
+
+	name := fmt.Sprintf("test_%d", s.dbSeq.Add(1))
+	if _, err := s.admin.Exec(ctx, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", name, templateDB)); err != nil {
+		t.Fatalf("pgtest: create database: %v", err)
+	}
+
+	pool, err := pgxpool.New(ctx, s.URL(name))
+	if err != nil {
+		t.Fatalf("pgtest: connect: %v", err)
+	}
+
+	t.Cleanup(func() {
+		pool.Close()
+		if _, err := s.admin.Exec(ctx, "DROP DATABASE IF EXISTS "+name); err != nil {
+			t.Errorf("pgtest: drop database: %v", err)
+		}
+	})
+
+	return pool
+}
+
+// Stop shuts postgres down and removes the cluster
+func (s *Server) Stop() {
+	if s.admin != nil {
+		s.admin.Close()
+	}
+
+	if s.cmd != nil && s.cmd.Process != nil {
+		// SIGINT is the fast shutdown mode
+		_ = s.cmd.Process.Signal(os.Interrupt)
+		select {
+		case <-s.done:
+		case <-time.After(startupTimeout):
+			_ = s.cmd.Process.Kill()
+			<-s.done
+		}
+	}
+
+	if s.logOut != nil {
+		s.logOut.Close()
+	}
+	os.RemoveAll(s.dir)
+}
+
+// findBinaries looks for initdb and postgres in PG_BIN_DIR, PATH and usual install locations
+func findBinaries() (initdb, postgresBin string, err error) {
+	var dirs []string
+	if dir := os.Getenv(binDirEnv); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if path, err := exec.LookPath("initdb"); err == nil {
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	// debian packages keep server binaries out of PATH
+	installed, _ := filepath.Glob("/usr/lib/postgresql/*/bin")
+	for i := len(installed) - 1; i >= 0; i-- { // newest version first
+		dirs = append(dirs, installed[i])
+	}
+
+	for _, dir := range dirs {
+		initdb, postgresBin = filepath.Join(dir, "initdb"), filepath.Join(dir, "postgres")
+		if isExecutable(initdb) && isExecutable(postgresBin) {
+			return initdb, postgresBin, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("%w: initdb and postgres not found, set %s", ErrUnavailable, binDirEnv)
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Mode()&0o111 != 0
+}
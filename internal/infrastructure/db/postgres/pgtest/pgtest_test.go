@@ -0,0 +1,45 @@
+package pgtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRequired(t *testing.T) {
+	tests := []struct {
+		ci, integration string
+		want            bool
+	}{
+		{"", "", false},
+		{"false", "", false},
+		{"true", "", true},
+		{"1", "", true},
+		{"", "1", true},
+		{"", "0", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("CI", tt.ci)
+		t.Setenv(integrationEnv, tt.integration)
+		if got := Required(); got != tt.want {
+			t.Errorf("CI=%q %s=%q: Required = %v, want %v", tt.ci, integrationEnv, tt.integration, got, tt.want)
+		}
+	}
+}
+
+// a required run never reports postgres as unavailable, so the tests fail instead of skipping
+func TestStartRequired(t *testing.T) {
+	t.Setenv(binDirEnv, t.TempDir())
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("CI", "")
+	t.Setenv(integrationEnv, "1")
+
+	server, err := Start(context.Background())
+	if err == nil {
+		server.Stop()
+		t.Skip("postgres is installed in a standard location")
+	}
+	if errors.Is(err, ErrUnavailable) {
+		t.Errorf("Start = %v, want an error that is not ErrUnavailable", err)
+	}
+}
@@ -0,0 +1,116 @@
+// Package repository handles data persistence and retrieval
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// IdempotencyRepository stores idempotency keys with their recorded responses
+type IdempotencyRepository struct {
+	trm *postgres.TransactionManager
+}
+
+// NewIdempotencyRepository creates new idempotency repository instance
+func NewIdempotencyRepository(trm *postgres.TransactionManager) *IdempotencyRepository {
+	return &IdempotencyRepository{trm: trm}
+}
+
+// check for interface implementation
+var _ repository.IdempotencyRepository = (*IdempotencyRepository)(nil)
+
+// Reserve claims the key, keys older than ttl are forgotten and can be reused
+func (r *IdempotencyRepository) Reserve(ctx context.Context, principal, key, requestHash string, ttl time.Duration) (*entity.IdempotencyRecord, bool, error) {
+	queryer := r.trm.GetQueryer(ctx)
+	orgID := tenant.FromContext(ctx)
+
+	const expireQuery = `DELETE FROM idempotency_keys WHERE org_id = $1 AND principal = $2 AND key = $3 AND created_at < NOW() - $4::interval`
+	if _, err := queryer.Exec(ctx, expireQuery, orgID, principal, key, ttl); err != nil {
+		return nil, false, postgres.TranslateError("IdempotencyRepo.Reserve (expire)", err)
+	}
+
+	// the primary key makes concurrent reservations of one key race-free
+	const insertQuery = `
+		INSERT INTO idempotency_keys (org_id, principal, key, request_hash) 
+		VALUES ($1, $2, $3, $4) 
+		ON CONFLICT (org_id, principal, key) DO NOTHING`
+	tag, err := queryer.Exec(ctx, insertQuery, orgID, principal, key, requestHash)
+	if err != nil {
+		return nil, false, postgres.TranslateError("IdempotencyRepo.Reserve (insert)", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil, true, nil
+	}
+
+	const selectQuery = `
+		SELECT key, request_hash, COALESCE(status_code, 0), response_body, created_at, completed_at 
+		FROM idempotency_keys 
+		WHERE org_id = $1 AND principal = $2 AND key = $3`
+
+	record := &entity.IdempotencyRecord{}
+	var completedAt pgtype.Timestamptz
+	err = queryer.QueryRow(ctx, selectQuery, orgID, principal, key).Scan(
+		&record.Key, &record.RequestHash, &record.StatusCode, &record.Body, &record.CreatedAt, &completedAt)
+	if err == pgx.ErrNoRows {
+		// released by the first request in the meantime
+		return nil, false, entity.ErrRequestInProgress
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("IdempotencyRepo.Reserve (select): %w", err)
+	}
+
+	if completedAt.Valid {
+		record.CompletedAt = &completedAt.Time
+	}
+
+	return record, false, nil
+}
+
+// Complete stores the response of the request that reserved the key
+func (r *IdempotencyRepository) Complete(ctx context.Context, principal, key string, statusCode int, body []byte) error {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `
+		UPDATE idempotency_keys 
+		SET status_code = $4, response_body = $5, completed_at = NOW() 
+		WHERE org_id = $1 AND principal = $2 AND key = $3`
+
+	if _, err := queryer.Exec(ctx, query, tenant.FromContext(ctx), principal, key, statusCode, body); err != nil {
+		return postgres.TranslateError("IdempotencyRepo.Complete", err)
+	}
+	return nil
+}
+
+// Release forgets an unfinished key so that the request can be retried
+func (r *IdempotencyRepository) Release(ctx context.Context, principal, key string) error {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `DELETE FROM idempotency_keys WHERE org_id = $1 AND principal = $2 AND key = $3 AND completed_at IS NULL`
+
+	if _, err := queryer.Exec(ctx, query, tenant.FromContext(ctx), principal, key); err != nil {
+		return postgres.TranslateError("IdempotencyRepo.Release", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes keys older than ttl in all organizations, the scan uses idx_idempotency_keys_created
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, ttl time.Duration) (int64, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `DELETE FROM idempotency_keys WHERE created_at < NOW() - $1::interval`
+
+	tag, err := queryer.Exec(ctx, query, ttl)
+	if err != nil {
+		return 0, postgres.TranslateError("IdempotencyRepo.DeleteExpired", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository/repositorytest"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres/pgtest"
+)
+
+// server is shared by all tests of the package, nil if postgres is unavailable
+var (
+	server     *pgtest.Server
+	skipReason string
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	var err error
+	server, err = pgtest.Start(context.Background())
+	switch {
+	case errors.Is(err, pgtest.ErrUnavailable):
+		skipReason = err.Error()
+	case err != nil:
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	default:
+		defer server.Stop()
+	}
+
+	return m.Run()
+}
+
+// newRepositories returns repositories on a fresh migrated database
+func newRepositories(t *testing.T) repositorytest.Repositories {
+	t.Helper()
+	if server == nil {
+		t.Skip(skipReason)
+	}
+
+	trm := postgres.NewTransactionManager(server.NewDatabase(t))
+	return repositorytest.Repositories{
+		Transactor:  trm,
+		Teams:       NewTeamRepository(trm),
+		Users:       NewUserRepository(trm),
+		PRs:         NewPRRepository(trm),
+		SLA:         NewSLARepository(trm),
+		Tokens:      NewTokenRepository(trm),
+		Idempotency: NewIdempotencyRepository(trm),
+	}
+}
@@ -4,9 +4,11 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
 
 	"github.com/jackc/pgx/v5"
@@ -31,17 +33,14 @@ func (r *PRRepository) Create(ctx context.Context, pr *entity.PullRequest) error
 	queryer := r.trm.GetQueryer(ctx)
 
 	const prQuery = `
-		INSERT INTO pull_requests (id, name, author_id, status, created_at) 
-		VALUES ($1, $2, $3, $4, NOW())`
+		INSERT INTO pull_requests (org_id, id, name, author_id, status, created_at, opened_at) 
+		VALUES ($1, $2, $3, $4, $5, NOW(), CASE WHEN $5 = 'OPEN' THEN NOW() END)`
 
 	// execute insert statement
-	_, err := queryer.Exec(ctx, prQuery, pr.ID, pr.Name, pr.AuthorID, pr.Status)
+	_, err := queryer.Exec(ctx, prQuery, tenant.FromContext(ctx), pr.ID, pr.Name, pr.AuthorID, pr.Status)
 	if err != nil {
-		// check for duplicate primary key
-		if err.Error() == "duplicate key value violates unique constraint \"pull_requests_pkey\"" {
-			return entity.ErrPRExists
-		}
-		return fmt.Errorf("PRRepo.Create (pr insert): %w", err)
+		// duplicate id and unknown author are translated to domain errors
+		return postgres.TranslateError("PRRepo.Create (pr insert)", err)
 	}
 
 	// add reviewers if any are specified
@@ -50,29 +49,44 @@ func (r *PRRepository) Create(ctx context.Context, pr *entity.PullRequest) error
 		for i, rev := range pr.Reviewers {
 			reviewerIDs[i] = rev.ID
 		}
-		if err := r.SetReviewers(ctx, pr.ID, reviewerIDs); err != nil {
-			return fmt.Errorf("PRRepo.Create (set reviewers): %w", err)
+		if err := r.setReviewers(ctx, pr.ID, reviewerIDs); err != nil {
+			return postgres.TranslateError("PRRepo.Create (set reviewers)", err)
 		}
 	}
 
+	pr.Version = 1
 	return nil
 }
 
 // GetByID retrieves a pull request by its unique identifier
 func (r *PRRepository) GetByID(ctx context.Context, id string) (*entity.PullRequest, error) {
+	return r.getByID(ctx, id, false)
+}
+
+// GetByIDForUpdate retrieves a pull request and locks its row until the transaction ends,
+// concurrent writers of the same pr are serialized on this lock
+func (r *PRRepository) GetByIDForUpdate(ctx context.Context, id string) (*entity.PullRequest, error) {
+	return r.getByID(ctx, id, true)
+}
+
+// getByID loads a pull request with reviewers and author, optionally locking it
+func (r *PRRepository) getByID(ctx context.Context, id string, forUpdate bool) (*entity.PullRequest, error) {
 	queryer := r.trm.GetQueryer(ctx)
 
-	const prQuery = `
-		SELECT id, name, author_id, status, created_at, merged_at 
+	prQuery := `
+		SELECT id, name, author_id, status, version, created_at, merged_at, closed_at 
 		FROM pull_requests 
-		WHERE id = $1`
+		WHERE org_id = $1 AND id = $2`
+	if forUpdate {
+		prQuery += ` FOR UPDATE`
+	}
 
 	pr := &entity.PullRequest{}
-	var mergedAt pgtype.Timestamptz
+	var mergedAt, closedAt pgtype.Timestamptz
 
 	// scan basic pr details
-	err := queryer.QueryRow(ctx, prQuery, id).Scan(
-		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+	err := queryer.QueryRow(ctx, prQuery, tenant.FromContext(ctx), id).Scan(
+		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version, &pr.CreatedAt, &mergedAt, &closedAt)
 
 	if err == pgx.ErrNoRows {
 		return nil, entity.ErrNotFound
@@ -81,149 +95,163 @@ func (r *PRRepository) GetByID(ctx context.Context, id string) (*entity.PullRequ
 		return nil, fmt.Errorf("PRRepo.GetByID (pr fetch): %w", err)
 	}
 
-	// handle nullable merged_at timestamp
+	// handle nullable timestamps
 	if mergedAt.Valid {
 		pr.MergedAt = &mergedAt.Time
 	}
-
-	const revQuery = `
-		SELECT u.id, u.username, u.team_name, u.is_active 
-		FROM pr_reviewers pr_rev
-		JOIN users u ON pr_rev.reviewer_id = u.id
-		WHERE pr_rev.pr_id = $1`
-
-	// fetch associated reviewers
-	rows, err := queryer.Query(ctx, revQuery, pr.ID)
-	if err != nil {
-		return nil, fmt.Errorf("PRRepo.GetByID (reviewers fetch): %w", err)
+	if closedAt.Valid {
+		pr.ClosedAt = &closedAt.Time
 	}
-	defer rows.Close()
 
-	pr.Reviewers = make([]entity.User, 0)
-	for rows.Next() {
-		rev := entity.User{}
-		if err := rows.Scan(&rev.ID, &rev.Username, &rev.TeamName, &rev.IsActive); err != nil {
-			return nil, fmt.Errorf("PRRepo.GetByID (reviewers scan): %w", err)
-		}
-		pr.Reviewers = append(pr.Reviewers, rev)
+	// fetch associated reviewers with their review state and the author
+	if err := r.loadReviewers(ctx, pr); err != nil {
+		return nil, fmt.Errorf("PRRepo.GetByID: %w", err)
+	}
+	if err := r.loadAuthors(ctx, pr); err != nil {
+		return nil, fmt.Errorf("PRRepo.GetByID: %w", err)
 	}
 
-	return pr, rows.Err()
+	return pr, nil
 }
 
 // UpdateStatus updates the status of an existing pull request
 func (r *PRRepository) UpdateStatus(ctx context.Context, id string, status entity.PRStatus) (*entity.PullRequest, error) {
 	queryer := r.trm.GetQueryer(ctx)
 
-	setClause := "status = $2"
-	args := []interface{}{id, status}
+	setClause := "status = $3, version = version + 1"
+	args := []interface{}{tenant.FromContext(ctx), id, status}
 
-	// update merged_at timestamp if status changes to merged
-	if status == entity.StatusMerged {
+	// maintain lifecycle timestamps of the target status
+	switch status {
+	case entity.StatusMerged:
 		setClause += ", merged_at = NOW()"
+	case entity.StatusClosed:
+		setClause += ", closed_at = NOW()"
+	case entity.StatusOpen:
+		setClause += ", closed_at = NULL, opened_at = NOW()" // ready or reopened, the sla starts over
+	case entity.StatusDraft:
+		setClause += ", closed_at = NULL" // reopened
 	}
 
 	query := fmt.Sprintf(`
 		UPDATE pull_requests 
 		SET %s 
-		WHERE id = $1 
-		RETURNING id, name, author_id, status, created_at, merged_at`, setClause)
+		WHERE org_id = $1 AND id = $2 
+		RETURNING id, name, author_id, status, version, created_at, merged_at, closed_at`, setClause)
 
 	pr := &entity.PullRequest{}
-	var mergedAt pgtype.Timestamptz
+	var mergedAt, closedAt pgtype.Timestamptz
 
 	// execute update and return new state
 	err := queryer.QueryRow(ctx, query, args...).Scan(
-		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version, &pr.CreatedAt, &mergedAt, &closedAt)
 
 	if err == pgx.ErrNoRows {
 		return nil, entity.ErrNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("PRRepo.UpdateStatus: %w", err)
+		return nil, postgres.TranslateError("PRRepo.UpdateStatus", err)
 	}
 
 	if mergedAt.Valid {
 		pr.MergedAt = &mergedAt.Time
 	}
+	if closedAt.Valid {
+		pr.ClosedAt = &closedAt.Time
+	}
 
-	// refresh reviewers list
-	reviewersPtrs, err := r.GetReviewersByPRID(ctx, id)
-	if err != nil {
+	// refresh reviewers list and the author
+	if err := r.loadReviewers(ctx, pr); err != nil {
 		return nil, fmt.Errorf("PRRepo.UpdateStatus: failed to load reviewers: %w", err)
 	}
-
-	reviewers := make([]entity.User, len(reviewersPtrs))
-	for i, r := range reviewersPtrs {
-		reviewers[i] = *r
+	if err := r.loadAuthors(ctx, pr); err != nil {
+		return nil, fmt.Errorf("PRRepo.UpdateStatus: failed to load author: %w", err)
 	}
 
-	pr.Reviewers = reviewers
+	return pr, nil
+}
 
+// UpdateStatusAndReviewers replaces the reviewers and changes the status as one change,
+// so the version is bumped once
+func (r *PRRepository) UpdateStatusAndReviewers(ctx context.Context, id string, status entity.PRStatus, reviewerIDs []string) (*entity.PullRequest, error) {
+	var pr *entity.PullRequest
+	err := r.trm.Do(ctx, func(txCtx context.Context) error {
+		if err := r.setReviewers(txCtx, id, reviewerIDs); err != nil {
+			return err
+		}
+		var err error
+		pr, err = r.UpdateStatus(txCtx, id, status)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	return pr, nil
 }
 
-// SetReviewers updates the list of reviewers for a specific pr
+// SetReviewers updates the list of reviewers for a specific pr and bumps its version,
+// review state of reviewers that stay assigned is preserved and every change is logged
 func (r *PRRepository) SetReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+	if err := r.setReviewers(ctx, prID, reviewerIDs); err != nil {
+		return err
+	}
+	return r.bumpVersion(ctx, prID)
+}
+
+// setReviewers replaces the reviewers without touching the pr version
+func (r *PRRepository) setReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
 	queryer := r.trm.GetQueryer(ctx)
+	orgID := tenant.FromContext(ctx)
 
-	// remove existing reviewers first
-	const deleteQuery = `DELETE FROM pr_reviewers WHERE pr_id = $1`
-	if _, err := queryer.Exec(ctx, deleteQuery, prID); err != nil {
-		return fmt.Errorf("PRRepo.SetReviewers (delete): %w", err)
+	// nil slice would be sent as NULL and match nothing
+	if reviewerIDs == nil {
+		reviewerIDs = []string{}
 	}
 
-	if len(reviewerIDs) == 0 {
-		return nil
+	// remove reviewers that are no longer assigned and log the removal
+	const deleteQuery = `
+		WITH removed AS (
+			DELETE FROM pr_reviewers WHERE org_id = $1 AND pr_id = $2 AND NOT (reviewer_id = ANY($3)) 
+			RETURNING org_id, pr_id, reviewer_id
+		)
+		INSERT INTO pr_assignment_events (org_id, pr_id, reviewer_id, action) 
+		SELECT org_id, pr_id, reviewer_id, 'UNASSIGNED' FROM removed`
+	if _, err := queryer.Exec(ctx, deleteQuery, orgID, prID, reviewerIDs); err != nil {
+		return postgres.TranslateError("PRRepo.SetReviewers (delete)", err)
 	}
 
-	rows := make([][]interface{}, len(reviewerIDs))
-	for i, userID := range reviewerIDs {
-		rows[i] = []interface{}{prID, userID}
+	if len(reviewerIDs) == 0 {
+		return nil
 	}
 
-	// bulk insert new reviewers
-	_, err := queryer.CopyFrom(
-		ctx,
-		pgx.Identifier{"pr_reviewers"},
-		[]string{"pr_id", "reviewer_id"},
-		pgx.CopyFromRows(rows),
-	)
-
-	if err != nil {
-		return fmt.Errorf("PRRepo.SetReviewers (copy from): %w", err)
+	// add new reviewers in a single statement, existing ones are kept as is
+	const insertQuery = `
+		WITH added AS (
+			INSERT INTO pr_reviewers (org_id, pr_id, reviewer_id) 
+			SELECT $1, $2, unnest($3::varchar[]) 
+			ON CONFLICT (org_id, pr_id, reviewer_id) DO NOTHING 
+			RETURNING org_id, pr_id, reviewer_id
+		)
+		INSERT INTO pr_assignment_events (org_id, pr_id, reviewer_id, action) 
+		SELECT org_id, pr_id, reviewer_id, 'ASSIGNED' FROM added`
+
+	if _, err := queryer.Exec(ctx, insertQuery, orgID, prID, reviewerIDs); err != nil {
+		return postgres.TranslateError("PRRepo.SetReviewers (insert)", err)
 	}
 
 	return nil
 }
 
-// GetReviewsByUserID finds all pull requests assigned to a specific reviewer
-func (r *PRRepository) GetReviewsByUserID(ctx context.Context, userID string) ([]*entity.PullRequest, error) {
-	queryer := r.trm.GetQueryer(ctx)
-
-	const query = `
-		SELECT p.id, p.name, p.author_id, p.status 
-		FROM pull_requests p
-		JOIN pr_reviewers pr_rev ON p.id = pr_rev.pr_id
-		WHERE pr_rev.reviewer_id = $1`
+// GetReviewsByUserID returns a page of pull requests assigned to a specific reviewer
+func (r *PRRepository) GetReviewsByUserID(ctx context.Context, userID string, filter entity.PRFilter) (*entity.PRPage, error) {
+	filter.ReviewerID = userID
 
-	// execute join query to find assignments
-	rows, err := queryer.Query(ctx, query, userID)
+	page, err := r.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("PRRepo.GetReviewsByUserID: %w", err)
 	}
-	defer rows.Close()
-
-	prs := make([]*entity.PullRequest, 0)
-	for rows.Next() {
-		pr := &entity.PullRequest{}
-		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
-			return nil, fmt.Errorf("PRRepo.GetReviewsByUserID scan: %w", err)
-		}
-		prs = append(prs, pr)
-	}
 
-	return prs, rows.Err()
+	return page, nil
 }
 
 // GetReviewersByPRID retrieves all users assigned to review a specific pr
@@ -232,15 +260,15 @@ func (r *PRRepository) GetReviewersByPRID(ctx context.Context, prID string) ([]*
 
 	const query = `
         SELECT 
-            u.id, u.username, u.team_name, u.is_active 
+            u.id, u.username, u.team_name, u.is_active, u.role 
         FROM 
             pr_reviewers pr_rev 
         JOIN 
-            users u ON pr_rev.reviewer_id = u.id 
+            users u ON u.org_id = pr_rev.org_id AND u.id = pr_rev.reviewer_id 
         WHERE 
-            pr_rev.pr_id = $1`
+            pr_rev.org_id = $1 AND pr_rev.pr_id = $2`
 
-	rows, err := queryer.Query(ctx, query, prID)
+	rows, err := queryer.Query(ctx, query, tenant.FromContext(ctx), prID)
 	if err != nil {
 		return nil, fmt.Errorf("PRRepo.GetReviewersByPRID (query): %w", err)
 	}
@@ -251,7 +279,7 @@ func (r *PRRepository) GetReviewersByPRID(ctx context.Context, prID string) ([]*
 	for rows.Next() {
 		user := &entity.User{}
 
-		err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive)
+		err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.Role)
 		if err != nil {
 			return nil, fmt.Errorf("PRRepo.GetReviewersByPRID (scan): %w", err)
 		}
@@ -264,3 +292,309 @@ func (r *PRRepository) GetReviewersByPRID(ctx context.Context, prID string) ([]*
 
 	return reviewers, nil
 }
+
+// SubmitReview stores the reviewer's review state and first response time
+func (r *PRRepository) SubmitReview(ctx context.Context, prID, reviewerID string, state entity.ReviewState) (*entity.Review, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `
+		UPDATE pr_reviewers 
+		SET state = $4, first_response_at = COALESCE(first_response_at, NOW()), updated_at = NOW() 
+		WHERE org_id = $1 AND pr_id = $2 AND reviewer_id = $3 
+		RETURNING pr_id, reviewer_id, state, assigned_at, first_response_at, updated_at`
+
+	review := &entity.Review{}
+	var firstResponseAt pgtype.Timestamptz
+
+	err := queryer.QueryRow(ctx, query, tenant.FromContext(ctx), prID, reviewerID, state).Scan(
+		&review.PRID, &review.ReviewerID, &review.State, &review.AssignedAt, &firstResponseAt, &review.UpdatedAt)
+
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrNotAssigned
+	}
+	if err != nil {
+		return nil, postgres.TranslateError("PRRepo.SubmitReview", err)
+	}
+
+	if firstResponseAt.Valid {
+		review.FirstResponseAt = &firstResponseAt.Time
+	}
+
+	if err := r.bumpVersion(ctx, prID); err != nil {
+		return nil, postgres.TranslateError("PRRepo.SubmitReview", err)
+	}
+
+	return review, nil
+}
+
+// bumpVersion increments the pr version after a change of its reviewers or reviews
+func (r *PRRepository) bumpVersion(ctx context.Context, prID string) error {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `UPDATE pull_requests SET version = version + 1 WHERE org_id = $1 AND id = $2`
+
+	if _, err := queryer.Exec(ctx, query, tenant.FromContext(ctx), prID); err != nil {
+		return postgres.TranslateError("version bump", err)
+	}
+	return nil
+}
+
+// loadReviewers fills reviewers and their review states of the given prs with a single query
+func (r *PRRepository) loadReviewers(ctx context.Context, prs ...*entity.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+	queryer := r.trm.GetQueryer(ctx)
+
+	byID := make(map[string]*entity.PullRequest, len(prs))
+	prIDs := make([]string, len(prs))
+	for i, pr := range prs {
+		pr.Reviewers = make([]entity.User, 0)
+		pr.Reviews = make([]entity.Review, 0)
+		byID[pr.ID] = pr
+		prIDs[i] = pr.ID
+	}
+
+	const query = `
+		SELECT pr_rev.pr_id, u.id, u.username, u.team_name, u.is_active, u.role, 
+			pr_rev.state, pr_rev.assigned_at, pr_rev.first_response_at, pr_rev.updated_at 
+		FROM pr_reviewers pr_rev
+		JOIN users u ON u.org_id = pr_rev.org_id AND u.id = pr_rev.reviewer_id
+		WHERE pr_rev.org_id = $1 AND pr_rev.pr_id = ANY($2)
+		ORDER BY pr_rev.pr_id, pr_rev.assigned_at, u.id`
+
+	rows, err := queryer.Query(ctx, query, tenant.FromContext(ctx), prIDs)
+	if err != nil {
+		return fmt.Errorf("reviewers fetch: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rev := entity.User{}
+		review := entity.Review{}
+		var firstResponseAt pgtype.Timestamptz
+
+		err := rows.Scan(&review.PRID, &rev.ID, &rev.Username, &rev.TeamName, &rev.IsActive, &rev.Role,
+			&review.State, &review.AssignedAt, &firstResponseAt, &review.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("reviewers scan: %w", err)
+		}
+
+		review.ReviewerID = rev.ID
+		if firstResponseAt.Valid {
+			review.FirstResponseAt = &firstResponseAt.Time
+		}
+
+		pr := byID[review.PRID]
+		pr.Reviewers = append(pr.Reviewers, rev)
+		pr.Reviews = append(pr.Reviews, review)
+	}
+
+	return rows.Err()
+}
+
+// loadAuthors attaches author details to the given prs with a single query
+func (r *PRRepository) loadAuthors(ctx context.Context, prs ...*entity.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+	queryer := r.trm.GetQueryer(ctx)
+
+	authorIDs := make([]string, 0, len(prs))
+	seen := make(map[string]bool, len(prs))
+	for _, pr := range prs {
+		if !seen[pr.AuthorID] {
+			seen[pr.AuthorID] = true
+			authorIDs = append(authorIDs, pr.AuthorID)
+		}
+	}
+
+	const query = `SELECT id, username, team_name, is_active, role FROM users WHERE org_id = $1 AND id = ANY($2)`
+
+	rows, err := queryer.Query(ctx, query, tenant.FromContext(ctx), authorIDs)
+	if err != nil {
+		return fmt.Errorf("authors fetch: %w", err)
+	}
+	defer rows.Close()
+
+	authors := make(map[string]*entity.User, len(authorIDs))
+	for rows.Next() {
+		user := &entity.User{}
+		if err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.Role); err != nil {
+			return fmt.Errorf("authors scan: %w", err)
+		}
+		authors[user.ID] = user
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("authors rows: %w", err)
+	}
+
+	for _, pr := range prs {
+		pr.Author = authors[pr.AuthorID]
+	}
+
+	return nil
+}
+
+// List returns a page of pull requests matching the filter in created_at, id order
+func (r *PRRepository) List(ctx context.Context, filter entity.PRFilter) (*entity.PRPage, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	conds := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	// arg appends a query argument and returns its placeholder
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// every page is scoped to the caller organization
+	conds = append(conds, "p.org_id = "+arg(tenant.FromContext(ctx)))
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, st := range filter.Statuses {
+			statuses[i] = string(st)
+		}
+		conds = append(conds, "p.status = ANY("+arg(statuses)+")")
+	}
+	if filter.AuthorID != "" {
+		conds = append(conds, "p.author_id = "+arg(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		pending := ""
+		if filter.OnlyPending {
+			pending = " AND pr_rev.state = 'PENDING'"
+		}
+		conds = append(conds, "EXISTS (SELECT 1 FROM pr_reviewers pr_rev WHERE pr_rev.org_id = p.org_id AND pr_rev.pr_id = p.id AND pr_rev.reviewer_id = "+arg(filter.ReviewerID)+pending+")")
+	}
+	if filter.TeamName != "" {
+		conds = append(conds, "p.author_id IN (SELECT id FROM users WHERE org_id = p.org_id AND team_name = "+arg(filter.TeamName)+")")
+	}
+	if filter.CreatedFrom != nil {
+		conds = append(conds, "p.created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conds = append(conds, "p.created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.MergedFrom != nil {
+		conds = append(conds, "p.merged_at >= "+arg(*filter.MergedFrom))
+	}
+	if filter.MergedTo != nil {
+		conds = append(conds, "p.merged_at < "+arg(*filter.MergedTo))
+	}
+
+	// keyset condition continues right after the cursor in the chosen direction
+	direction, cmp := "ASC", ">"
+	if filter.Desc {
+		direction, cmp = "DESC", "<"
+	}
+	if filter.After != nil {
+		conds = append(conds, fmt.Sprintf("(p.created_at, p.id) %s (%s::timestamptz, %s::varchar)", cmp, arg(filter.After.CreatedAt), arg(filter.After.ID)))
+	}
+
+	where := "WHERE " + strings.Join(conds, " AND ")
+
+	// fetch one extra row to know whether there is a next page
+	query := fmt.Sprintf(`
+		SELECT p.id, p.name, p.author_id, p.status, p.version, p.created_at, p.merged_at, p.closed_at 
+		FROM pull_requests p
+		%s
+		ORDER BY p.created_at %s, p.id %s
+		LIMIT %s`, where, direction, direction, arg(filter.Limit+1))
+
+	rows, err := queryer.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PRRepo.List: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]*entity.PullRequest, 0, filter.Limit+1)
+	for rows.Next() {
+		pr := &entity.PullRequest{}
+		var mergedAt, closedAt pgtype.Timestamptz
+
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version, &pr.CreatedAt, &mergedAt, &closedAt); err != nil {
+			return nil, fmt.Errorf("PRRepo.List scan: %w", err)
+		}
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+		if closedAt.Valid {
+			pr.ClosedAt = &closedAt.Time
+		}
+		prs = append(prs, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("PRRepo.List rows: %w", err)
+	}
+
+	page := &entity.PRPage{Items: prs}
+	if len(prs) > filter.Limit {
+		page.Items = prs[:filter.Limit]
+		last := page.Items[len(page.Items)-1]
+		page.Next = &entity.PRCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	// load reviewers and authors of the whole page at once
+	if err := r.loadReviewers(ctx, page.Items...); err != nil {
+		return nil, fmt.Errorf("PRRepo.List: %w", err)
+	}
+	if err := r.loadAuthors(ctx, page.Items...); err != nil {
+		return nil, fmt.Errorf("PRRepo.List: %w", err)
+	}
+
+	return page, nil
+}
+
+// GetAssignmentHistory counts reviewer changes of a pr and keeps the last event of every reviewer
+func (r *PRRepository) GetAssignmentHistory(ctx context.Context, prID string) (*entity.AssignmentHistory, error) {
+	queryer := r.trm.GetQueryer(ctx)
+	orgID := tenant.FromContext(ctx)
+
+	const countQuery = `
+		SELECT 
+			COUNT(*) FILTER (WHERE action = 'ASSIGNED'), 
+			COUNT(*) FILTER (WHERE action = 'UNASSIGNED'), 
+			MAX(created_at) 
+		FROM pr_assignment_events 
+		WHERE org_id = $1 AND pr_id = $2`
+
+	history := &entity.AssignmentHistory{Events: make([]entity.AssignmentEvent, 0)}
+	var lastChangeAt pgtype.Timestamptz
+	err := queryer.QueryRow(ctx, countQuery, orgID, prID).Scan(&history.Assignments, &history.Unassignments, &lastChangeAt)
+	if err != nil {
+		return nil, fmt.Errorf("PRRepo.GetAssignmentHistory (count): %w", err)
+	}
+	if lastChangeAt.Valid {
+		history.LastChangeAt = &lastChangeAt.Time
+	}
+
+	// one row per reviewer however long the log is
+	const lastQuery = `
+		SELECT reviewer_id, action, created_at 
+		FROM (
+			SELECT DISTINCT ON (reviewer_id) id, reviewer_id, action, created_at 
+			FROM pr_assignment_events 
+			WHERE org_id = $1 AND pr_id = $2 
+			ORDER BY reviewer_id, id DESC
+		) last_events 
+		ORDER BY id`
+
+	rows, err := queryer.Query(ctx, lastQuery, orgID, prID)
+	if err != nil {
+		return nil, fmt.Errorf("PRRepo.GetAssignmentHistory (last events): %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event entity.AssignmentEvent
+		if err := rows.Scan(&event.ReviewerID, &event.Action, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("PRRepo.GetAssignmentHistory scan: %w", err)
+		}
+		history.Events = append(history.Events, event)
+	}
+
+	return history, rows.Err()
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository/repositorytest"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, newRepositories)
+}
+
+// seed creates team backend with u1..u3 and pr-1 by u1 reviewed by u2
+func seed(t *testing.T, r repositorytest.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+
+	users := []*entity.User{
+		{ID: "u1", Username: "a", IsActive: true},
+		{ID: "u2", Username: "b", IsActive: true},
+		{ID: "u3", Username: "c", IsActive: true},
+	}
+	if err := r.Teams.Create(ctx, &entity.Team{Name: "backend"}, users); err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err := r.PRs.Create(ctx, &entity.PullRequest{ID: "pr-1", Name: "x", AuthorID: "u1", Status: entity.StatusOpen, Reviewers: []entity.User{{ID: "u2"}}}); err != nil {
+		t.Fatalf("create pr: %v", err)
+	}
+}
+
+func TestTransactionManagerDo(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name       string
+		fn         func(ctx context.Context, r repositorytest.Repositories) error
+		wantErr    error
+		wantStatus entity.PRStatus // status of pr-1 after Do
+	}{
+		{
+			name: "commit",
+			fn: func(ctx context.Context, r repositorytest.Repositories) error {
+				_, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusMerged)
+				return err
+			},
+			wantStatus: entity.StatusMerged,
+		},
+		{
+			name: "error rolls back",
+			fn: func(ctx context.Context, r repositorytest.Repositories) error {
+				if _, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusMerged); err != nil {
+					return err
+				}
+				return errBoom
+			},
+			wantErr:    errBoom,
+			wantStatus: entity.StatusOpen,
+		},
+		{
+			name: "nested error rolls back outer",
+			fn: func(ctx context.Context, r repositorytest.Repositories) error {
+				if _, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusMerged); err != nil {
+					return err
+				}
+				return r.Transactor.Do(ctx, func(context.Context) error { return errBoom })
+			},
+			wantErr:    errBoom,
+			wantStatus: entity.StatusOpen,
+		},
+		{
+			name: "constraint violation rolls back earlier writes",
+			fn: func(ctx context.Context, r repositorytest.Repositories) error {
+				if _, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusClosed); err != nil {
+					return err
+				}
+				return r.PRs.Create(ctx, &entity.PullRequest{ID: "pr-1", Name: "dup", AuthorID: "u1", Status: entity.StatusOpen})
+			},
+			wantErr:    entity.ErrPRExists,
+			wantStatus: entity.StatusOpen,
+		},
+		{
+			name: "canceled context rolls back",
+			fn: func(ctx context.Context, r repositorytest.Repositories) error {
+				if _, err := r.PRs.UpdateStatus(ctx, "pr-1", entity.StatusMerged); err != nil {
+					return err
+				}
+				return context.Canceled
+			},
+			wantErr:    context.Canceled,
+			wantStatus: entity.StatusOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositories(t)
+			seed(t, r)
+			ctx := context.Background()
+
+			err := r.Transactor.Do(ctx, func(ctx context.Context) error { return tt.fn(ctx, r) })
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do = %v, want %v", err, tt.wantErr)
+			}
+
+			pr, err := r.PRs.GetByID(ctx, "pr-1")
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if pr.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", pr.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestTransactionManagerDoPanic(t *testing.T) {
+	r := newRepositories(t)
+	seed(t, r)
+	ctx := context.Background()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("panic was swallowed")
+			}
+		}()
+		_ = r.Transactor.Do(ctx, func(ctx context.Context) error {
+			if _, err := r.Users.SetIsActive(ctx, "u2", false); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	user, err := r.Users.GetByID(ctx, "u2")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !user.IsActive {
+		t.Error("update inside panicking transaction was committed")
+	}
+}
+
+// TestConcurrentTransactions checks that GetByIDForUpdate serializes read-modify-write transactions
+func TestConcurrentTransactions(t *testing.T) {
+	r := newRepositories(t)
+	seed(t, r)
+	ctx := context.Background()
+
+	const workers = 10
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := r.Transactor.Do(ctx, func(ctx context.Context) error {
+				pr, err := r.PRs.GetByIDForUpdate(ctx, "pr-1")
+				if err != nil {
+					return err
+				}
+				return r.PRs.SetReviewers(ctx, pr.ID, []string{pr.Reviewers[0].ID})
+			})
+			if err != nil {
+				t.Errorf("Do: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	pr, err := r.PRs.GetByID(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if pr.Version != workers+1 {
+		t.Errorf("version = %d, want %d", pr.Version, workers+1)
+	}
+}
+
+// TestTranslateError runs raw statements against the real schema to check constraint names
+func TestTranslateError(t *testing.T) {
+	r := newRepositories(t)
+	seed(t, r)
+	ctx := context.Background()
+	queryer := r.Transactor.(*postgres.TransactionManager).GetQueryer(ctx)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantErr   error  // domain error, nil if the error must stay untranslated
+		wantState string // sqlstate kept in the chain
+	}{
+		{"duplicate team", `INSERT INTO teams (org_id, name) VALUES ('default', 'backend')`, entity.ErrTeamExists, "23505"},
+		{"duplicate user", `INSERT INTO users (org_id, id, username, team_name) VALUES ('default', 'u1', 'x', 'backend')`, entity.ErrUserExists, "23505"},
+		{"duplicate pr", `INSERT INTO pull_requests (org_id, id, name, author_id, status) VALUES ('default', 'pr-1', 'x', 'u1', 'OPEN')`, entity.ErrPRExists, "23505"},
+		{"unknown team", `INSERT INTO users (org_id, id, username, team_name) VALUES ('default', 'u9', 'x', 'nope')`, entity.ErrNotFound, "23503"},
+		{"unknown author", `INSERT INTO pull_requests (org_id, id, name, author_id, status) VALUES ('default', 'pr-9', 'x', 'nope', 'OPEN')`, entity.ErrNotFound, "23503"},
+		{"check violation", `UPDATE pull_requests SET status = 'BROKEN' WHERE id = 'pr-1'`, nil, "23514"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := queryer.Exec(ctx, tt.query)
+			if err == nil {
+				t.Fatal("statement succeeded")
+			}
+			err = postgres.TranslateError("op", err)
+
+			for _, domain := range []error{entity.ErrTeamExists, entity.ErrUserExists, entity.ErrPRExists, entity.ErrNotFound} {
+				if got := errors.Is(err, domain); got != (domain == tt.wantErr) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, domain, got)
+				}
+			}
+
+			var pgErr *pgconn.PgError
+			if !errors.As(err, &pgErr) || pgErr.Code != tt.wantState {
+				t.Errorf("sqlstate of %v, want %s", err, tt.wantState)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package repository handles data persistence and retrieval
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SLARepository manages review sla tracking
+type SLARepository struct {
+	trm *postgres.TransactionManager
+}
+
+// NewSLARepository creates new sla repository instance
+func NewSLARepository(trm *postgres.TransactionManager) *SLARepository {
+	return &SLARepository{trm: trm}
+}
+
+// check for interface implementation
+var _ repository.SLARepository = (*SLARepository)(nil)
+
+// ListBreaches finds open pull requests whose author team sla of the given kind
+// has expired and for which no event of that kind was sent yet,
+// the sla of a reviewer who has not responded runs from the later of the pr entering OPEN
+// and the reviewer assignment, the scan covers all organizations and every breach carries its own
+func (r *SLARepository) ListBreaches(ctx context.Context, kind entity.SLAKind, now time.Time) ([]*entity.SLABreach, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	// pick the threshold column matching the sla kind
+	var column string
+	switch kind {
+	case entity.SLAReminder:
+		column = "ts.reminder_after"
+	case entity.SLAEscalation:
+		column = "ts.escalate_after"
+	default:
+		return nil, fmt.Errorf("SLARepo.ListBreaches: unknown sla kind %q", kind)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.org_id, p.id, p.name, p.author_id, p.status, p.created_at, p.merged_at, ts.auto_reassign 
+		FROM pull_requests p
+		JOIN users a ON a.org_id = p.org_id AND a.id = p.author_id
+		JOIN team_settings ts ON ts.org_id = a.org_id AND ts.team_name = a.team_name
+		WHERE p.status = 'OPEN' 
+			AND %[1]s > INTERVAL '0' 
+			AND EXISTS (
+				SELECT 1 FROM pr_reviewers r 
+				WHERE r.org_id = p.org_id AND r.pr_id = p.id 
+					AND r.first_response_at IS NULL 
+					AND GREATEST(p.opened_at, r.assigned_at) <= $1 - %[1]s
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM pr_sla_events e WHERE e.org_id = p.org_id AND e.pr_id = p.id AND e.kind = $2
+			)
+		ORDER BY p.created_at`, column)
+
+	rows, err := queryer.Query(ctx, query, now, kind)
+	if err != nil {
+		return nil, fmt.Errorf("SLARepo.ListBreaches: %w", err)
+	}
+	defer rows.Close()
+
+	breaches := make([]*entity.SLABreach, 0)
+	for rows.Next() {
+		pr := &entity.PullRequest{}
+		breach := &entity.SLABreach{PR: pr, Kind: kind}
+		var mergedAt pgtype.Timestamptz
+
+		err := rows.Scan(&breach.OrgID, &pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, &breach.AutoReassign)
+		if err != nil {
+			return nil, fmt.Errorf("SLARepo.ListBreaches scan: %w", err)
+		}
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+		breaches = append(breaches, breach)
+	}
+
+	return breaches, rows.Err()
+}
+
+// MarkSent records that an sla event was emitted, returns false if it was already recorded
+func (r *SLARepository) MarkSent(ctx context.Context, prID string, kind entity.SLAKind) (bool, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `
+		INSERT INTO pr_sla_events (org_id, pr_id, kind, sent_at) 
+		VALUES ($1, $2, $3, NOW()) 
+		ON CONFLICT (org_id, pr_id, kind) DO NOTHING`
+
+	tag, err := queryer.Exec(ctx, query, tenant.FromContext(ctx), prID, kind)
+	if err != nil {
+		return false, postgres.TranslateError("SLARepo.MarkSent", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
 
 	"github.com/jackc/pgx/v5"
@@ -28,13 +29,14 @@ var _ repository.TeamRepository = (*TeamRepository)(nil)
 // Create creates a new team and optionally inserts members
 func (r *TeamRepository) Create(ctx context.Context, team *entity.Team, users []*entity.User) error {
 	queryer := r.trm.GetQueryer(ctx)
+	orgID := tenant.FromContext(ctx)
 
-	teamQuery := `INSERT INTO teams (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`
+	teamQuery := `INSERT INTO teams (org_id, name) VALUES ($1, $2) ON CONFLICT (org_id, name) DO NOTHING`
 
 	// insert team if not exists
-	teamTag, err := queryer.Exec(ctx, teamQuery, team.Name)
+	teamTag, err := queryer.Exec(ctx, teamQuery, orgID, team.Name)
 	if err != nil {
-		return fmt.Errorf("TeamRepo.Create (team insert): %w", err)
+		return postgres.TranslateError("TeamRepo.Create (team insert)", err)
 	}
 
 	if teamTag.RowsAffected() == 0 {
@@ -44,10 +46,10 @@ func (r *TeamRepository) Create(ctx context.Context, team *entity.Team, users []
 	// insert initial team members using batch
 	if len(users) > 0 {
 		batch := &pgx.Batch{}
-		userQuery := `INSERT INTO users (id, username, team_name, is_active) VALUES ($1, $2, $3, $4)`
+		userQuery := `INSERT INTO users (org_id, id, username, team_name, is_active, role) VALUES ($1, $2, $3, $4, $5, $6)`
 
 		for _, u := range users {
-			batch.Queue(userQuery, u.ID, u.Username, team.Name, u.IsActive)
+			batch.Queue(userQuery, orgID, u.ID, u.Username, team.Name, u.IsActive, roleOrDefault(u.Role))
 		}
 
 		batchRes := queryer.SendBatch(ctx, batch)
@@ -56,12 +58,12 @@ func (r *TeamRepository) Create(ctx context.Context, team *entity.Team, users []
 		for range users {
 			_, err := batchRes.Exec()
 			if err != nil {
-				return fmt.Errorf("TeamRepo.Create (user batch insert): %w", err)
+				return postgres.TranslateError("TeamRepo.Create (user batch insert)", err)
 			}
 		}
 
 		if err := batchRes.Close(); err != nil {
-			return fmt.Errorf("TeamRepo.Create (batch close): %w", err)
+			return postgres.TranslateError("TeamRepo.Create (batch close)", err)
 		}
 	}
 
@@ -72,11 +74,11 @@ func (r *TeamRepository) Create(ctx context.Context, team *entity.Team, users []
 func (r *TeamRepository) GetByName(ctx context.Context, name string) (*entity.Team, error) {
 	queryer := r.trm.GetQueryer(ctx)
 
-	const query = `SELECT name FROM teams WHERE name = $1`
+	const query = `SELECT name FROM teams WHERE org_id = $1 AND name = $2`
 
 	var team entity.Team
 
-	err := queryer.QueryRow(ctx, query, name).Scan(&team.Name)
+	err := queryer.QueryRow(ctx, query, tenant.FromContext(ctx), name).Scan(&team.Name)
 
 	if err == pgx.ErrNoRows {
 		return nil, entity.ErrNotFound
@@ -87,3 +89,57 @@ func (r *TeamRepository) GetByName(ctx context.Context, name string) (*entity.Te
 
 	return &team, nil
 }
+
+// GetSettings retrieves team review settings, returning defaults if none are stored
+func (r *TeamRepository) GetSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `
+		SELECT team_name, reminder_after, escalate_after, auto_reassign, 
+			min_approvals, require_all_approved, block_on_changes_requested 
+		FROM team_settings 
+		WHERE org_id = $1 AND team_name = $2`
+
+	settings := &entity.TeamSettings{}
+	policy := &settings.MergePolicy
+	err := queryer.QueryRow(ctx, query, tenant.FromContext(ctx), teamName).Scan(
+		&settings.TeamName, &settings.ReminderAfter, &settings.EscalateAfter, &settings.AutoReassign,
+		&policy.MinApprovals, &policy.RequireAllApproved, &policy.BlockOnChangesRequested)
+
+	if err == pgx.ErrNoRows {
+		// settings are optional, everything is disabled by default
+		return &entity.TeamSettings{TeamName: teamName}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("TeamRepo.GetSettings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSettings creates or replaces team review settings
+func (r *TeamRepository) SaveSettings(ctx context.Context, settings *entity.TeamSettings) error {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `
+		INSERT INTO team_settings (org_id, team_name, reminder_after, escalate_after, auto_reassign, 
+			min_approvals, require_all_approved, block_on_changes_requested) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (org_id, team_name) DO UPDATE 
+		SET reminder_after = EXCLUDED.reminder_after, 
+			escalate_after = EXCLUDED.escalate_after, 
+			auto_reassign = EXCLUDED.auto_reassign, 
+			min_approvals = EXCLUDED.min_approvals, 
+			require_all_approved = EXCLUDED.require_all_approved, 
+			block_on_changes_requested = EXCLUDED.block_on_changes_requested`
+
+	policy := settings.MergePolicy
+	_, err := queryer.Exec(ctx, query, tenant.FromContext(ctx),
+		settings.TeamName, settings.ReminderAfter, settings.EscalateAfter, settings.AutoReassign,
+		policy.MinApprovals, policy.RequireAllApproved, policy.BlockOnChangesRequested)
+	if err != nil {
+		return postgres.TranslateError("TeamRepo.SaveSettings", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Package repository handles data persistence and retrieval
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TokenRepository manages api token persistence
+type TokenRepository struct {
+	trm *postgres.TransactionManager
+}
+
+// NewTokenRepository creates new token repository instance
+func NewTokenRepository(trm *postgres.TransactionManager) *TokenRepository {
+	return &TokenRepository{trm: trm}
+}
+
+// check for interface implementation
+var _ repository.TokenRepository = (*TokenRepository)(nil)
+
+const tokenColumns = `id, org_id, name, COALESCE(user_id, ''), scopes, created_at, expires_at, revoked_at`
+
+// Create stores a new token together with the hash of its secret
+func (r *TokenRepository) Create(ctx context.Context, token *entity.APIToken, tokenHash string) error {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `
+		INSERT INTO api_tokens (id, org_id, name, token_hash, scopes, user_id, created_at, expires_at) 
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8)`
+
+	_, err := queryer.Exec(ctx, query,
+		token.ID, token.OrgID, token.Name, tokenHash, scopesToStrings(token.Scopes), token.UserID, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		return postgres.TranslateError("TokenRepo.Create", err)
+	}
+
+	return nil
+}
+
+// GetByHash finds a token by the hash of its secret in any organization,
+// it runs before the organization of the request is known
+func (r *TokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.APIToken, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	query := `SELECT ` + tokenColumns + ` FROM api_tokens WHERE token_hash = $1`
+
+	token, err := scanToken(queryer.QueryRow(ctx, query, tokenHash))
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("TokenRepo.GetByHash: %w", err)
+	}
+
+	return token, nil
+}
+
+// List returns all tokens issued in the organization, newest first
+func (r *TokenRepository) List(ctx context.Context) ([]*entity.APIToken, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	query := `SELECT ` + tokenColumns + ` FROM api_tokens WHERE org_id = $1 ORDER BY created_at DESC, id`
+
+	rows, err := queryer.Query(ctx, query, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("TokenRepo.List: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]*entity.APIToken, 0)
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("TokenRepo.List scan: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// Revoke marks a token as revoked, revoking twice keeps the first timestamp
+func (r *TokenRepository) Revoke(ctx context.Context, id string) (*entity.APIToken, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	query := `
+		UPDATE api_tokens 
+		SET revoked_at = COALESCE(revoked_at, NOW()) 
+		WHERE org_id = $1 AND id = $2 
+		RETURNING ` + tokenColumns
+
+	token, err := scanToken(queryer.QueryRow(ctx, query, tenant.FromContext(ctx), id))
+	if err == pgx.ErrNoRows {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, postgres.TranslateError("TokenRepo.Revoke", err)
+	}
+
+	return token, nil
+}
+
+// scanToken reads a token row selected with tokenColumns
+func scanToken(row pgx.Row) (*entity.APIToken, error) {
+	token := &entity.APIToken{}
+	var scopes []string
+	var expiresAt, revokedAt pgtype.Timestamptz
+
+	if err := row.Scan(&token.ID, &token.OrgID, &token.Name, &token.UserID, &scopes, &token.CreatedAt, &expiresAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	token.Scopes = make([]entity.Scope, len(scopes))
+	for i, s := range scopes {
+		token.Scopes[i] = entity.Scope(s)
+	}
+	if expiresAt.Valid {
+		token.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return token, nil
+}
+
+// scopesToStrings converts scopes to a postgres text array value
+func scopesToStrings(scopes []entity.Scope) []string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = string(s)
+	}
+	return out
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/postgres"
 
 	"github.com/jackc/pgx/v5"
@@ -29,11 +30,11 @@ var _ repository.UserRepository = (*UserRepository)(nil)
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*entity.User, error) {
 	queryer := r.trm.GetQueryer(ctx)
 
-	const query = `SELECT id, username, team_name, is_active FROM users WHERE id = $1`
+	const query = `SELECT id, username, team_name, is_active, role FROM users WHERE org_id = $1 AND id = $2`
 
 	user := &entity.User{}
 	// execute query and scan result
-	err := queryer.QueryRow(ctx, query, id).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive)
+	err := queryer.QueryRow(ctx, query, tenant.FromContext(ctx), id).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.Role)
 
 	if err == pgx.ErrNoRows {
 		return nil, entity.ErrNotFound
@@ -49,11 +50,11 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*entity.User,
 func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 	queryer := r.trm.GetQueryer(ctx)
 
-	const query = `INSERT INTO users (id, username, team_name, is_active) VALUES ($1, $2, $3, $4)`
+	const query = `INSERT INTO users (org_id, id, username, team_name, is_active, role) VALUES ($1, $2, $3, $4, $5, $6)`
 
-	_, err := queryer.Exec(ctx, query, user.ID, user.Username, user.TeamName, user.IsActive)
+	_, err := queryer.Exec(ctx, query, tenant.FromContext(ctx), user.ID, user.Username, user.TeamName, user.IsActive, roleOrDefault(user.Role))
 	if err != nil {
-		return fmt.Errorf("UserRepo.Create: %w", err)
+		return postgres.TranslateError("UserRepo.Create", err)
 	}
 	return nil
 }
@@ -64,11 +65,11 @@ func (r *UserRepository) GetActiveCandidatesByTeam(ctx context.Context, teamName
 
 	// filter by team, active status and exclude author
 	const query = `
-		SELECT id, username, team_name, is_active 
+		SELECT id, username, team_name, is_active, role 
 		FROM users 
-		WHERE team_name = $1 AND is_active = TRUE AND id != $2`
+		WHERE org_id = $1 AND team_name = $2 AND is_active = TRUE AND id != $3`
 
-	rows, err := queryer.Query(ctx, query, teamName, excludeUserID)
+	rows, err := queryer.Query(ctx, query, tenant.FromContext(ctx), teamName, excludeUserID)
 	if err != nil {
 		return nil, fmt.Errorf("UserRepo.GetActiveCandidatesByTeam: %w", err)
 	}
@@ -77,7 +78,7 @@ func (r *UserRepository) GetActiveCandidatesByTeam(ctx context.Context, teamName
 	users := make([]*entity.User, 0)
 	for rows.Next() {
 		user := &entity.User{}
-		err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive)
+		err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.Role)
 		if err != nil {
 			return nil, fmt.Errorf("UserRepo.GetActiveCandidatesByTeam scan: %w", err)
 		}
@@ -86,26 +87,62 @@ func (r *UserRepository) GetActiveCandidatesByTeam(ctx context.Context, teamName
 	return users, rows.Err()
 }
 
+// ListByTeam retrieves all members of a team including inactive ones in id order
+func (r *UserRepository) ListByTeam(ctx context.Context, teamName string) ([]*entity.User, error) {
+	queryer := r.trm.GetQueryer(ctx)
+
+	const query = `
+		SELECT id, username, team_name, is_active, role 
+		FROM users 
+		WHERE org_id = $1 AND team_name = $2 
+		ORDER BY id`
+
+	rows, err := queryer.Query(ctx, query, tenant.FromContext(ctx), teamName)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo.ListByTeam: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*entity.User, 0)
+	for rows.Next() {
+		user := &entity.User{}
+		err := rows.Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.Role)
+		if err != nil {
+			return nil, fmt.Errorf("UserRepo.ListByTeam scan: %w", err)
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
 // SetIsActive updates user's active status
 func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
 	queryer := r.trm.GetQueryer(ctx)
 
 	const query = `
 		UPDATE users 
-		SET is_active = $2 
-		WHERE id = $1 
-		RETURNING id, username, team_name, is_active`
+		SET is_active = $3 
+		WHERE org_id = $1 AND id = $2 
+		RETURNING id, username, team_name, is_active, role`
 
 	user := &entity.User{}
 	// execute update and return modified user
-	err := queryer.QueryRow(ctx, query, userID, isActive).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive)
+	err := queryer.QueryRow(ctx, query, tenant.FromContext(ctx), userID, isActive).Scan(&user.ID, &user.Username, &user.TeamName, &user.IsActive, &user.Role)
 
 	if err == pgx.ErrNoRows {
 		return nil, entity.ErrNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("UserRepo.SetIsActive: %w", err)
+		return nil, postgres.TranslateError("UserRepo.SetIsActive", err)
 	}
 
 	return user, nil
 }
+
+// roleOrDefault stores members without an explicit role as regular members
+func roleOrDefault(role entity.Role) entity.Role {
+	if role == "" {
+		return entity.RoleMember
+	}
+	return role
+}
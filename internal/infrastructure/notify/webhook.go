@@ -0,0 +1,191 @@
+// Package notify delivers reviewer notifications to external chat services
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// DefaultTemplate is used when no message template is configured
+const DefaultTemplate = `{{.Mentions}} you have been assigned to review "{{.PR.Name}}" ({{.PR.ID}}) by {{.PR.AuthorID}}`
+
+// DefaultReminderTemplate is used when no reminder template is configured
+const DefaultReminderTemplate = `{{.Mentions}} {{if eq .Kind "ESCALATION"}}escalation{{else}}reminder{{end}}: "{{.PR.Name}}" ({{.PR.ID}}) is waiting for review since {{.PR.CreatedAt.Format "2006-01-02 15:04 MST"}}`
+
+// MessageData is passed to the message templates
+type MessageData struct {
+	PR        *entity.PullRequest
+	Reviewers []entity.User
+	Mentions  string
+	Kind      entity.SLAKind // empty for assignment messages
+}
+
+// WebhookNotifier posts messages to slack-compatible incoming webhooks
+type WebhookNotifier struct {
+	webhooks       map[string]string // org/team name -> webhook url, bare team names for the default org
+	defaultWebhook string
+	mentions       map[string]string // org/user id -> chat member id, bare user ids for the default org
+	tmpl           *template.Template
+	reminderTmpl   *template.Template
+	client         *http.Client
+}
+
+// check for interface implementation
+var _ services.Notifier = (*WebhookNotifier)(nil)
+
+// NewWebhookNotifier creates a new webhook notifier instance
+func NewWebhookNotifier(webhooks map[string]string, defaultWebhook string, mentions map[string]string, msgTemplate, reminderTemplate string, timeout time.Duration) (*WebhookNotifier, error) {
+	if msgTemplate == "" {
+		msgTemplate = DefaultTemplate
+	}
+	if reminderTemplate == "" {
+		reminderTemplate = DefaultReminderTemplate
+	}
+
+	tmpl, err := template.New("message").Parse(msgTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("notify - NewWebhookNotifier - template.Parse: %w", err)
+	}
+
+	reminderTmpl, err := template.New("reminder").Parse(reminderTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("notify - NewWebhookNotifier - template.Parse (reminder): %w", err)
+	}
+
+	return &WebhookNotifier{
+		webhooks:       webhooks,
+		defaultWebhook: defaultWebhook,
+		mentions:       mentions,
+		tmpl:           tmpl,
+		reminderTmpl:   reminderTmpl,
+		client:         &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// NotifyAssigned sends one message per reviewer team to the team's webhook
+func (n *WebhookNotifier) NotifyAssigned(ctx context.Context, pr *entity.PullRequest, reviewers []entity.User) error {
+	return n.send(ctx, n.tmpl, pr, reviewers, "")
+}
+
+// NotifyReminder sends an sla reminder or escalation to the reviewers' team webhooks
+func (n *WebhookNotifier) NotifyReminder(ctx context.Context, pr *entity.PullRequest, reviewers []entity.User, kind entity.SLAKind) error {
+	return n.send(ctx, n.reminderTmpl, pr, reviewers, kind)
+}
+
+// send renders and posts one message per reviewer team
+func (n *WebhookNotifier) send(ctx context.Context, tmpl *template.Template, pr *entity.PullRequest, reviewers []entity.User, kind entity.SLAKind) error {
+	// group reviewers by team since every team has its own channel
+	byTeam := make(map[string][]entity.User)
+	for _, rev := range reviewers {
+		byTeam[rev.TeamName] = append(byTeam[rev.TeamName], rev)
+	}
+
+	var errs []error
+	for teamName, teamReviewers := range byTeam {
+		url := n.webhookFor(tenant.FromContext(ctx), teamName)
+		if url == "" {
+			continue // team has no channel configured
+		}
+
+		text, err := n.render(tmpl, tenant.FromContext(ctx), pr, teamReviewers, kind)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := n.post(ctx, url, text); err != nil {
+			errs = append(errs, fmt.Errorf("team %s: %w", teamName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// webhookFor returns the organization team webhook url or the default one
+func (n *WebhookNotifier) webhookFor(orgID, teamName string) string {
+	if url, ok := lookup(n.webhooks, orgID, teamName); ok {
+		return url
+	}
+	return n.defaultWebhook
+}
+
+// mention formats a chat mention for the user, falling back to the username
+func (n *WebhookNotifier) mention(orgID string, user entity.User) string {
+	if memberID, ok := lookup(n.mentions, orgID, user.ID); ok {
+		return "<@" + memberID + ">"
+	}
+	return "@" + user.Username
+}
+
+// lookup finds the org/key entry, bare keys belong to the default organization
+// since team names and user ids repeat across organizations
+func lookup(m map[string]string, orgID, key string) (string, bool) {
+	if value, ok := m[orgID+"/"+key]; ok {
+		return value, true
+	}
+	if orgID != tenant.Default {
+		return "", false
+	}
+	value, ok := m[key]
+	return value, ok
+}
+
+// render builds the message text from the given template
+func (n *WebhookNotifier) render(tmpl *template.Template, orgID string, pr *entity.PullRequest, reviewers []entity.User, kind entity.SLAKind) (string, error) {
+	mentions := make([]string, len(reviewers))
+	for i, rev := range reviewers {
+		mentions[i] = n.mention(orgID, rev)
+	}
+
+	data := MessageData{
+		PR:        pr,
+		Reviewers: reviewers,
+		Mentions:  strings.Join(mentions, " "),
+		Kind:      kind,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("notify - render: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// post sends the message payload to the webhook
+func (n *WebhookNotifier) post(ctx context.Context, url, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("notify - post - json.Marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify - post - http.NewRequest: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify - post - client.Do: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notify - post: webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/notify"
+)
+
+// chat is a webhook endpoint recording the messages posted to every path
+type chat struct {
+	*httptest.Server
+	mu       sync.Mutex
+	messages map[string][]string // path -> texts
+	status   int
+}
+
+func newChat(t *testing.T) *chat {
+	t.Helper()
+	c := &chat{messages: make(map[string][]string), status: http.StatusOK}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("%s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.messages[r.URL.Path] = append(c.messages[r.URL.Path], payload.Text)
+		w.WriteHeader(c.status)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+// received returns the paths that got a message
+func (c *chat) received() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	paths := make([]string, 0, len(c.messages))
+	for path := range c.messages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (c *chat) text(path string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return strings.Join(c.messages[path], "\n")
+}
+
+var testPR = &entity.PullRequest{
+	ID:        "pr-1",
+	Name:      "Add search",
+	AuthorID:  "u1",
+	CreatedAt: time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC),
+}
+
+func TestWebhookNotifier_Routing(t *testing.T) {
+	tests := []struct {
+		name           string
+		org            string
+		webhooks       map[string]string
+		defaultWebhook string
+		teams          []string // teams of the reviewers
+		want           []string // paths receiving a message
+	}{
+		{"team webhook", tenant.Default, map[string]string{"backend": "/backend"}, "", []string{"backend"}, []string{"/backend"}},
+		{"org team webhook wins", "acme", map[string]string{"backend": "/backend", "acme/backend": "/acme-backend"}, "/default", []string{"backend"}, []string{"/acme-backend"}},
+		{"bare team is the default org only", "globex", map[string]string{"backend": "/backend", "acme/backend": "/acme-backend"}, "", []string{"backend"}, []string{}},
+		{"other org falls back to the default webhook", "globex", map[string]string{"backend": "/backend"}, "/default", []string{"backend"}, []string{"/default"}},
+		{"default webhook", tenant.Default, map[string]string{"backend": "/backend"}, "/default", []string{"frontend"}, []string{"/default"}},
+		{"no webhook", tenant.Default, map[string]string{"backend": "/backend"}, "", []string{"frontend"}, []string{}},
+		{"one message per team", tenant.Default, map[string]string{"backend": "/backend", "frontend": "/frontend"}, "", []string{"backend", "frontend", "backend"}, []string{"/backend", "/frontend"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newChat(t)
+			webhooks := make(map[string]string, len(tt.webhooks))
+			for team, path := range tt.webhooks {
+				webhooks[team] = c.URL + path
+			}
+			defaultWebhook := ""
+			if tt.defaultWebhook != "" {
+				defaultWebhook = c.URL + tt.defaultWebhook
+			}
+
+			n, err := notify.NewWebhookNotifier(webhooks, defaultWebhook, nil, "", "", time.Second)
+			if err != nil {
+				t.Fatalf("new notifier: %v", err)
+			}
+			reviewers := make([]entity.User, len(tt.teams))
+			for i, team := range tt.teams {
+				reviewers[i] = entity.User{ID: fmt.Sprintf("r%d", i), Username: "reviewer", TeamName: team}
+			}
+
+			if err := n.NotifyAssigned(tenant.WithOrg(context.Background(), tt.org), testPR, reviewers); err != nil {
+				t.Fatalf("NotifyAssigned: %v", err)
+			}
+			if got := c.received(); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("messages sent to %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_Templates(t *testing.T) {
+	reviewers := []entity.User{
+		{ID: "u2", Username: "bob", TeamName: "backend"},
+		{ID: "u3", Username: "carol", TeamName: "backend"},
+	}
+	mentions := map[string]string{"u2": "U024BE7LH"}
+
+	tests := []struct {
+		name     string
+		template string
+		reminder string
+		kind     entity.SLAKind // empty for an assignment
+		want     string
+	}{
+		{"default assignment", "", "", "", `<@U024BE7LH> @carol you have been assigned to review "Add search" (pr-1) by u1`},
+		{"default reminder", "", "", entity.SLAReminder, `<@U024BE7LH> @carol reminder: "Add search" (pr-1) is waiting for review since 2026-01-02 15:04 UTC`},
+		{"default escalation", "", "", entity.SLAEscalation, `<@U024BE7LH> @carol escalation: "Add search" (pr-1) is waiting for review since 2026-01-02 15:04 UTC`},
+		{"custom assignment", `{{.PR.ID}}:{{range .Reviewers}} {{.Username}}{{end}}`, "", "", "pr-1: bob carol"},
+		{"custom reminder", "", `{{.Kind}} {{.Mentions}}`, entity.SLAEscalation, "ESCALATION <@U024BE7LH> @carol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newChat(t)
+			n, err := notify.NewWebhookNotifier(nil, c.URL+"/default", mentions, tt.template, tt.reminder, time.Second)
+			if err != nil {
+				t.Fatalf("new notifier: %v", err)
+			}
+
+			if tt.kind == "" {
+				err = n.NotifyAssigned(context.Background(), testPR, reviewers)
+			} else {
+				err = n.NotifyReminder(context.Background(), testPR, reviewers, tt.kind)
+			}
+			if err != nil {
+				t.Fatalf("notify: %v", err)
+			}
+			if got := c.text("/default"); got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// two organizations share a team name and a user id, neither sees the other's messages
+func TestWebhookNotifier_OrgIsolation(t *testing.T) {
+	c := newChat(t)
+	webhooks := map[string]string{"backend": c.URL + "/default-backend", "acme/backend": c.URL + "/acme-backend"}
+	mentions := map[string]string{"u2": "UDEFAULT", "acme/u2": "UACME"}
+	n, err := notify.NewWebhookNotifier(webhooks, "", mentions, "{{.Mentions}}", "", time.Second)
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+	reviewers := []entity.User{{ID: "u2", Username: "bob", TeamName: "backend"}}
+
+	for _, org := range []string{tenant.Default, "acme", "globex"} {
+		if err := n.NotifyAssigned(tenant.WithOrg(context.Background(), org), testPR, reviewers); err != nil {
+			t.Fatalf("NotifyAssigned(%s): %v", org, err)
+		}
+	}
+
+	want := map[string]string{"/default-backend": "<@UDEFAULT>", "/acme-backend": "<@UACME>"}
+	if got := c.received(); len(got) != len(want) {
+		t.Errorf("messages sent to %v, want %v", got, want)
+	}
+	for path, text := range want {
+		if got := c.text(path); got != text {
+			t.Errorf("%s got %q, want %q", path, got, text)
+		}
+	}
+}
+
+func TestWebhookNotifier_Errors(t *testing.T) {
+	if _, err := notify.NewWebhookNotifier(nil, "", nil, "{{.PR.ID", "", time.Second); err == nil {
+		t.Error("invalid template accepted")
+	}
+	if _, err := notify.NewWebhookNotifier(nil, "", nil, "", "{{end}}", time.Second); err == nil {
+		t.Error("invalid reminder template accepted")
+	}
+
+	reviewers := []entity.User{{ID: "u2", Username: "bob", TeamName: "backend"}}
+
+	t.Run("execution error", func(t *testing.T) {
+		c := newChat(t)
+		n, err := notify.NewWebhookNotifier(nil, c.URL, nil, "{{.PR.Missing}}", "", time.Second)
+		if err != nil {
+			t.Fatalf("new notifier: %v", err)
+		}
+		if err := n.NotifyAssigned(context.Background(), testPR, reviewers); err == nil {
+			t.Error("template execution error ignored")
+		}
+		if len(c.received()) != 0 {
+			t.Errorf("message sent although rendering failed: %v", c.received())
+		}
+	})
+
+	t.Run("webhook rejects the message", func(t *testing.T) {
+		c := newChat(t)
+		c.status = http.StatusInternalServerError
+		n, err := notify.NewWebhookNotifier(nil, c.URL, nil, "", "", time.Second)
+		if err != nil {
+			t.Fatalf("new notifier: %v", err)
+		}
+		err = n.NotifyAssigned(context.Background(), testPR, reviewers)
+		if err == nil || !strings.Contains(err.Error(), "team backend") || !strings.Contains(err.Error(), "500") {
+			t.Errorf("error = %v, want the team and the status", err)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+// Package oidc verifies sso issued jwt bearer tokens
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRefetchInterval limits jwks downloads caused by unknown key ids
+const minRefetchInterval = time.Minute
+
+var errKeyNotFound = errors.New("signing key not found")
+
+// KeySource provides public keys for signature verification
+type KeySource interface {
+	// Key returns the key with the given id, an empty id matches a set with a single key
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// KeySet is a parsed json web key set
+type KeySet struct {
+	keys map[string]crypto.PublicKey
+}
+
+// jwk holds the fields of a json web key used for signature verification
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseKeySet parses a jwks document, keys of unsupported types are skipped
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("oidc - ParseKeySet - json.Unmarshal: %w", err)
+	}
+
+	set := &KeySet{keys: make(map[string]crypto.PublicKey, len(doc.Keys))}
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("oidc - ParseKeySet - key %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			set.keys[k.Kid] = key
+		}
+	}
+
+	if len(set.keys) == 0 {
+		return nil, errors.New("oidc - ParseKeySet: no usable signing keys")
+	}
+	return set, nil
+}
+
+// Key returns the key with the given id
+func (s *KeySet) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	// tokens without kid are accepted only when there is no ambiguity
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, nil
+		}
+	}
+	return nil, errKeyNotFound
+}
+
+// has reports whether Key would find a key for the id
+func (s *KeySet) has(kid string) bool {
+	_, err := s.Key(context.Background(), kid)
+	return err == nil
+}
+
+// publicKey decodes the key material, nil means the key type is not supported
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("rsa exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, nil
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// LoadKeySetFile reads a jwks document from disk
+func LoadKeySetFile(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("oidc - LoadKeySetFile - os.ReadFile: %w", err)
+	}
+	return ParseKeySet(data)
+}
+
+// RemoteKeySet downloads a jwks document and refreshes it periodically
+// and whenever a token is signed with an unknown key
+type RemoteKeySet struct {
+	url     string
+	refresh time.Duration
+	client  *http.Client
+	now     func() time.Time
+
+	mu        sync.Mutex
+	set       *KeySet
+	fetchedAt time.Time
+	inflight  *fetchCall // download shared by concurrent callers
+}
+
+// fetchCall is a jwks download in progress
+type fetchCall struct {
+	done chan struct{}
+	err  error
+}
+
+// wait blocks until the download finishes or ctx is done
+func (c *fetchCall) wait(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// check for interface implementation
+var _ KeySource = (*RemoteKeySet)(nil)
+
+// NewRemoteKeySet creates a jwks source for the given url, keys are fetched lazily
+func NewRemoteKeySet(url string, refresh, timeout time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:     url,
+		refresh: refresh,
+		client:  &http.Client{Timeout: timeout},
+		now:     time.Now,
+	}
+}
+
+// Key returns the key with the given id, downloading the set when needed,
+// the lock is never held during a download so that requests with known keys are not stalled
+func (r *RemoteKeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	r.mu.Lock()
+	now := r.now()
+	var call *fetchCall
+	switch {
+	case r.set == nil:
+		// nothing is cached yet, the first callers wait for the download
+		call = r.startFetch()
+	case !r.set.has(kid) && now.Sub(r.fetchedAt) > minRefetchInterval:
+		// the issuer may have rotated its keys
+		call = r.startFetch()
+	case now.Sub(r.fetchedAt) > r.refresh:
+		// cached keys keep serving while the set is refreshed in background
+		r.startFetch()
+	}
+	r.mu.Unlock()
+
+	if call != nil {
+		if err := call.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	set := r.set
+	r.mu.Unlock()
+	return set.Key(ctx, kid)
+}
+
+// startFetch begins a download unless one is in flight, the caller holds the lock
+func (r *RemoteKeySet) startFetch() *fetchCall {
+	if r.inflight != nil {
+		return r.inflight
+	}
+
+	call := &fetchCall{done: make(chan struct{})}
+	r.inflight = call
+	go r.runFetch(call)
+	return call
+}
+
+// runFetch downloads the set and publishes it, the previous set is kept on failure
+func (r *RemoteKeySet) runFetch(call *fetchCall) {
+	// not bound to a request, a cancelled caller must not fail the others; the client timeout applies
+	set, err := r.fetch(context.Background())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.inflight = nil
+	switch {
+	case err == nil:
+		r.set, r.fetchedAt = set, r.now()
+	case r.set != nil:
+		// keep serving cached keys and retry after the next refresh interval
+		slog.Warn("Failed to refresh JWKS, using cached keys", "error", err)
+		r.fetchedAt = r.now()
+	}
+
+	call.err = err
+	close(call.done)
+}
+
+// fetch downloads and parses the key set
+func (r *RemoteKeySet) fetch(ctx context.Context) (*KeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("oidc - fetch - http.NewRequest: %w", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc - fetch - client.Do: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc - fetch: jwks endpoint responded with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("oidc - fetch - io.ReadAll: %w", err)
+	}
+
+	return ParseKeySet(data)
+}
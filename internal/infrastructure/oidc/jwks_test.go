@@ -0,0 +1,151 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer serves a replaceable jwks document and counts downloads
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	doc     []byte
+	status  int
+	block   chan struct{} // when set, downloads wait for it to be closed
+	fetches atomic.Int32
+}
+
+func newJWKSServer(t *testing.T, doc []byte) *jwksServer {
+	t.Helper()
+	s := &jwksServer{doc: doc, status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.fetches.Add(1)
+		s.mu.Lock()
+		doc, status, block := s.doc, s.status, s.block
+		s.mu.Unlock()
+
+		if block != nil {
+			<-block
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write(doc)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) set(doc []byte, status int, block chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.doc, s.status, s.block = doc, status, block
+}
+
+// clock is a manually advanced time source
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newRemote(url string, c *clock) *RemoteKeySet {
+	r := NewRemoteKeySet(url, time.Hour, 5*time.Second)
+	r.now = c.Now
+	return r
+}
+
+func TestRemoteKeySet_UnknownKidRefetch(t *testing.T) {
+	keys := newTestKeys(t)
+	srv := newJWKSServer(t, jwks(t, rsaJWK("rsa-1", keys.rsa)))
+	c := &clock{now: time.Now()}
+	remote := newRemote(srv.URL, c)
+	ctx := context.Background()
+
+	if _, err := remote.Key(ctx, "rsa-1"); err != nil {
+		t.Fatalf("Key(rsa-1): %v", err)
+	}
+
+	// the issuer rotates keys, a refetch right after the download is rate limited
+	srv.set(jwks(t, rsaJWK("rsa-1", keys.rsa), rsaJWK("rsa-2", keys.other)), http.StatusOK, nil)
+	if _, err := remote.Key(ctx, "rsa-2"); !errors.Is(err, errKeyNotFound) {
+		t.Fatalf("Key(rsa-2) right after download = %v, want errKeyNotFound", err)
+	}
+	if n := srv.fetches.Load(); n != 1 {
+		t.Fatalf("downloads = %d, want 1", n)
+	}
+
+	c.Advance(2 * minRefetchInterval)
+	if _, err := remote.Key(ctx, "rsa-2"); err != nil {
+		t.Fatalf("Key(rsa-2) after rotation: %v", err)
+	}
+	if n := srv.fetches.Load(); n != 2 {
+		t.Errorf("downloads = %d, want 2", n)
+	}
+}
+
+func TestRemoteKeySet_RefreshDoesNotBlock(t *testing.T) {
+	keys := newTestKeys(t)
+	srv := newJWKSServer(t, jwks(t, rsaJWK("rsa-1", keys.rsa)))
+	c := &clock{now: time.Now()}
+	remote := newRemote(srv.URL, c)
+	ctx := context.Background()
+
+	if _, err := remote.Key(ctx, "rsa-1"); err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	// the periodic refresh hangs and then fails
+	release := make(chan struct{})
+	srv.set([]byte("broken"), http.StatusInternalServerError, release)
+	c.Advance(2 * time.Hour)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := remote.Key(ctx, "rsa-1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Key during refresh: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Key blocked on the jwks download")
+	}
+
+	close(release)
+	for remote.isFetching() {
+		time.Sleep(time.Millisecond)
+	}
+
+	// cached keys survive the failed refresh
+	if _, err := remote.Key(ctx, "rsa-1"); err != nil {
+		t.Fatalf("Key after failed refresh: %v", err)
+	}
+	if n := srv.fetches.Load(); n != 2 {
+		t.Errorf("downloads = %d, want 2", n)
+	}
+}
+
+// isFetching reports whether a download is in flight
+func (r *RemoteKeySet) isFetching() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.inflight != nil
+}
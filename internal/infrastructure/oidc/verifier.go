@@ -0,0 +1,121 @@
+// Package oidc verifies sso issued jwt bearer tokens
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// supportedMethods lists the asymmetric algorithms accepted from the issuer,
+// hmac is excluded so that a public key can never be used as a shared secret
+var supportedMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// Verifier validates jwt signature, issuer, audience and expiry
+// and maps the configured claim to a user id
+type Verifier struct {
+	keys      KeySource
+	issuer    string
+	audience  string
+	userClaim string
+	orgClaim  string
+	scopes    []entity.Scope
+	leeway    time.Duration
+	now       func() time.Time
+}
+
+// NewVerifier creates a jwt verifier, scopes are granted to every sso user
+// and users without the organization claim belong to the default organization
+func NewVerifier(keys KeySource, issuer, audience, userClaim, orgClaim string, scopes []entity.Scope, leeway time.Duration) (*Verifier, error) {
+	if issuer == "" || audience == "" {
+		return nil, errors.New("oidc - NewVerifier: issuer and audience are required")
+	}
+	if userClaim == "" {
+		userClaim = "sub"
+	}
+	for _, s := range scopes {
+		if !s.IsValid() {
+			return nil, fmt.Errorf("oidc - NewVerifier: %w: %s", entity.ErrInvalidScope, s)
+		}
+	}
+
+	return &Verifier{
+		keys:      keys,
+		issuer:    issuer,
+		audience:  audience,
+		userClaim: userClaim,
+		orgClaim:  orgClaim,
+		scopes:    scopes,
+		leeway:    leeway,
+		now:       time.Now,
+	}, nil
+}
+
+// WithClock replaces the time source used for expiry checks
+func (v *Verifier) WithClock(now func() time.Time) *Verifier {
+	v.now = now
+	return v
+}
+
+// Authenticate verifies a jwt and returns a credential acting on behalf of the mapped user
+func (v *Verifier) Authenticate(ctx context.Context, raw string) (*entity.APIToken, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(supportedMethods),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(v.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.leeway),
+		jwt.WithTimeFunc(v.now),
+	)
+
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrUnauthorized, err)
+	}
+
+	userID, _ := claims[v.userClaim].(string)
+	if userID == "" {
+		return nil, fmt.Errorf("%w: claim %q is missing", entity.ErrUnauthorized, v.userClaim)
+	}
+
+	// sso users are always pinned to an organization
+	orgID := tenant.Default
+	if v.orgClaim != "" {
+		if claimed, _ := claims[v.orgClaim].(string); claimed != "" {
+			if !tenant.IsValid(claimed) {
+				return nil, fmt.Errorf("%w: claim %q is not a valid organization id", entity.ErrUnauthorized, v.orgClaim)
+			}
+			orgID = claimed
+		}
+	}
+
+	token := &entity.APIToken{
+		OrgID:  orgID,
+		Name:   v.issuer,
+		UserID: userID,
+		Scopes: v.scopes,
+	}
+	if jti, ok := claims["jti"].(string); ok {
+		token.ID = jti
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		token.ExpiresAt = &exp.Time
+	}
+
+	return token, nil
+}
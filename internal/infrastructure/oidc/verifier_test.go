@@ -0,0 +1,178 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+const (
+	testIssuer   = "https://sso.example.com"
+	testAudience = "pr-reviewer"
+)
+
+// testKeys are signing keys generated per test run, their public parts form the jwks
+type testKeys struct {
+	rsa   *rsa.PrivateKey
+	ec    *ecdsa.PrivateKey
+	other *rsa.PrivateKey // not published, signs forged tokens
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKeys{rsa: rsaKey, ec: ecKey, other: other}
+}
+
+// rsaJWK returns the public jwk of an rsa key
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   b64(key.N.Bytes()),
+		"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// ecJWK returns the public jwk of a p-256 key
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   b64(key.X.FillBytes(make([]byte, 32))),
+		"y":   b64(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// jwks encodes keys as a jwks document
+func jwks(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// sign issues a token with the given claims on top of valid defaults
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, now time.Time, override jwt.MapClaims) string {
+	t.Helper()
+
+	claims := jwt.MapClaims{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"sub": "u1",
+		"exp": now.Add(time.Hour).Unix(),
+		"iat": now.Unix(),
+		"jti": "jwt-1",
+	}
+	for k, v := range override {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return raw
+}
+
+func TestVerifier_Authenticate(t *testing.T) {
+	keys := newTestKeys(t)
+	set, err := ParseKeySet(jwks(t, rsaJWK("rsa-1", keys.rsa), ecJWK("ec-1", keys.ec)))
+	if err != nil {
+		t.Fatalf("ParseKeySet: %v", err)
+	}
+
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	v, err := NewVerifier(set, testIssuer, testAudience, "sub", "org", []entity.Scope{entity.ScopePRWrite}, 30*time.Second)
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	v.WithClock(func() time.Time { return now })
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&keys.rsa.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantOrg string // empty when the token is rejected
+	}{
+		{"rsa", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, nil), "default"},
+		{"ecdsa with org", sign(t, jwt.SigningMethodES256, "ec-1", keys.ec, now, jwt.MapClaims{"org": "acme"}), "acme"},
+		{"expired within leeway", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, jwt.MapClaims{"exp": now.Add(-10 * time.Second).Unix()}), "default"},
+		{"expired beyond leeway", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()}), ""},
+		{"no expiry", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, jwt.MapClaims{"exp": nil}), ""},
+		{"bad signature", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.other, now, nil), ""},
+		{"wrong issuer", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, jwt.MapClaims{"iss": "https://evil.example.com"}), ""},
+		{"wrong audience", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, jwt.MapClaims{"aud": "another-service"}), ""},
+		{"unknown kid", sign(t, jwt.SigningMethodRS256, "rsa-2", keys.rsa, now, nil), ""},
+		{"alg none", sign(t, jwt.SigningMethodNone, "rsa-1", jwt.UnsafeAllowNoneSignatureType, now, nil), ""},
+		// the public key used as an hmac secret must not verify
+		{"hs256 with public key", sign(t, jwt.SigningMethodHS256, "rsa-1", publicDER, now, nil), ""},
+		{"missing user claim", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, jwt.MapClaims{"sub": nil}), ""},
+		{"invalid org claim", sign(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, now, jwt.MapClaims{"org": "Not An Org!"}), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := v.Authenticate(context.Background(), tt.token)
+			if tt.wantOrg == "" {
+				if !errors.Is(err, entity.ErrUnauthorized) {
+					t.Fatalf("Authenticate = %+v, %v, want ErrUnauthorized", token, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate: %v", err)
+			}
+			if token.UserID != "u1" || token.OrgID != tt.wantOrg || token.ID != "jwt-1" || token.ExpiresAt == nil {
+				t.Errorf("token = %+v", token)
+			}
+			if len(token.Scopes) != 1 || token.Scopes[0] != entity.ScopePRWrite {
+				t.Errorf("scopes = %v", token.Scopes)
+			}
+		})
+	}
+}
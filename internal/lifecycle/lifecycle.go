@@ -0,0 +1,167 @@
+// Package lifecycle runs the servers and background workers of the process
+// and stops them in order on a termination signal
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Manager tracks readiness of the process and the steps to stop its components
+type Manager struct {
+	timeout time.Duration
+	delay   time.Duration
+	ready   atomic.Bool
+	// first failure of a server, triggers the shutdown
+	failed chan error
+
+	mu       sync.Mutex
+	notReady []func()
+	steps    []step
+}
+
+// step stops one component within the shutdown deadline
+type step struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// New creates a manager, delay is how long the process keeps serving after readiness
+// flips to false so that load balancers stop routing to it, timeout bounds the stop steps
+func New(timeout, delay time.Duration) *Manager {
+	return &Manager{
+		timeout: timeout,
+		delay:   delay,
+		failed:  make(chan error, 1),
+	}
+}
+
+// Ready reports whether the process accepts traffic, it is false until MarkReady and once shutdown begins
+func (m *Manager) Ready() bool {
+	return m.ready.Load()
+}
+
+// MarkReady is called once every component is started
+func (m *Manager) MarkReady() {
+	m.ready.Store(true)
+}
+
+// OnNotReady registers a callback run when readiness flips to false, e.g. a grpc health status
+func (m *Manager) OnNotReady(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notReady = append(m.notReady, fn)
+}
+
+// OnStop registers a shutdown step, steps run in reverse order of registration like defers,
+// so a component registered after its dependencies is stopped before them
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.steps = append(m.steps, step{name: name, stop: stop})
+}
+
+// Serve runs a blocking server in background and registers stop as its shutdown step,
+// serve returns nil once stopped and an error when it fails, which shuts the process down
+func (m *Manager) Serve(name string, serve func() error, stop func(ctx context.Context) error) {
+	m.OnStop(name, stop)
+	go func() {
+		if err := serve(); err != nil {
+			select {
+			case m.failed <- fmt.Errorf("%s: %w", name, err):
+			default:
+			}
+		}
+	}()
+}
+
+// Go runs a background worker until its shutdown step cancels ctx, the step waits for run to return
+func (m *Manager) Go(name string, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+
+	m.OnStop(name, func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	})
+}
+
+// Wait blocks until SIGINT or SIGTERM, a server failure or cancellation of ctx, then shuts down,
+// a second signal abandons the shutdown
+func (m *Manager) Wait(ctx context.Context) error {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	var cause error
+	select {
+	case sig := <-signals:
+		slog.Info("Received signal, shutting down", "signal", sig.String())
+	case cause = <-m.failed:
+		slog.Error("Server failed, shutting down", "error", cause)
+	case <-ctx.Done():
+		slog.Info("Shutting down")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- m.Shutdown() }()
+
+	select {
+	case err := <-done:
+		return errors.Join(cause, err)
+	case sig := <-signals:
+		return fmt.Errorf("shutdown abandoned on second signal %s", sig)
+	}
+}
+
+// Shutdown flips readiness to false, waits for the drain delay and runs the stop steps
+// with a shared deadline, a failed step does not prevent the next ones
+func (m *Manager) Shutdown() error {
+	m.ready.Store(false)
+
+	m.mu.Lock()
+	notReady, steps := m.notReady, m.steps
+	m.mu.Unlock()
+
+	for _, fn := range notReady {
+		fn()
+	}
+
+	// in-flight and newly routed requests are still served during the delay
+	if m.delay > 0 {
+		slog.Info("Readiness is off, waiting before stopping servers", "delay", m.delay)
+		time.Sleep(m.delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(steps) - 1; i >= 0; i-- {
+		s, start := steps[i], time.Now()
+		if err := s.stop(ctx); err != nil {
+			slog.Error("Failed to stop gracefully", "component", s.name, "error", err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", s.name, err))
+			continue
+		}
+		slog.Info("Stopped", "component", s.name, "took", time.Since(start))
+	}
+	return errors.Join(errs...)
+}
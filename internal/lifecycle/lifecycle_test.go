@@ -0,0 +1,104 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/lifecycle"
+)
+
+func TestManager_StopsInReverseOrder(t *testing.T) {
+	lc := lifecycle.New(time.Second, 0)
+
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	lc.OnNotReady(func() {
+		if lc.Ready() {
+			t.Error("readiness is on in the not ready callback")
+		}
+		order = append(order, "not ready")
+	})
+	lc.OnStop("storage", record("storage"))
+	lc.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		order = append(order, "worker")
+	})
+	stopped := make(chan struct{})
+	lc.Serve("server", func() error {
+		<-stopped
+		return nil
+	}, func(context.Context) error {
+		close(stopped)
+		order = append(order, "server")
+		return nil
+	})
+
+	lc.MarkReady()
+	if !lc.Ready() {
+		t.Fatal("not ready after MarkReady")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := lc.Wait(ctx); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	if got := fmt.Sprint(order); got != "[not ready server worker storage]" {
+		t.Errorf("shutdown order = %s", got)
+	}
+	if lc.Ready() {
+		t.Error("ready after shutdown")
+	}
+}
+
+func TestManager_ServerFailure(t *testing.T) {
+	lc := lifecycle.New(time.Second, 0)
+
+	errBind := errors.New("address in use")
+	stopped := false
+	lc.OnStop("storage", func(context.Context) error {
+		stopped = true
+		return nil
+	})
+	lc.Serve("server", func() error { return errBind }, func(context.Context) error { return nil })
+
+	if err := lc.Wait(context.Background()); !errors.Is(err, errBind) {
+		t.Fatalf("wait = %v, want the server error", err)
+	}
+	if !stopped {
+		t.Error("storage was not stopped after the server failed")
+	}
+}
+
+func TestManager_Timeout(t *testing.T) {
+	lc := lifecycle.New(20*time.Millisecond, 0)
+
+	closed := false
+	lc.OnStop("storage", func(context.Context) error {
+		closed = true
+		return nil
+	})
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	lc.Go("stuck worker", func(context.Context) {
+		<-release
+	})
+
+	// the stuck worker fails its step, the pool is still closed
+	if err := lc.Shutdown(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("shutdown = %v, want deadline exceeded", err)
+	}
+	if !closed {
+		t.Error("storage was not stopped after a step timed out")
+	}
+}
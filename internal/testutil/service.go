@@ -0,0 +1,61 @@
+// Package testutil wires the service on the in-memory storage for transport and client tests
+package testutil
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/memory"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/handler"
+	authmw "github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/middleware"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/router"
+)
+
+// BootstrapToken is accepted as an admin token by every service built here
+const BootstrapToken = "test-bootstrap-token"
+
+// Service holds the use cases wired like main does, authentication is enabled
+type Service struct {
+	Store  *memory.Store
+	Teams  *services.TeamUseCase
+	Users  *services.UserUseCase
+	PRs    *services.PRUseCase
+	Tokens *services.TokenUseCase
+	Events *services.EventUseCase
+
+	idempotency *memory.IdempotencyRepository
+}
+
+// NewService creates a service on an empty in-memory storage
+func NewService() *Service {
+	store := memory.NewStore()
+	teamRepo, userRepo, prRepo := memory.NewTeamRepository(store), memory.NewUserRepository(store), memory.NewPRRepository(store)
+
+	authz := services.NewAuthorizer(userRepo)
+	eventBus := services.NewEventBus(100)
+
+	return &Service{
+		Store:       store,
+		Teams:       services.NewTeamUseCase(teamRepo, userRepo, store, authz),
+		Users:       services.NewUserUseCase(userRepo, prRepo, authz),
+		PRs:         services.NewPRUseCase(prRepo, userRepo, teamRepo, store, services.NewAssigner(), services.NopNotifier{}, eventBus, authz),
+		Tokens:      services.NewTokenUseCase(memory.NewTokenRepository(store), userRepo, BootstrapToken),
+		Events:      services.NewEventUseCase(eventBus, userRepo),
+		idempotency: memory.NewIdempotencyRepository(store),
+	}
+}
+
+// Router returns the http api of the service
+func (s *Service) Router() http.Handler {
+	return router.NewRouter(
+		handler.NewTeamHandler(s.Teams),
+		handler.NewUserHandler(s.Users),
+		handler.NewPRHandler(s.PRs),
+		handler.NewTokenHandler(s.Tokens),
+		handler.NewEventHandler(s.Events, time.Minute),
+		func() bool { return true },
+		authmw.Authenticate(s.Tokens, true),
+		authmw.Idempotency(s.idempotency, time.Hour),
+	)
+}
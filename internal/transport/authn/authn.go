@@ -0,0 +1,107 @@
+// Package authn resolves the caller and organization of a call, shared by the http and grpc apis
+package authn
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+)
+
+// TokenAuthenticator resolves a presented bearer token to an api token
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (*entity.APIToken, error)
+}
+
+// bearerRouter sends tokens shaped like a jwt to the sso verifier and the rest to api tokens
+type bearerRouter struct {
+	jwt    TokenAuthenticator
+	tokens TokenAuthenticator
+}
+
+// WithJWT accepts sso issued jwts next to api tokens
+func WithJWT(jwt, tokens TokenAuthenticator) TokenAuthenticator {
+	return bearerRouter{jwt: jwt, tokens: tokens}
+}
+
+// Authenticate picks the authenticator by token shape, api tokens never contain dots
+func (b bearerRouter) Authenticate(ctx context.Context, secret string) (*entity.APIToken, error) {
+	if strings.Count(secret, ".") == 2 {
+		return b.jwt.Authenticate(ctx, secret)
+	}
+	return b.tokens.Authenticate(ctx, secret)
+}
+
+// Resolve authenticates the authorization value of a call and returns ctx carrying the caller
+// principal and the organization, requestedOrg is the X-Org-ID header or x-org-id metadata,
+// when disabled every call gets full access
+func Resolve(ctx context.Context, authenticator TokenAuthenticator, enabled bool, authorization, requestedOrg string) (context.Context, error) {
+	if !enabled {
+		orgID, err := resolveOrg(requestedOrg, "")
+		if err != nil {
+			return nil, err
+		}
+		p := auth.Principal{Scopes: []entity.Scope{entity.ScopeTeamAdmin}}
+		return tenant.WithOrg(auth.WithPrincipal(ctx, p), orgID), nil
+	}
+
+	secret, ok := bearerToken(authorization)
+	if !ok {
+		return nil, entity.ErrUnauthorized
+	}
+
+	token, err := authenticator.Authenticate(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	orgID, err := resolveOrg(requestedOrg, token.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := auth.Principal{
+		TokenID: token.ID,
+		UserID:  token.UserID,
+		Scopes:  token.Scopes,
+	}
+	return tenant.WithOrg(auth.WithPrincipal(ctx, p), orgID), nil
+}
+
+// Rejected reports whether err rejects the caller, other errors of Resolve are failures
+// of the authentication itself, e.g. an unavailable token store, and are worth logging
+func Rejected(err error) bool {
+	return errors.Is(err, entity.ErrUnauthorized) || errors.Is(err, entity.ErrForbidden) || errors.Is(err, entity.ErrInvalidOrg)
+}
+
+// resolveOrg picks the organization of the call, credentials bound to
+// an organization cannot be used for another one
+func resolveOrg(requested, bound string) (string, error) {
+	if bound != "" {
+		if requested != "" && requested != bound {
+			return "", entity.ErrForbidden
+		}
+		return bound, nil
+	}
+
+	if requested == "" {
+		return tenant.Default, nil
+	}
+	if !tenant.IsValid(requested) {
+		return "", entity.ErrInvalidOrg
+	}
+	return requested, nil
+}
+
+// bearerToken extracts the token from an authorization value
+func bearerToken(authorization string) (string, bool) {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
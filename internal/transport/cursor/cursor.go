@@ -0,0 +1,45 @@
+// Package cursor encodes keyset pagination positions shared by the http and grpc apis
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// ErrMalformed is returned for cursors not produced by Encode
+var ErrMalformed = errors.New("cursor is malformed")
+
+// payload is the opaque cursor representation sent to clients
+type payload struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+// Encode serializes a keyset position into an opaque string
+func Encode(c *entity.PRCursor) string {
+	if c == nil {
+		return ""
+	}
+	raw, _ := json.Marshal(payload{CreatedAt: c.CreatedAt, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode parses a cursor received from a client, empty means first page
+func Decode(value string) (*entity.PRCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var p payload
+	if err := json.Unmarshal(raw, &p); err != nil || p.ID == "" {
+		return nil, ErrMalformed
+	}
+	return &entity.PRCursor{CreatedAt: p.CreatedAt, ID: p.ID}, nil
+}
@@ -0,0 +1,81 @@
+// Package server implements the grpc api on top of the domain use cases
+package server
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/authn"
+	pb "github.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1"
+)
+
+// OrgMetadata selects the organization for credentials not bound to one,
+// it is the grpc counterpart of the X-Org-ID header
+const OrgMetadata = "x-org-id"
+
+// methodScopes lists the scope every method requires, same as the http routes
+var methodScopes = map[string]entity.Scope{
+	pb.TeamService_AddTeam_FullMethodName:        entity.ScopePRWrite,
+	pb.TeamService_GetTeam_FullMethodName:        entity.ScopeRead,
+	pb.TeamService_GetSettings_FullMethodName:    entity.ScopeRead,
+	pb.TeamService_UpdateSettings_FullMethodName: entity.ScopePRWrite,
+
+	pb.UserService_SetIsActive_FullMethodName: entity.ScopePRWrite,
+	pb.UserService_GetReviews_FullMethodName:  entity.ScopeRead,
+
+	pb.PRService_Create_FullMethodName:       entity.ScopePRWrite,
+	pb.PRService_Merge_FullMethodName:        entity.ScopePRWrite,
+	pb.PRService_MarkReady_FullMethodName:    entity.ScopePRWrite,
+	pb.PRService_Close_FullMethodName:        entity.ScopePRWrite,
+	pb.PRService_Reopen_FullMethodName:       entity.ScopePRWrite,
+	pb.PRService_Reassign_FullMethodName:     entity.ScopePRWrite,
+	pb.PRService_SubmitReview_FullMethodName: entity.ScopePRWrite,
+	pb.PRService_List_FullMethodName:         entity.ScopeRead,
+	pb.PRService_Get_FullMethodName:          entity.ScopeRead,
+}
+
+// Authenticate requires a valid bearer token in the authorization metadata, stores the
+// caller principal and organization in the context and checks the scope of the method,
+// when disabled every call gets full access, the health service is always open
+func Authenticate(authenticator authn.TokenAuthenticator, enabled bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, "/"+grpc_health_v1.Health_ServiceDesc.ServiceName+"/") {
+			return handler(ctx, req)
+		}
+
+		// unknown methods are rejected instead of being left open
+		scope, ok := methodScopes[info.FullMethod]
+		if !ok {
+			return nil, statusError(entity.ErrForbidden)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx, err := authn.Resolve(ctx, authenticator, enabled, first(md, "authorization"), first(md, OrgMetadata))
+		if err != nil {
+			if !authn.Rejected(err) {
+				slog.Error("Failed to authenticate call", "method", info.FullMethod, "error", err)
+			}
+			return nil, statusError(err)
+		}
+
+		if !auth.FromContext(ctx).HasScope(scope) {
+			return nil, statusError(entity.ErrForbidden)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// first returns the first value of a metadata key
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
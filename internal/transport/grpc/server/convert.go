@@ -0,0 +1,136 @@
+// Package server implements the grpc api on top of the domain use cases
+package server
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	pb "github.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1"
+)
+
+// proto enum values are the entity values prefixed with the enum name, e.g. PR_STATUS_OPEN
+const (
+	rolePrefix             = "ROLE_"
+	statusPrefix           = "PR_STATUS_"
+	reviewStatePrefix      = "REVIEW_STATE_"
+	reviewActionPrefix     = "REVIEW_ACTION_"
+	assignmentActionPrefix = "ASSIGNMENT_ACTION_"
+)
+
+// fromEnum strips the enum prefix, the unspecified value becomes empty
+func fromEnum(name, prefix string) string {
+	value, ok := strings.CutPrefix(name, prefix)
+	if !ok || value == "UNSPECIFIED" {
+		return ""
+	}
+	return value
+}
+
+// toEnum looks up the enum number of an entity value, unknown values are unspecified
+func toEnum(value, prefix string, numbers map[string]int32) int32 {
+	return numbers[prefix+value]
+}
+
+func toPBUser(u *entity.User) *pb.User {
+	return &pb.User{
+		UserId:   u.ID,
+		Username: u.Username,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+		Role:     pb.Role(toEnum(string(u.Role), rolePrefix, pb.Role_value)),
+	}
+}
+
+func toPBTeam(team *entity.Team, members []*entity.User) *pb.Team {
+	resp := &pb.Team{TeamName: team.Name, Members: make([]*pb.TeamMember, 0, len(members))}
+	for _, m := range members {
+		resp.Members = append(resp.Members, &pb.TeamMember{
+			UserId:   m.ID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+			Role:     pb.Role(toEnum(string(m.Role), rolePrefix, pb.Role_value)),
+		})
+	}
+	return resp
+}
+
+func toPBSettings(settings *entity.TeamSettings) *pb.TeamSettings {
+	return &pb.TeamSettings{
+		TeamName:      settings.TeamName,
+		ReminderAfter: durationpb.New(settings.ReminderAfter),
+		EscalateAfter: durationpb.New(settings.EscalateAfter),
+		AutoReassign:  settings.AutoReassign,
+		MergePolicy: &pb.MergePolicy{
+			MinApprovals:            int32(settings.MergePolicy.MinApprovals),
+			RequireAllApproved:      settings.MergePolicy.RequireAllApproved,
+			BlockOnChangesRequested: settings.MergePolicy.BlockOnChangesRequested,
+		},
+	}
+}
+
+func toPBPullRequest(pr *entity.PullRequest) *pb.PullRequest {
+	resp := &pb.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            pb.PRStatus(toEnum(string(pr.Status), statusPrefix, pb.PRStatus_value)),
+		Version:           int64(pr.Version),
+		AssignedReviewers: make([]string, len(pr.Reviewers)),
+		CreatedAt:         timestamppb.New(pr.CreatedAt),
+		MergedAt:          optionalTimestamp(pr.MergedAt),
+		ClosedAt:          optionalTimestamp(pr.ClosedAt),
+	}
+	for i, u := range pr.Reviewers {
+		resp.AssignedReviewers[i] = u.ID
+	}
+	for i := range pr.Reviews {
+		resp.Reviews = append(resp.Reviews, toPBReview(&pr.Reviews[i]))
+	}
+
+	if h := pr.History; h != nil {
+		resp.AssignmentHistory = &pb.AssignmentHistory{
+			Assignments:   int32(h.Assignments),
+			Unassignments: int32(h.Unassignments),
+			LastChangeAt:  optionalTimestamp(h.LastChangeAt),
+		}
+		for _, e := range h.Events {
+			resp.AssignmentHistory.Events = append(resp.AssignmentHistory.Events, &pb.AssignmentEvent{
+				ReviewerId: e.ReviewerID,
+				Action:     pb.AssignmentAction(toEnum(string(e.Action), assignmentActionPrefix, pb.AssignmentAction_value)),
+				CreatedAt:  timestamppb.New(e.CreatedAt),
+			})
+		}
+	}
+	return resp
+}
+
+func toPBPullRequests(prs []*entity.PullRequest) []*pb.PullRequest {
+	resp := make([]*pb.PullRequest, len(prs))
+	for i, pr := range prs {
+		resp[i] = toPBPullRequest(pr)
+	}
+	return resp
+}
+
+func toPBReview(r *entity.Review) *pb.Review {
+	return &pb.Review{
+		PullRequestId:   r.PRID,
+		ReviewerId:      r.ReviewerID,
+		State:           pb.ReviewState(toEnum(string(r.State), reviewStatePrefix, pb.ReviewState_value)),
+		AssignedAt:      timestamppb.New(r.AssignedAt),
+		FirstResponseAt: optionalTimestamp(r.FirstResponseAt),
+		UpdatedAt:       timestamppb.New(r.UpdatedAt),
+	}
+}
+
+// optionalTimestamp keeps an unset time unset in the response
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
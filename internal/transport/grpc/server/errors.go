@@ -0,0 +1,138 @@
+// Package server implements the grpc api on top of the domain use cases
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/validate"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to failed calls
+const ErrorDomain = "pr-reviewer-assigner"
+
+// MapDomainErrorToGRPCCode translates domain errors to grpc status codes,
+// the second value is the http api error code, idempotency keys are http only,
+// the message is the same fixed text as in the http api
+func MapDomainErrorToGRPCCode(err error) (codes.Code, string, string) {
+	if errors.Is(err, entity.ErrNotFound) {
+		return codes.NotFound, "NOT_FOUND", entity.ErrNotFound.Error()
+	}
+	if errors.Is(err, entity.ErrTeamExists) {
+		return codes.AlreadyExists, "TEAM_EXISTS", entity.ErrTeamExists.Error()
+	}
+	if errors.Is(err, entity.ErrUserExists) {
+		return codes.AlreadyExists, "USER_EXISTS", entity.ErrUserExists.Error()
+	}
+	if errors.Is(err, entity.ErrPRMerged) {
+		return codes.FailedPrecondition, "PR_MERGED", entity.ErrPRMerged.Error()
+	}
+	if errors.Is(err, entity.ErrNotAssigned) {
+		return codes.FailedPrecondition, "NOT_ASSIGNED", entity.ErrNotAssigned.Error()
+	}
+	if errors.Is(err, entity.ErrNoCandidate) {
+		return codes.NotFound, "NO_CANDIDATE", entity.ErrNoCandidate.Error()
+	}
+	if errors.Is(err, entity.ErrPRExists) {
+		return codes.AlreadyExists, "PR_EXISTS", entity.ErrPRExists.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidTransition) {
+		return codes.FailedPrecondition, "INVALID_TRANSITION", entity.ErrInvalidTransition.Error()
+	}
+	if errors.Is(err, entity.ErrPRNotOpen) {
+		return codes.FailedPrecondition, "PR_NOT_OPEN", entity.ErrPRNotOpen.Error()
+	}
+	// the unmet policy conditions are meant for the caller
+	var blocked *entity.MergeBlockedError
+	if errors.As(err, &blocked) {
+		return codes.FailedPrecondition, "MERGE_BLOCKED", blocked.Error()
+	}
+	if errors.Is(err, entity.ErrMergeBlocked) {
+		return codes.FailedPrecondition, "MERGE_BLOCKED", entity.ErrMergeBlocked.Error()
+	}
+	if errors.Is(err, entity.ErrConflict) {
+		return codes.Aborted, "CONFLICT", entity.ErrConflict.Error()
+	}
+	if errors.Is(err, entity.ErrUnauthorized) {
+		return codes.Unauthenticated, "UNAUTHORIZED", entity.ErrUnauthorized.Error()
+	}
+	if errors.Is(err, entity.ErrForbidden) {
+		return codes.PermissionDenied, "FORBIDDEN", entity.ErrForbidden.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidScope) {
+		return codes.InvalidArgument, "INVALID_INPUT", entity.ErrInvalidScope.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidOrg) {
+		return codes.InvalidArgument, "INVALID_INPUT", entity.ErrInvalidOrg.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidVersion) {
+		return codes.InvalidArgument, "INVALID_INPUT", entity.ErrInvalidVersion.Error()
+	}
+	// so are the broken settings invariants
+	var invalid *entity.InvalidSettingsError
+	if errors.As(err, &invalid) {
+		return codes.InvalidArgument, "INVALID_INPUT", invalid.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidSettings) {
+		return codes.InvalidArgument, "INVALID_INPUT", entity.ErrInvalidSettings.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidReviewAction) {
+		return codes.InvalidArgument, "INVALID_INPUT", entity.ErrInvalidReviewAction.Error()
+	}
+	return codes.Internal, "INTERNAL_ERROR", "Internal server error"
+}
+
+// domainError logs a failed use case call and converts its error to a grpc status
+func domainError(logMsg string, err error) error {
+	slog.Error(logMsg, "error", err)
+	return statusError(err)
+}
+
+// statusError converts a domain error to a grpc status with the api error code attached
+func statusError(err error) error {
+	code, apiCode, msg := MapDomainErrorToGRPCCode(err)
+	st, detailErr := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{Reason: apiCode, Domain: ErrorDomain})
+	if detailErr != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// violationsError returns INVALID_ARGUMENT listing the violations, nil when there are none
+func violationsError(v validate.Violations) error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	fields := make([]*errdetails.BadRequest_FieldViolation, 0, len(v))
+	for _, violation := range v {
+		fields = append(fields, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Message,
+			Reason:      strings.ToUpper(violation.Constraint),
+		})
+	}
+
+	msg := v.Summary()
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(
+		&errdetails.ErrorInfo{Reason: "INVALID_INPUT", Domain: ErrorDomain},
+		&errdetails.BadRequest{FieldViolations: fields},
+	)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
+
+// invalidArgument reports a single missing or invalid field
+func invalidArgument(field, constraint, description string) error {
+	var v validate.Violations
+	v.Add(field, constraint, description)
+	return violationsError(v)
+}
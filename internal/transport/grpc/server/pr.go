@@ -0,0 +1,235 @@
+// Package server implements the grpc api on top of the domain use cases
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/cursor"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/validate"
+	pb "github.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1"
+)
+
+type PRServer struct {
+	pb.UnimplementedPRServiceServer
+	prService services.PRService
+}
+
+// NewPRServer creates the grpc pull request service
+func NewPRServer(service services.PRService) *PRServer {
+	return &PRServer{prService: service}
+}
+
+// Create opens a pull request and assigns reviewers
+func (s *PRServer) Create(ctx context.Context, req *pb.CreateRequest) (*pb.CreateResponse, error) {
+	var v validate.Violations
+	v.RequireID("pull_request_id", req.GetPullRequestId())
+	v.RequireText("pull_request_name", req.GetPullRequestName())
+	v.RequireID("author_id", req.GetAuthorId())
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prService.Create(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId(), req.GetDraft())
+	if err != nil {
+		return nil, domainError("Failed to create PR", err)
+	}
+	return &pb.CreateResponse{Pr: toPBPullRequest(pr)}, nil
+}
+
+// Merge marks a pr as merged, force bypasses the team merge policy
+func (s *PRServer) Merge(ctx context.Context, req *pb.MergeRequest) (*pb.MergeResponse, error) {
+	var v validate.Violations
+	version := checkTarget(&v, req.GetPullRequestId(), req.GetExpectedVersion())
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prService.Merge(ctx, req.GetPullRequestId(), req.GetForce(), version)
+	if err != nil {
+		return nil, domainError("Failed to merge PR", err)
+	}
+	return &pb.MergeResponse{Pr: toPBPullRequest(pr)}, nil
+}
+
+// MarkReady moves a draft pr to open and assigns reviewers
+func (s *PRServer) MarkReady(ctx context.Context, req *pb.MarkReadyRequest) (*pb.MarkReadyResponse, error) {
+	pr, err := s.changeStatus(ctx, req.GetPullRequestId(), req.GetExpectedVersion(), s.prService.MarkReady, "Failed to mark PR ready")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MarkReadyResponse{Pr: pr}, nil
+}
+
+// Close closes a pr without merging
+func (s *PRServer) Close(ctx context.Context, req *pb.CloseRequest) (*pb.CloseResponse, error) {
+	pr, err := s.changeStatus(ctx, req.GetPullRequestId(), req.GetExpectedVersion(), s.prService.Close, "Failed to close PR")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CloseResponse{Pr: pr}, nil
+}
+
+// Reopen reopens a closed pr
+func (s *PRServer) Reopen(ctx context.Context, req *pb.ReopenRequest) (*pb.ReopenResponse, error) {
+	pr, err := s.changeStatus(ctx, req.GetPullRequestId(), req.GetExpectedVersion(), s.prService.Reopen, "Failed to reopen PR")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReopenResponse{Pr: pr}, nil
+}
+
+// changeStatus applies a lifecycle operation to a pr
+func (s *PRServer) changeStatus(ctx context.Context, prID string, expectedVersion int64, op func(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error), logMsg string) (*pb.PullRequest, error) {
+	var v validate.Violations
+	version := checkTarget(&v, prID, expectedVersion)
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	pr, err := op(ctx, prID, version)
+	if err != nil {
+		return nil, domainError(logMsg, err)
+	}
+	return toPBPullRequest(pr), nil
+}
+
+// Reassign replaces a reviewer with another active member of their team
+func (s *PRServer) Reassign(ctx context.Context, req *pb.ReassignRequest) (*pb.ReassignResponse, error) {
+	var v validate.Violations
+	version := checkTarget(&v, req.GetPullRequestId(), req.GetExpectedVersion())
+	v.RequireID("old_reviewer_id", req.GetOldReviewerId())
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	pr, newReviewerID, err := s.prService.Reassign(ctx, req.GetPullRequestId(), req.GetOldReviewerId(), version)
+	if err != nil {
+		return nil, domainError("Failed to reassign reviewer", err)
+	}
+	return &pb.ReassignResponse{Pr: toPBPullRequest(pr), ReplacedBy: newReviewerID}, nil
+}
+
+// SubmitReview records a reviewer's decision on a pull request
+func (s *PRServer) SubmitReview(ctx context.Context, req *pb.SubmitReviewRequest) (*pb.SubmitReviewResponse, error) {
+	var v validate.Violations
+	version := checkTarget(&v, req.GetPullRequestId(), req.GetExpectedVersion())
+	v.RequireID("reviewer_id", req.GetReviewerId())
+	action := entity.ReviewAction(fromEnum(req.GetAction().String(), reviewActionPrefix))
+	if !action.IsValid() {
+		v.Add("action", "enum", entity.ErrInvalidReviewAction.Error())
+	}
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	review, err := s.prService.SubmitReview(ctx, req.GetPullRequestId(), req.GetReviewerId(), action, version)
+	if err != nil {
+		return nil, domainError("Failed to submit review", err)
+	}
+	return &pb.SubmitReviewResponse{Review: toPBReview(review)}, nil
+}
+
+// List returns a filtered page of pull requests, newest first by default
+func (s *PRServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	filter := entity.PRFilter{
+		AuthorID:   req.GetAuthorId(),
+		ReviewerID: req.GetReviewerId(),
+		TeamName:   req.GetTeamName(),
+		Desc:       !req.GetAscending(),
+	}
+
+	var v validate.Violations
+	filter.Statuses = parseStatuses(&v, req.GetStatuses())
+	filter.CreatedFrom = parseTimestamp(&v, "created_from", req.GetCreatedFrom())
+	filter.CreatedTo = parseTimestamp(&v, "created_to", req.GetCreatedTo())
+	filter.MergedFrom = parseTimestamp(&v, "merged_from", req.GetMergedFrom())
+	filter.MergedTo = parseTimestamp(&v, "merged_to", req.GetMergedTo())
+	filter.Limit = checkLimit(&v, req.GetLimit())
+	filter.After = parseCursor(&v, req.GetCursor())
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	page, err := s.prService.List(ctx, filter)
+	if err != nil {
+		return nil, domainError("Failed to list PRs", err)
+	}
+	return &pb.ListResponse{
+		PullRequests: toPBPullRequests(page.Items),
+		NextCursor:   cursor.Encode(page.Next),
+	}, nil
+}
+
+// Get returns a single pull request with reviewers and assignment history
+func (s *PRServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id", "required", "pull_request_id is required")
+	}
+
+	pr, err := s.prService.Get(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, domainError("Failed to get PR", err)
+	}
+	return &pb.GetResponse{Pr: toPBPullRequest(pr)}, nil
+}
+
+// checkTarget validates the pr id and expected version of a write call,
+// zero version disables the check
+func checkTarget(v *validate.Violations, prID string, expectedVersion int64) int {
+	v.RequireID("pull_request_id", prID)
+	if expectedVersion < 0 {
+		v.Add("expected_version", "range", "expected_version must not be negative")
+		return 0
+	}
+	return int(expectedVersion)
+}
+
+// parseStatuses converts status filters, unspecified is rejected
+func parseStatuses(v *validate.Violations, statuses []pb.PRStatus) []entity.PRStatus {
+	var result []entity.PRStatus
+	for _, st := range statuses {
+		status := entity.PRStatus(fromEnum(st.String(), statusPrefix))
+		if !status.IsValid() {
+			v.Add("statuses", "enum", "statuses must be DRAFT, OPEN, MERGED or CLOSED")
+			return nil
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// parseTimestamp converts an optional timestamp filter
+func parseTimestamp(v *validate.Violations, field string, ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		v.Add(field, "type", field+" is not a valid timestamp")
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// checkLimit validates an optional page size, zero means the default
+func checkLimit(v *validate.Violations, limit int32) int {
+	if limit < 0 {
+		v.Add("limit", "range", "limit must be a positive integer")
+		return 0
+	}
+	return int(limit)
+}
+
+// parseCursor decodes the cursor of the previous page
+func parseCursor(v *validate.Violations, value string) *entity.PRCursor {
+	after, err := cursor.Decode(value)
+	if err != nil {
+		v.Add("cursor", "type", err.Error())
+	}
+	return after
+}
@@ -0,0 +1,41 @@
+// Package server implements the grpc api on top of the domain use cases
+package server
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1"
+)
+
+// NewServer creates a grpc server with the api services and the standard health service,
+// authenticate is the interceptor returned by Authenticate, healthSrv is shut down on shutdown
+// to report NOT_SERVING
+func NewServer(team *TeamServer, user *UserServer, pr *PRServer, authenticate grpc.UnaryServerInterceptor, healthSrv *health.Server) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(recoverer, authenticate))
+
+	pb.RegisterTeamServiceServer(srv, team)
+	pb.RegisterUserServiceServer(srv, user)
+	pb.RegisterPRServiceServer(srv, pr)
+	grpc_health_v1.RegisterHealthServer(srv, healthSrv)
+
+	return srv
+}
+
+// recoverer turns a panic of a call into INTERNAL instead of crashing the process
+func recoverer(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			slog.Error("Panic in grpc call", "method", info.FullMethod, "panic", p, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "Internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
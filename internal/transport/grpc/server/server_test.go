@@ -0,0 +1,285 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/tenant"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/testutil"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
+	pb "github.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1"
+)
+
+const bootstrapToken = testutil.BootstrapToken
+
+// newConn serves the grpc api on the in-memory storage and returns a connection to it
+// together with a token having only the read scope
+func newConn(t *testing.T) (*grpc.ClientConn, string) {
+	t.Helper()
+
+	svc := testutil.NewService()
+	_, readToken, err := svc.Tokens.Mint(tenant.WithOrg(context.Background(), tenant.Default), "reader", "", []entity.Scope{entity.ScopeRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := NewServer(
+		NewTeamServer(svc.Teams),
+		NewUserServer(svc.Users),
+		NewPRServer(svc.PRs),
+		Authenticate(svc.Tokens, true),
+		health.NewServer(),
+	)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, readToken
+}
+
+// withToken adds the bearer token to outgoing metadata
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// assertStatus checks the grpc code and the api error code of a failed call
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != code {
+		t.Fatalf("error = %v, want code %s", err, code)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.Reason != reason {
+				t.Fatalf("reason = %s, want %s", info.Reason, reason)
+			}
+			return
+		}
+	}
+	t.Fatalf("error %v has no ErrorInfo detail", err)
+}
+
+func TestGRPC(t *testing.T) {
+	conn, _ := newConn(t)
+	ctx := withToken(context.Background(), bootstrapToken)
+	teams, users, prs := pb.NewTeamServiceClient(conn), pb.NewUserServiceClient(conn), pb.NewPRServiceClient(conn)
+
+	team, err := teams.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{
+		TeamName: "backend",
+		Members: []*pb.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true, Role: pb.Role_ROLE_ADMIN},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+			{UserId: "u3", Username: "Carol", IsActive: true},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := team.GetTeam().GetMembers()[1].GetRole(); got != pb.Role_ROLE_MEMBER {
+		t.Fatalf("default role = %s, want ROLE_MEMBER", got)
+	}
+
+	_, err = teams.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{TeamName: "backend"}})
+	assertStatus(t, err, codes.AlreadyExists, "TEAM_EXISTS")
+
+	user, err := users.SetIsActive(ctx, &pb.SetIsActiveRequest{UserId: "u3", IsActive: false})
+	if err != nil || user.GetUser().GetIsActive() {
+		t.Fatalf("SetIsActive = %v, %v", user, err)
+	}
+
+	// u2 is the only active member besides the author
+	created, err := prs.Create(ctx, &pb.CreateRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr := created.GetPr()
+	if pr.GetStatus() != pb.PRStatus_PR_STATUS_OPEN || len(pr.GetAssignedReviewers()) != 1 || pr.GetAssignedReviewers()[0] != "u2" {
+		t.Fatalf("created pr = %v", pr)
+	}
+
+	reviews, err := users.GetReviews(ctx, &pb.GetReviewsRequest{UserId: "u2", Pending: true})
+	if err != nil || len(reviews.GetPullRequests()) != 1 {
+		t.Fatalf("GetReviews = %v, %v", reviews, err)
+	}
+
+	_, err = prs.Reassign(ctx, &pb.ReassignRequest{PullRequestId: "pr-1", OldReviewerId: "u2"})
+	assertStatus(t, err, codes.NotFound, "NO_CANDIDATE")
+
+	_, err = prs.Merge(ctx, &pb.MergeRequest{PullRequestId: "pr-1", Force: true, ExpectedVersion: pr.GetVersion() + 1})
+	assertStatus(t, err, codes.Aborted, "CONFLICT")
+
+	merged, err := prs.Merge(ctx, &pb.MergeRequest{PullRequestId: "pr-1", Force: true, ExpectedVersion: pr.GetVersion()})
+	if err != nil || merged.GetPr().GetStatus() != pb.PRStatus_PR_STATUS_MERGED || merged.GetPr().GetMergedAt() == nil {
+		t.Fatalf("Merge = %v, %v", merged, err)
+	}
+
+	_, err = prs.Reassign(ctx, &pb.ReassignRequest{PullRequestId: "pr-1", OldReviewerId: "u2"})
+	assertStatus(t, err, codes.FailedPrecondition, "PR_MERGED")
+
+	list, err := prs.List(ctx, &pb.ListRequest{Statuses: []pb.PRStatus{pb.PRStatus_PR_STATUS_MERGED}})
+	if err != nil || len(list.GetPullRequests()) != 1 || list.GetNextCursor() != "" {
+		t.Fatalf("List = %v, %v", list, err)
+	}
+
+	got, err := prs.Get(ctx, &pb.GetRequest{PullRequestId: "pr-1"})
+	if err != nil || got.GetPr().GetAssignmentHistory().GetAssignments() != 1 {
+		t.Fatalf("Get = %v, %v", got, err)
+	}
+}
+
+func TestGRPCValidation(t *testing.T) {
+	conn, _ := newConn(t)
+	ctx := withToken(context.Background(), bootstrapToken)
+	prs := pb.NewPRServiceClient(conn)
+
+	_, err := prs.SubmitReview(ctx, &pb.SubmitReviewRequest{PullRequestId: "pr-1", ExpectedVersion: -1})
+	assertStatus(t, err, codes.InvalidArgument, "INVALID_INPUT")
+
+	var fields, reasons []string
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+				reasons = append(reasons, v.GetReason())
+			}
+		}
+	}
+	if fmt.Sprint(fields) != "[expected_version reviewer_id action]" {
+		t.Fatalf("violations = %v", fields)
+	}
+	// the same constraints as error.details of the http api
+	if fmt.Sprint(reasons) != "[RANGE REQUIRED ENUM]" {
+		t.Fatalf("reasons = %v", reasons)
+	}
+
+	_, err = prs.List(ctx, &pb.ListRequest{Cursor: "not-a-cursor"})
+	assertStatus(t, err, codes.InvalidArgument, "INVALID_INPUT")
+
+	// settings invariants are checked by the use case and reported as field violations
+	_, err = pb.NewTeamServiceClient(conn).UpdateSettings(ctx, &pb.UpdateSettingsRequest{Settings: &pb.TeamSettings{
+		TeamName:      "backend",
+		ReminderAfter: durationpb.New(2 * time.Hour),
+		EscalateAfter: durationpb.New(time.Hour),
+	}})
+	assertStatus(t, err, codes.InvalidArgument, "INVALID_INPUT")
+	fields = nil
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+	if fmt.Sprint(fields) != "[settings.escalate_after]" {
+		t.Errorf("settings violations = %v", fields)
+	}
+}
+
+func TestGRPCAuth(t *testing.T) {
+	conn, readToken := newConn(t)
+	teams := pb.NewTeamServiceClient(conn)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		code   codes.Code
+		reason string
+	}{
+		{name: "missing token", ctx: context.Background(), code: codes.Unauthenticated, reason: "UNAUTHORIZED"},
+		{name: "unknown token", ctx: withToken(context.Background(), "nope"), code: codes.Unauthenticated, reason: "UNAUTHORIZED"},
+		{name: "missing scope", ctx: withToken(context.Background(), readToken), code: codes.PermissionDenied, reason: "FORBIDDEN"},
+		{
+			name:   "foreign organization",
+			ctx:    metadata.AppendToOutgoingContext(withToken(context.Background(), readToken), OrgMetadata, "other"),
+			code:   codes.PermissionDenied,
+			reason: "FORBIDDEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := teams.AddTeam(tt.ctx, &pb.AddTeamRequest{Team: &pb.Team{TeamName: "backend"}})
+			assertStatus(t, err, tt.code, tt.reason)
+		})
+	}
+
+	// read scope is enough for reads
+	_, err := teams.GetTeam(withToken(context.Background(), readToken), &pb.GetTeamRequest{TeamName: "backend"})
+	assertStatus(t, err, codes.NotFound, "NOT_FOUND")
+
+	// the health check needs no token
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("health = %v, %v", resp, err)
+	}
+}
+
+// every domain error keeps the api error code of the http api
+func TestMapDomainErrorToGRPCCode(t *testing.T) {
+	httpToGRPC := map[int][]codes.Code{
+		http.StatusBadRequest:          {codes.InvalidArgument},
+		http.StatusUnauthorized:        {codes.Unauthenticated},
+		http.StatusForbidden:           {codes.PermissionDenied},
+		http.StatusNotFound:            {codes.NotFound},
+		http.StatusConflict:            {codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted},
+		http.StatusUnprocessableEntity: {codes.InvalidArgument},
+		http.StatusInternalServerError: {codes.Internal},
+	}
+
+	errs := []error{
+		entity.ErrNotFound, entity.ErrTeamExists, entity.ErrUserExists, entity.ErrPRMerged,
+		entity.ErrNoCandidate, entity.ErrNotAssigned, entity.ErrPRExists, entity.ErrInvalidReviewAction,
+		entity.ErrMergeBlocked, entity.ErrForbidden, entity.ErrUnauthorized, entity.ErrInvalidOrg,
+		entity.ErrInvalidScope, entity.ErrInvalidTransition, entity.ErrPRNotOpen, entity.ErrConflict, &entity.InvalidSettingsError{Violations: []entity.SettingsViolation{{Field: "merge_policy.min_approvals", Err: entity.ErrInvalidMergePolicy}}}, entity.ErrInvalidVersion, errors.New("db is down"),
+	}
+
+	for _, err := range errs {
+		t.Run(err.Error(), func(t *testing.T) {
+			wrapped := fmt.Errorf("op: %w", err)
+			httpStatus, httpCode, httpMsg := httperr.MapDomainErrorToHTTPCode(wrapped)
+			code, apiCode, msg := MapDomainErrorToGRPCCode(wrapped)
+
+			if apiCode != httpCode || msg != httpMsg {
+				t.Fatalf("grpc maps to %s %q, http to %s %q", apiCode, msg, httpCode, httpMsg)
+			}
+			// the operation stays in the logs, clients get the fixed message
+			if strings.Contains(msg, "op: ") {
+				t.Errorf("message %q exposes the wrapping operation", msg)
+			}
+			allowed := false
+			for _, c := range httpToGRPC[httpStatus] {
+				allowed = allowed || c == code
+			}
+			if !allowed {
+				t.Fatalf("grpc code %s does not match http status %d", code, httpStatus)
+			}
+		})
+	}
+}
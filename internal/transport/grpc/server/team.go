@@ -0,0 +1,139 @@
+// Package server implements the grpc api on top of the domain use cases
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/validate"
+	pb "github.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1"
+)
+
+type TeamServer struct {
+	pb.UnimplementedTeamServiceServer
+	teamService services.TeamService
+}
+
+// NewTeamServer creates the grpc team service
+func NewTeamServer(service services.TeamService) *TeamServer {
+	return &TeamServer{teamService: service}
+}
+
+// AddTeam creates a team and its members
+func (s *TeamServer) AddTeam(ctx context.Context, req *pb.AddTeamRequest) (*pb.AddTeamResponse, error) {
+	team := req.GetTeam()
+
+	var v validate.Violations
+	v.RequireID("team.team_name", team.GetTeamName())
+	seen := make(map[string]bool, len(team.GetMembers()))
+	for i, m := range team.GetMembers() {
+		prefix := fmt.Sprintf("team.members[%d].", i)
+		v.RequireID(prefix+"user_id", m.GetUserId())
+		v.RequireText(prefix+"username", m.GetUsername())
+		if m.GetUserId() != "" && seen[m.GetUserId()] {
+			v.Add(prefix+"user_id", "unique", "duplicate member "+m.GetUserId())
+		}
+		seen[m.GetUserId()] = true
+	}
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	// map request data to domain entities, members are plain members by default
+	teamEntity := &entity.Team{Name: team.GetTeamName()}
+	userEntities := make([]*entity.User, 0, len(team.GetMembers()))
+	for _, m := range team.GetMembers() {
+		role := entity.Role(fromEnum(m.GetRole().String(), rolePrefix))
+		if role == "" {
+			role = entity.RoleMember
+		}
+		userEntities = append(userEntities, &entity.User{
+			ID:       m.GetUserId(),
+			Username: m.GetUsername(),
+			TeamName: team.GetTeamName(),
+			IsActive: m.GetIsActive(),
+			Role:     role,
+		})
+	}
+
+	if err := s.teamService.CreateTeamWithUsers(ctx, teamEntity, userEntities); err != nil {
+		return nil, domainError("Failed to create team", err)
+	}
+	return &pb.AddTeamResponse{Team: toPBTeam(teamEntity, userEntities)}, nil
+}
+
+// GetTeam returns a team with all its members
+func (s *TeamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.GetTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name", "required", "team_name is required")
+	}
+
+	team, members, err := s.teamService.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, domainError("Failed to get team", err)
+	}
+	return &pb.GetTeamResponse{Team: toPBTeam(team, members)}, nil
+}
+
+// GetSettings returns review sla and merge policy settings of a team
+func (s *TeamServer) GetSettings(ctx context.Context, req *pb.GetSettingsRequest) (*pb.GetSettingsResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name", "required", "team_name is required")
+	}
+
+	settings, err := s.teamService.GetSettings(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, domainError("Failed to get team settings", err)
+	}
+	return &pb.GetSettingsResponse{Settings: toPBSettings(settings)}, nil
+}
+
+// UpdateSettings replaces review settings of a team, unset durations disable the check
+func (s *TeamServer) UpdateSettings(ctx context.Context, req *pb.UpdateSettingsRequest) (*pb.UpdateSettingsResponse, error) {
+	in := req.GetSettings()
+
+	var v validate.Violations
+	v.RequireID("settings.team_name", in.GetTeamName())
+	reminderAfter, escalateAfter := in.GetReminderAfter().AsDuration(), in.GetEscalateAfter().AsDuration()
+	if in.GetReminderAfter() != nil && in.GetReminderAfter().CheckValid() != nil {
+		v.Add("settings.reminder_after", "format", "reminder_after must be a valid duration")
+	}
+	if in.GetEscalateAfter() != nil && in.GetEscalateAfter().CheckValid() != nil {
+		v.Add("settings.escalate_after", "format", "escalate_after must be a valid duration")
+	}
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.teamService.UpdateSettings(ctx, &entity.TeamSettings{
+		TeamName:      in.GetTeamName(),
+		ReminderAfter: reminderAfter,
+		EscalateAfter: escalateAfter,
+		AutoReassign:  in.GetAutoReassign(),
+		MergePolicy: entity.MergePolicy{
+			MinApprovals:            int(in.GetMergePolicy().GetMinApprovals()),
+			RequireAllApproved:      in.GetMergePolicy().GetRequireAllApproved(),
+			BlockOnChangesRequested: in.GetMergePolicy().GetBlockOnChangesRequested(),
+		},
+	})
+	var invalid *entity.InvalidSettingsError
+	if errors.As(err, &invalid) {
+		return nil, violationsError(settingsViolations(invalid))
+	}
+	if err != nil {
+		return nil, domainError("Failed to update team settings", err)
+	}
+	return &pb.UpdateSettingsResponse{Settings: toPBSettings(settings)}, nil
+}
+
+// settingsViolations lists the broken settings invariants as request field violations
+func settingsViolations(err *entity.InvalidSettingsError) validate.Violations {
+	var v validate.Violations
+	for _, violation := range err.Violations {
+		v.Add("settings."+violation.Field, "range", violation.Err.Error())
+	}
+	return v
+}
@@ -0,0 +1,64 @@
+// Package server implements the grpc api on top of the domain use cases
+package server
+
+import (
+	"context"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/cursor"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/validate"
+	pb "github.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1"
+)
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	userService services.UserService
+}
+
+// NewUserServer creates the grpc user service
+func NewUserServer(service services.UserService) *UserServer {
+	return &UserServer{userService: service}
+}
+
+// SetIsActive updates the active status of a user
+func (s *UserServer) SetIsActive(ctx context.Context, req *pb.SetIsActiveRequest) (*pb.SetIsActiveResponse, error) {
+	var v validate.Violations
+	v.RequireID("user_id", req.GetUserId())
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, domainError("Failed to set user active status", err)
+	}
+	return &pb.SetIsActiveResponse{User: toPBUser(user)}, nil
+}
+
+// GetReviews returns a page of pull requests assigned to a user
+func (s *UserServer) GetReviews(ctx context.Context, req *pb.GetReviewsRequest) (*pb.GetReviewsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id", "required", "user_id is required")
+	}
+
+	// status defaults to OPEN in the service
+	filter := entity.PRFilter{Desc: true, OnlyPending: req.GetPending()}
+	var v validate.Violations
+	filter.Statuses = parseStatuses(&v, req.GetStatuses())
+	filter.Limit = checkLimit(&v, req.GetLimit())
+	filter.After = parseCursor(&v, req.GetCursor())
+	if err := violationsError(v); err != nil {
+		return nil, err
+	}
+
+	page, err := s.userService.GetReviews(ctx, req.GetUserId(), filter)
+	if err != nil {
+		return nil, domainError("Failed to get reviews for user", err)
+	}
+	return &pb.GetReviewsResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: toPBPullRequests(page.Items),
+		NextCursor:   cursor.Encode(page.Next),
+	}, nil
+}
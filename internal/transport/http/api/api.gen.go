@@ -0,0 +1,1720 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for APITokenScopes.
+const (
+	APITokenScopesPrWrite   APITokenScopes = "pr:write"
+	APITokenScopesRead      APITokenScopes = "read"
+	APITokenScopesTeamAdmin APITokenScopes = "team:admin"
+)
+
+// Defines values for AssignmentHistoryEventsAction.
+const (
+	ASSIGNED   AssignmentHistoryEventsAction = "ASSIGNED"
+	UNASSIGNED AssignmentHistoryEventsAction = "UNASSIGNED"
+)
+
+// Defines values for ErrorResponseErrorCode.
+const (
+	CONFLICT             ErrorResponseErrorCode = "CONFLICT"
+	FORBIDDEN            ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INVALIDINPUT         ErrorResponseErrorCode = "INVALID_INPUT"
+	INVALIDTRANSITION    ErrorResponseErrorCode = "INVALID_TRANSITION"
+	MERGEBLOCKED         ErrorResponseErrorCode = "MERGE_BLOCKED"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	PRNOTOPEN            ErrorResponseErrorCode = "PR_NOT_OPEN"
+	REQUESTINPROGRESS    ErrorResponseErrorCode = "REQUEST_IN_PROGRESS"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED         ErrorResponseErrorCode = "UNAUTHORIZED"
+	USEREXISTS           ErrorResponseErrorCode = "USER_EXISTS"
+)
+
+// Defines values for ErrorResponseErrorDetailsConstraint.
+const (
+	Enum      ErrorResponseErrorDetailsConstraint = "enum"
+	Format    ErrorResponseErrorDetailsConstraint = "format"
+	MaxLength ErrorResponseErrorDetailsConstraint = "max_length"
+	Range     ErrorResponseErrorDetailsConstraint = "range"
+	Required  ErrorResponseErrorDetailsConstraint = "required"
+	Type      ErrorResponseErrorDetailsConstraint = "type"
+	Unique    ErrorResponseErrorDetailsConstraint = "unique"
+	Unknown   ErrorResponseErrorDetailsConstraint = "unknown"
+)
+
+// Defines values for PullRequestStatus.
+const (
+	CLOSED PullRequestStatus = "CLOSED"
+	DRAFT  PullRequestStatus = "DRAFT"
+	MERGED PullRequestStatus = "MERGED"
+	OPEN   PullRequestStatus = "OPEN"
+)
+
+// Defines values for ReviewState.
+const (
+	APPROVED         ReviewState = "APPROVED"
+	CHANGESREQUESTED ReviewState = "CHANGES_REQUESTED"
+	COMMENTED        ReviewState = "COMMENTED"
+	PENDING          ReviewState = "PENDING"
+)
+
+// Defines values for TeamMemberRole.
+const (
+	TeamMemberRoleADMIN  TeamMemberRole = "ADMIN"
+	TeamMemberRoleMEMBER TeamMemberRole = "MEMBER"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleADMIN  UserRole = "ADMIN"
+	UserRoleMEMBER UserRole = "MEMBER"
+)
+
+// Defines values for CreateTokenJSONBodyScopes.
+const (
+	CreateTokenJSONBodyScopesPrWrite   CreateTokenJSONBodyScopes = "pr:write"
+	CreateTokenJSONBodyScopesRead      CreateTokenJSONBodyScopes = "read"
+	CreateTokenJSONBodyScopesTeamAdmin CreateTokenJSONBodyScopes = "team:admin"
+)
+
+// Defines values for ListPRsParamsSort.
+const (
+	Asc  ListPRsParamsSort = "asc"
+	Desc ListPRsParamsSort = "desc"
+)
+
+// Defines values for SubmitReviewJSONBodyAction.
+const (
+	APPROVE        SubmitReviewJSONBodyAction = "APPROVE"
+	COMMENT        SubmitReviewJSONBodyAction = "COMMENT"
+	REQUESTCHANGES SubmitReviewJSONBodyAction = "REQUEST_CHANGES"
+)
+
+// APIToken defines model for APIToken.
+type APIToken struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+	Name      string    `json:"name"`
+
+	// OrgID Организация, к которой привязан токен
+	OrgID     string           `json:"org_id,omitempty"`
+	RevokedAt time.Time        `json:"revokedAt,omitempty"`
+	Scopes    []APITokenScopes `json:"scopes"`
+	TokenID   string           `json:"token_id"`
+
+	// UserID Пользователь, от имени которого действует токен
+	UserID string `json:"user_id,omitempty"`
+}
+
+// APITokenScopes defines model for APIToken.Scopes.
+type APITokenScopes string
+
+// AssignmentHistory Сводка истории назначений ревьюверов (только в /pullRequest/get)
+type AssignmentHistory struct {
+	// Assignments Всего назначений за историю PR
+	Assignments int `json:"assignments"`
+
+	// Events Последнее событие каждого ревьювера в хронологическом порядке; полный журнал не возвращается
+	Events []struct {
+		Action     AssignmentHistoryEventsAction `json:"action"`
+		CreatedAt  time.Time                     `json:"createdAt"`
+		ReviewerID string                        `json:"reviewer_id"`
+	} `json:"events"`
+	LastChangeAt time.Time `json:"lastChangeAt,omitempty"`
+
+	// Unassignments Всего снятий за историю PR
+	Unassignments int `json:"unassignments"`
+}
+
+// AssignmentHistoryEventsAction defines model for AssignmentHistory.Events.Action.
+type AssignmentHistoryEventsAction string
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error struct {
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// Details Нарушения по полям тела запроса (только для INVALID_INPUT)
+		Details []struct {
+			Constraint ErrorResponseErrorDetailsConstraint `json:"constraint"`
+			Field      string                              `json:"field"`
+			Message    string                              `json:"message"`
+		} `json:"details,omitempty"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
+type ErrorResponseErrorCode string
+
+// ErrorResponseErrorDetailsConstraint defines model for ErrorResponse.Error.Details.Constraint.
+type ErrorResponseErrorDetailsConstraint string
+
+// MergePolicy Условия, которые должны выполняться перед мерджем PR авторов команды
+type MergePolicy struct {
+	// BlockOnChangesRequested Запрещать мердж, пока есть запрос изменений
+	BlockOnChangesRequested bool `json:"block_on_changes_requested,omitempty"`
+
+	// MinApprovals Минимальное число одобрений, не больше числа назначаемых ревьюверов (2)
+	MinApprovals int `json:"min_approvals,omitempty"`
+
+	// RequireAllApproved Все назначенные ревьюверы должны одобрить PR
+	RequireAllApproved bool `json:"require_all_approved,omitempty"`
+}
+
+// PullRequest defines model for PullRequest.
+type PullRequest struct {
+	// AssignedReviewers user_id назначенных ревьюверов (0..2)
+	AssignedReviewers []string `json:"assigned_reviewers"`
+
+	// AssignmentHistory Сводка истории назначений ревьюверов (только в /pullRequest/get)
+	AssignmentHistory AssignmentHistory `json:"assignment_history,omitempty"`
+	Author            User              `json:"author,omitempty"`
+	AuthorID          string            `json:"author_id"`
+	ClosedAt          time.Time         `json:"closedAt"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	MergedAt          time.Time         `json:"mergedAt"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+
+	// Reviews Состояние ревью каждого назначенного ревьювера
+	Reviews []Review          `json:"reviews,omitempty"`
+	Status  PullRequestStatus `json:"status"`
+
+	// Version Версия PR, увеличивается при каждом изменении статуса, ревьюверов и ревью
+	Version int `json:"version,omitempty"`
+}
+
+// PullRequestStatus defines model for PullRequest.Status.
+type PullRequestStatus string
+
+// Review defines model for Review.
+type Review struct {
+	AssignedAt time.Time `json:"assignedAt"`
+
+	// FirstResponseAt Время первого ответа ревьювера
+	FirstResponseAt time.Time   `json:"firstResponseAt"`
+	PullRequestID   string      `json:"pull_request_id"`
+	ReviewerID      string      `json:"reviewer_id"`
+	State           ReviewState `json:"state"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+}
+
+// ReviewState defines model for Review.State.
+type ReviewState string
+
+// Team defines model for Team.
+type Team struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// TeamMember defines model for TeamMember.
+type TeamMember struct {
+	IsActive bool `json:"is_active"`
+
+	// Role Администраторы команды управляют её участниками и настройками
+	Role     TeamMemberRole `json:"role,omitempty"`
+	UserID   string         `json:"user_id"`
+	Username string         `json:"username"`
+}
+
+// TeamMemberRole Администраторы команды управляют её участниками и настройками
+type TeamMemberRole string
+
+// TeamSettings defines model for TeamSettings.
+type TeamSettings struct {
+	// AutoReassign Переназначать ревьюверов автоматически при эскалации
+	AutoReassign bool `json:"auto_reassign,omitempty"`
+
+	// EscalateAfter Через сколько после создания PR эскалировать (Go duration, "0s" — выключено)
+	EscalateAfter string `json:"escalate_after,omitempty"`
+
+	// MergePolicy Условия, которые должны выполняться перед мерджем PR авторов команды
+	MergePolicy MergePolicy `json:"merge_policy,omitempty"`
+
+	// ReminderAfter Через сколько после создания PR напомнить ревьюверам (Go duration, "0s" — выключено)
+	ReminderAfter string `json:"reminder_after,omitempty"`
+	TeamName      string `json:"team_name"`
+}
+
+// User defines model for User.
+type User struct {
+	IsActive bool     `json:"is_active"`
+	Role     UserRole `json:"role,omitempty"`
+	TeamName string   `json:"team_name"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+}
+
+// UserRole defines model for User.Role.
+type UserRole string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// TeamNameQuery defines model for TeamNameQuery.
+type TeamNameQuery = string
+
+// UserIDQuery defines model for UserIdQuery.
+type UserIDQuery = string
+
+// CreateTokenJSONBody defines parameters for CreateToken.
+type CreateTokenJSONBody struct {
+	// ExpiresIn Срок действия, например 720h; пусто — бессрочный
+	ExpiresIn string                      `json:"expires_in,omitempty"`
+	Name      string                      `json:"name"`
+	Scopes    []CreateTokenJSONBodyScopes `json:"scopes"`
+	UserID    string                      `json:"user_id,omitempty"`
+}
+
+// CreateTokenJSONBodyScopes defines parameters for CreateToken.
+type CreateTokenJSONBodyScopes string
+
+// RevokeTokenJSONBody defines parameters for RevokeToken.
+type RevokeTokenJSONBody struct {
+	TokenID string `json:"token_id"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// UserID Идентификатор пользователя
+	UserID UserIDQuery `form:"user_id" json:"user_id"`
+
+	// LastEventID id последнего полученного сообщения
+	LastEventID string `json:"Last-Event-ID,omitempty"`
+}
+
+// ClosePRJSONBody defines parameters for ClosePR.
+type ClosePRJSONBody struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// ClosePRParams defines parameters for ClosePR.
+type ClosePRParams struct {
+	// IfMatch Версия PR (поле `version`), которую видел клиент: `"3"` или `ETag` из `GET /pullRequest/get`
+	// (`W/"3-<hash>"`, учитывается только версия). Если PR с тех пор изменился, возвращается 409 `CONFLICT`; без заголовка проверка не выполняется.
+	IfMatch IfMatch `json:"If-Match,omitempty"`
+}
+
+// CreatePRJSONBody defines parameters for CreatePR.
+type CreatePRJSONBody struct {
+	AuthorID        string `json:"author_id"`
+	Draft           bool   `json:"draft,omitempty"`
+	PullRequestID   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+}
+
+// CreatePRParams defines parameters for CreatePR.
+type CreatePRParams struct {
+	// IdempotencyKey Ключ повтора запроса. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`), с другим телом — 422 `IDEMPOTENCY_KEY_REUSED`,
+	// пока первый запрос выполняется — 409 `REQUEST_IN_PROGRESS`.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPRParams defines parameters for GetPR.
+type GetPRParams struct {
+	PullRequestID string `form:"pull_request_id" json:"pull_request_id"`
+	IfNoneMatch   string `json:"If-None-Match,omitempty"`
+}
+
+// ListPRsParams defines parameters for ListPRs.
+type ListPRsParams struct {
+	// Status Статусы через запятую, например OPEN,MERGED
+	Status     string `form:"status,omitempty" json:"status,omitempty"`
+	AuthorID   string `form:"author_id,omitempty" json:"author_id,omitempty"`
+	ReviewerID string `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName    string    `form:"team_name,omitempty" json:"team_name,omitempty"`
+	CreatedFrom time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Верхняя граница (не включительно)
+	CreatedTo  time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+	MergedFrom time.Time `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+
+	// MergedTo Верхняя граница (не включительно)
+	MergedTo time.Time         `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+	Sort     ListPRsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Limit    int               `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor   string            `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListPRsParamsSort defines parameters for ListPRs.
+type ListPRsParamsSort string
+
+// MergePRJSONBody defines parameters for MergePR.
+type MergePRJSONBody struct {
+	Force         bool   `json:"force,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// MergePRParams defines parameters for MergePR.
+type MergePRParams struct {
+	// IfMatch Версия PR (поле `version`), которую видел клиент: `"3"` или `ETag` из `GET /pullRequest/get`
+	// (`W/"3-<hash>"`, учитывается только версия). Если PR с тех пор изменился, возвращается 409 `CONFLICT`; без заголовка проверка не выполняется.
+	IfMatch IfMatch `json:"If-Match,omitempty"`
+}
+
+// MarkReadyJSONBody defines parameters for MarkReady.
+type MarkReadyJSONBody struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// MarkReadyParams defines parameters for MarkReady.
+type MarkReadyParams struct {
+	// IfMatch Версия PR (поле `version`), которую видел клиент: `"3"` или `ETag` из `GET /pullRequest/get`
+	// (`W/"3-<hash>"`, учитывается только версия). Если PR с тех пор изменился, возвращается 409 `CONFLICT`; без заголовка проверка не выполняется.
+	IfMatch IfMatch `json:"If-Match,omitempty"`
+}
+
+// ReassignReviewerJSONBody defines parameters for ReassignReviewer.
+type ReassignReviewerJSONBody struct {
+	OldReviewerID string `json:"old_reviewer_id"`
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// ReassignReviewerParams defines parameters for ReassignReviewer.
+type ReassignReviewerParams struct {
+	// IfMatch Версия PR (поле `version`), которую видел клиент: `"3"` или `ETag` из `GET /pullRequest/get`
+	// (`W/"3-<hash>"`, учитывается только версия). Если PR с тех пор изменился, возвращается 409 `CONFLICT`; без заголовка проверка не выполняется.
+	IfMatch IfMatch `json:"If-Match,omitempty"`
+
+	// IdempotencyKey Ключ повтора запроса. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`), с другим телом — 422 `IDEMPOTENCY_KEY_REUSED`,
+	// пока первый запрос выполняется — 409 `REQUEST_IN_PROGRESS`.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ReopenPRJSONBody defines parameters for ReopenPR.
+type ReopenPRJSONBody struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// ReopenPRParams defines parameters for ReopenPR.
+type ReopenPRParams struct {
+	// IfMatch Версия PR (поле `version`), которую видел клиент: `"3"` или `ETag` из `GET /pullRequest/get`
+	// (`W/"3-<hash>"`, учитывается только версия). Если PR с тех пор изменился, возвращается 409 `CONFLICT`; без заголовка проверка не выполняется.
+	IfMatch IfMatch `json:"If-Match,omitempty"`
+}
+
+// SubmitReviewJSONBody defines parameters for SubmitReview.
+type SubmitReviewJSONBody struct {
+	Action        SubmitReviewJSONBodyAction `json:"action"`
+	PullRequestID string                     `json:"pull_request_id"`
+	ReviewerID    string                     `json:"reviewer_id"`
+}
+
+// SubmitReviewParams defines parameters for SubmitReview.
+type SubmitReviewParams struct {
+	// IfMatch Версия PR (поле `version`), которую видел клиент: `"3"` или `ETag` из `GET /pullRequest/get`
+	// (`W/"3-<hash>"`, учитывается только версия). Если PR с тех пор изменился, возвращается 409 `CONFLICT`; без заголовка проверка не выполняется.
+	IfMatch IfMatch `json:"If-Match,omitempty"`
+}
+
+// SubmitReviewJSONBodyAction defines parameters for SubmitReview.
+type SubmitReviewJSONBodyAction string
+
+// GetTeamParams defines parameters for GetTeam.
+type GetTeamParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// GetSettingsParams defines parameters for GetSettings.
+type GetSettingsParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// GetReviewsParams defines parameters for GetReviews.
+type GetReviewsParams struct {
+	// UserID Идентификатор пользователя
+	UserID UserIDQuery `form:"user_id" json:"user_id"`
+
+	// Pending Только PR'ы, на которые пользователь ещё не ответил
+	Pending bool `form:"pending,omitempty" json:"pending,omitempty"`
+
+	// Status Статусы через запятую
+	Status string `form:"status,omitempty" json:"status,omitempty"`
+	Limit  int    `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor из предыдущего ответа
+	Cursor string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// SetIsActiveJSONBody defines parameters for SetIsActive.
+type SetIsActiveJSONBody struct {
+	IsActive *bool  `json:"is_active"`
+	UserID   string `json:"user_id"`
+}
+
+// CreateTokenJSONRequestBody defines body for CreateToken for application/json ContentType.
+type CreateTokenJSONRequestBody CreateTokenJSONBody
+
+// RevokeTokenJSONRequestBody defines body for RevokeToken for application/json ContentType.
+type RevokeTokenJSONRequestBody RevokeTokenJSONBody
+
+// ClosePRJSONRequestBody defines body for ClosePR for application/json ContentType.
+type ClosePRJSONRequestBody ClosePRJSONBody
+
+// CreatePRJSONRequestBody defines body for CreatePR for application/json ContentType.
+type CreatePRJSONRequestBody CreatePRJSONBody
+
+// MergePRJSONRequestBody defines body for MergePR for application/json ContentType.
+type MergePRJSONRequestBody MergePRJSONBody
+
+// MarkReadyJSONRequestBody defines body for MarkReady for application/json ContentType.
+type MarkReadyJSONRequestBody MarkReadyJSONBody
+
+// ReassignReviewerJSONRequestBody defines body for ReassignReviewer for application/json ContentType.
+type ReassignReviewerJSONRequestBody ReassignReviewerJSONBody
+
+// ReopenPRJSONRequestBody defines body for ReopenPR for application/json ContentType.
+type ReopenPRJSONRequestBody ReopenPRJSONBody
+
+// SubmitReviewJSONRequestBody defines body for SubmitReview for application/json ContentType.
+type SubmitReviewJSONRequestBody SubmitReviewJSONBody
+
+// AddTeamJSONRequestBody defines body for AddTeam for application/json ContentType.
+type AddTeamJSONRequestBody = Team
+
+// SetSettingsJSONRequestBody defines body for SetSettings for application/json ContentType.
+type SetSettingsJSONRequestBody = TeamSettings
+
+// SetIsActiveJSONRequestBody defines body for SetIsActive for application/json ContentType.
+type SetIsActiveJSONRequestBody SetIsActiveJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Выпустить API-токен (требуется team:admin)
+	// (POST /admin/tokens/create)
+	CreateToken(w http.ResponseWriter, r *http.Request)
+	// Список API-токенов без секретов (требуется team:admin)
+	// (GET /admin/tokens/list)
+	ListTokens(w http.ResponseWriter, r *http.Request)
+	// Отозвать API-токен (требуется team:admin)
+	// (POST /admin/tokens/revoke)
+	RevokeToken(w http.ResponseWriter, r *http.Request)
+	// Поток событий ревьювера (Server-Sent Events)
+	// (GET /events/stream)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
+	// Закрыть PR без мерджа (идемпотентная операция)
+	// (POST /pullRequest/close)
+	ClosePR(w http.ResponseWriter, r *http.Request, params ClosePRParams)
+	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// (POST /pullRequest/create)
+	CreatePR(w http.ResponseWriter, r *http.Request, params CreatePRParams)
+	// Получить PR с ревьюверами и историей назначений
+	// (GET /pullRequest/get)
+	GetPR(w http.ResponseWriter, r *http.Request, params GetPRParams)
+	// Список PR с фильтрами и курсорной пагинацией
+	// (GET /pullRequest/list)
+	ListPRs(w http.ResponseWriter, r *http.Request, params ListPRsParams)
+	// Пометить PR как MERGED (идемпотентная операция)
+	// (POST /pullRequest/merge)
+	MergePR(w http.ResponseWriter, r *http.Request, params MergePRParams)
+	// Перевести DRAFT в OPEN и назначить ревьюверов
+	// (POST /pullRequest/ready)
+	MarkReady(w http.ResponseWriter, r *http.Request, params MarkReadyParams)
+	// Переназначить конкретного ревьювера на другого из его команды
+	// (POST /pullRequest/reassign)
+	ReassignReviewer(w http.ResponseWriter, r *http.Request, params ReassignReviewerParams)
+	// Переоткрыть закрытый PR
+	// (POST /pullRequest/reopen)
+	ReopenPR(w http.ResponseWriter, r *http.Request, params ReopenPRParams)
+	// Оставить ревью (одобрить, запросить изменения или прокомментировать)
+	// (POST /pullRequest/review)
+	SubmitReview(w http.ResponseWriter, r *http.Request, params SubmitReviewParams)
+	// Создать команду с участниками (создаёт/обновляет пользователей)
+	// (POST /team/add)
+	AddTeam(w http.ResponseWriter, r *http.Request)
+	// Получить команду с участниками
+	// (GET /team/get)
+	GetTeam(w http.ResponseWriter, r *http.Request, params GetTeamParams)
+	// Получить настройки SLA ревью команды
+	// (GET /team/getSettings)
+	GetSettings(w http.ResponseWriter, r *http.Request, params GetSettingsParams)
+	// Задать настройки SLA ревью команды
+	// (POST /team/setSettings)
+	SetSettings(w http.ResponseWriter, r *http.Request)
+	// Получить PR'ы, где пользователь назначен ревьювером
+	// (GET /users/getReview)
+	GetReviews(w http.ResponseWriter, r *http.Request, params GetReviewsParams)
+	// Установить флаг активности пользователя
+	// (POST /users/setIsActive)
+	SetIsActive(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Выпустить API-токен (требуется team:admin)
+// (POST /admin/tokens/create)
+func (_ Unimplemented) CreateToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список API-токенов без секретов (требуется team:admin)
+// (GET /admin/tokens/list)
+func (_ Unimplemented) ListTokens(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать API-токен (требуется team:admin)
+// (POST /admin/tokens/revoke)
+func (_ Unimplemented) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Поток событий ревьювера (Server-Sent Events)
+// (GET /events/stream)
+func (_ Unimplemented) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Закрыть PR без мерджа (идемпотентная операция)
+// (POST /pullRequest/close)
+func (_ Unimplemented) ClosePR(w http.ResponseWriter, r *http.Request, params ClosePRParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// (POST /pullRequest/create)
+func (_ Unimplemented) CreatePR(w http.ResponseWriter, r *http.Request, params CreatePRParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить PR с ревьюверами и историей назначений
+// (GET /pullRequest/get)
+func (_ Unimplemented) GetPR(w http.ResponseWriter, r *http.Request, params GetPRParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список PR с фильтрами и курсорной пагинацией
+// (GET /pullRequest/list)
+func (_ Unimplemented) ListPRs(w http.ResponseWriter, r *http.Request, params ListPRsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Пометить PR как MERGED (идемпотентная операция)
+// (POST /pullRequest/merge)
+func (_ Unimplemented) MergePR(w http.ResponseWriter, r *http.Request, params MergePRParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перевести DRAFT в OPEN и назначить ревьюверов
+// (POST /pullRequest/ready)
+func (_ Unimplemented) MarkReady(w http.ResponseWriter, r *http.Request, params MarkReadyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переназначить конкретного ревьювера на другого из его команды
+// (POST /pullRequest/reassign)
+func (_ Unimplemented) ReassignReviewer(w http.ResponseWriter, r *http.Request, params ReassignReviewerParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переоткрыть закрытый PR
+// (POST /pullRequest/reopen)
+func (_ Unimplemented) ReopenPR(w http.ResponseWriter, r *http.Request, params ReopenPRParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Оставить ревью (одобрить, запросить изменения или прокомментировать)
+// (POST /pullRequest/review)
+func (_ Unimplemented) SubmitReview(w http.ResponseWriter, r *http.Request, params SubmitReviewParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать команду с участниками (создаёт/обновляет пользователей)
+// (POST /team/add)
+func (_ Unimplemented) AddTeam(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить команду с участниками
+// (GET /team/get)
+func (_ Unimplemented) GetTeam(w http.ResponseWriter, r *http.Request, params GetTeamParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить настройки SLA ревью команды
+// (GET /team/getSettings)
+func (_ Unimplemented) GetSettings(w http.ResponseWriter, r *http.Request, params GetSettingsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Задать настройки SLA ревью команды
+// (POST /team/setSettings)
+func (_ Unimplemented) SetSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить PR'ы, где пользователь назначен ревьювером
+// (GET /users/getReview)
+func (_ Unimplemented) GetReviews(w http.ResponseWriter, r *http.Request, params GetReviewsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Установить флаг активности пользователя
+// (POST /users/setIsActive)
+func (_ Unimplemented) SetIsActive(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// CreateToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTokens operation middleware
+func (siw *ServerInterfaceWrapper) ListTokens(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClosePR operation middleware
+func (siw *ServerInterfaceWrapper) ClosePR(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ClosePRParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClosePR(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePR operation middleware
+func (siw *ServerInterfaceWrapper) CreatePR(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreatePRParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePR(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPR operation middleware
+func (siw *ServerInterfaceWrapper) GetPR(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPRParams
+
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	if paramValue := r.URL.Query().Get("pull_request_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pull_request_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", r.URL.Query(), &params.PullRequestID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pull_request_id", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPR(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPRs operation middleware
+func (siw *ServerInterfaceWrapper) ListPRs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPRsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author_id", r.URL.Query(), &params.AuthorID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewer_id", r.URL.Query(), &params.ReviewerID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_from", r.URL.Query(), &params.MergedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_to", r.URL.Query(), &params.MergedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPRs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergePR operation middleware
+func (siw *ServerInterfaceWrapper) MergePR(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MergePRParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergePR(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MarkReady operation middleware
+func (siw *ServerInterfaceWrapper) MarkReady(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MarkReadyParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkReady(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReassignReviewer operation middleware
+func (siw *ServerInterfaceWrapper) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReassignReviewerParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = IfMatch
+
+	}
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReassignReviewer(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReopenPR operation middleware
+func (siw *ServerInterfaceWrapper) ReopenPR(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReopenPRParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReopenPR(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SubmitReview operation middleware
+func (siw *ServerInterfaceWrapper) SubmitReview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SubmitReviewParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubmitReview(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddTeam operation middleware
+func (siw *ServerInterfaceWrapper) AddTeam(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddTeam(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeam operation middleware
+func (siw *ServerInterfaceWrapper) GetTeam(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeam(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetSettings(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSettingsParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSettings(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetSettings operation middleware
+func (siw *ServerInterfaceWrapper) SetSettings(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetReviews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pending" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pending", r.URL.Query(), &params.Pending)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pending", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReviews(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetIsActive operation middleware
+func (siw *ServerInterfaceWrapper) SetIsActive(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetIsActive(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/tokens/create", wrapper.CreateToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/tokens/list", wrapper.ListTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/tokens/revoke", wrapper.RevokeToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events/stream", wrapper.StreamEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/close", wrapper.ClosePR)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/create", wrapper.CreatePR)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/get", wrapper.GetPR)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/list", wrapper.ListPRs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/merge", wrapper.MergePR)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/ready", wrapper.MarkReady)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/reassign", wrapper.ReassignReviewer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/reopen", wrapper.ReopenPR)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/review", wrapper.SubmitReview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/add", wrapper.AddTeam)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/team/get", wrapper.GetTeam)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/team/getSettings", wrapper.GetSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/setSettings", wrapper.SetSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/getReview", wrapper.GetReviews)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/setIsActive", wrapper.SetIsActive)
+	})
+
+	return r
+}
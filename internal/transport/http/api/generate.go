@@ -0,0 +1,4 @@
+// Package api holds the server interface and models generated from openapi.yml
+package api
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.1 -config oapi-codegen.yaml ../../../../openapi.yml
@@ -0,0 +1,122 @@
+// Package handler processes incoming http requests
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/api"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
+)
+
+// eventReset tells a resuming client that events were lost and its state must be reloaded
+const eventReset = "RESET"
+
+type EventHandler struct {
+	eventService services.EventService
+	heartbeat    time.Duration
+}
+
+// NewEventHandler creates a new event handler, idle streams get a comment every heartbeat
+func NewEventHandler(service services.EventService, heartbeat time.Duration) *EventHandler {
+	return &EventHandler{eventService: service, heartbeat: heartbeat}
+}
+
+// StreamEvents streams assignment updates of a user as server-sent events
+// until the client disconnects
+func (h *EventHandler) StreamEvents(w http.ResponseWriter, r *http.Request, params api.StreamEventsParams) {
+	if params.UserID == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id query parameter is required")
+		return
+	}
+
+	var lastEventID uint64
+	if params.LastEventID != "" {
+		var err error
+		if lastEventID, err = strconv.ParseUint(params.LastEventID, 10, 64); err != nil {
+			respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "Last-Event-ID must be the id of a received event")
+			return
+		}
+	}
+
+	sub, err := h.eventService.Subscribe(r.Context(), params.UserID, lastEventID)
+	if err != nil {
+		slog.Error("Failed to subscribe to events", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+	defer sub.Close()
+
+	// the stream outlives the write timeout of the server
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.Error("Failed to clear write deadline of event stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx would hold the events back otherwise
+	w.WriteHeader(http.StatusOK)
+
+	// replay what the client missed, a gap makes it reload instead
+	if sub.Gap {
+		fmt.Fprintf(w, "id: %d\nevent: %s\ndata: {}\n\n", sub.LastID, eventReset)
+	} else {
+		for _, e := range sub.Missed {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	var heartbeat <-chan time.Time
+	if h.heartbeat > 0 {
+		ticker := time.NewTicker(h.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.Events:
+			// closed when the client falls behind, it resumes with Last-Event-ID
+			if !ok {
+				return
+			}
+			err = writeEvent(w, e)
+		case <-heartbeat:
+			_, err = io.WriteString(w, ": ping\n\n")
+		}
+
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes a single server-sent event message
+func writeEvent(w io.Writer, e entity.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
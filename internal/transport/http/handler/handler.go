@@ -3,59 +3,15 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
+	"strings"
 
-	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
 )
 
-// ErrorResponse standardizes error response structure
-type ErrorResponse struct {
-	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-	} `json:"error"`
-}
-
-// MapDomainErrorToHTTPCode translates domain errors to http status codes
-func MapDomainErrorToHTTPCode(err error) (int, string, string) {
-	if errors.Is(err, entity.ErrNotFound) {
-		return http.StatusNotFound, "NOT_FOUND", err.Error()
-	}
-	if errors.Is(err, entity.ErrTeamExists) {
-		return http.StatusConflict, "TEAM_EXISTS", err.Error()
-	}
-	if errors.Is(err, entity.ErrPRMerged) {
-		return http.StatusConflict, "PR_MERGED", err.Error()
-	}
-	if errors.Is(err, entity.ErrNotAssigned) {
-		return http.StatusConflict, "NOT_ASSIGNED", err.Error()
-	}
-	if errors.Is(err, entity.ErrNoCandidate) {
-		return http.StatusNotFound, "NO_CANDIDATE", err.Error()
-	}
-	if errors.Is(err, entity.ErrPRExists) {
-		return http.StatusConflict, "PR_EXISTS", err.Error()
-	}
-	return http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error"
-}
-
 // respondWithError sends a json formatted error response
 func respondWithError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	resp := ErrorResponse{
-		Error: struct {
-			Code    string `json:"code"`
-			Message string `json:"message"`
-		}{
-			Code:    code,
-			Message: message,
-		},
-	}
-
-	_ = json.NewEncoder(w).Encode(resp)
+	httperr.Write(w, status, code, message, nil)
 }
 
 // respondWithJSON sends a successful json response
@@ -64,3 +20,33 @@ func respondWithJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(data)
 }
+
+// respondWithETag sends a json response tagged with etag,
+// clients sending a matching If-None-Match get 304 without the body
+func respondWithETag(w http.ResponseWriter, r *http.Request, status int, etag string, data interface{}) {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	respondWithJSON(w, status, data)
+}
+
+// etagMatches checks an If-None-Match header value against the current etag,
+// weak and strong forms of the same tag match like the weak comparison of rfc 9110
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// Package handler processes incoming http requests
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// parseStatuses parses a comma separated list of pr statuses
+func parseStatuses(value string) ([]entity.PRStatus, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	statuses := make([]entity.PRStatus, 0, len(parts))
+	for _, p := range parts {
+		st := entity.PRStatus(strings.ToUpper(strings.TrimSpace(p)))
+		if !st.IsValid() {
+			return nil, errors.New("status must be a comma separated list of DRAFT, OPEN, MERGED, CLOSED")
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// optionalTime turns an omitted timestamp parameter into nil
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// checkLimit validates an optional page size, zero means the default
+func checkLimit(limit int) (int, error) {
+	if limit < 0 {
+		return 0, errors.New("limit must be a positive integer")
+	}
+	return limit, nil
+}
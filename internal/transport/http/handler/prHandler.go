@@ -2,12 +2,21 @@
 package handler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/cursor"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/api"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
 )
 
 type PRHandler struct {
@@ -19,36 +28,30 @@ func NewPRHandler(prService services.PRService) *PRHandler {
 	return &PRHandler{prService: prService}
 }
 
-type CreatePRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-}
-
-type ReassignReviewerRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldReviewerID string `json:"old_reviewer_id"`
-}
-
-type PRIDRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-}
+// request bodies are generated from openapi.yml
+type (
+	CreatePRRequest         api.CreatePRJSONRequestBody
+	ReassignReviewerRequest api.ReassignReviewerJSONRequestBody
+	MergePRRequest          api.MergePRJSONRequestBody
+	SubmitReviewRequest     api.SubmitReviewJSONRequestBody
+	PRIDRequest             api.MarkReadyJSONRequestBody // ready, close and reopen share the body
+)
 
-// CreatePR processes request to create a new pull request
-func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
+// CreatePR processes request to create a new pull request,
+// the idempotency key is handled by the middleware
+func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request, _ api.CreatePRParams) {
 	var req CreatePRRequest
 	// decode request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid JSON body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
 	// call service to create pr and assign reviewers
-	pr, err := h.prService.Create(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.prService.Create(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID, req.Draft)
 
 	if err != nil {
 		slog.Error("Failed to create PR", "error", err)
-		status, code, msg := MapDomainErrorToHTTPCode(err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
 		respondWithError(w, status, code, msg)
 		return
 	}
@@ -57,46 +60,56 @@ func (h *PRHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 }
 
 // MergePR handles request to mark a pr as merged
-func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request) {
-	var req PRIDRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid JSON body")
+func (h *PRHandler) MergePR(w http.ResponseWriter, r *http.Request, params api.MergePRParams) {
+	var req MergePRRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	version, err := parseIfMatch(params.IfMatch)
+	if err != nil {
+		httperr.WriteDomain(w, err)
 		return
 	}
 
-	// update pr status in service
-	pr, err := h.prService.Merge(r.Context(), req.PullRequestID)
+	// update pr status in service, force bypasses the team merge policy
+	pr, err := h.prService.Merge(r.Context(), req.PullRequestID, req.Force, version)
 
 	if err != nil {
 		slog.Error("Failed to merge PR", "error", err)
-		status, code, msg := MapDomainErrorToHTTPCode(err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
 		respondWithError(w, status, code, msg)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, pr)
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
 }
 
 // ReassignReviewer processes request to change a reviewer
-func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
+func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request, params api.ReassignReviewerParams) {
 	var req ReassignReviewerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid JSON body")
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	version, err := parseIfMatch(params.IfMatch)
+	if err != nil {
+		httperr.WriteDomain(w, err)
 		return
 	}
 
 	// find new reviewer and replace the old one
-	pr, newReviewerID, err := h.prService.Reassign(r.Context(), req.PullRequestID, req.OldReviewerID)
+	pr, newReviewerID, err := h.prService.Reassign(r.Context(), req.PullRequestID, req.OldReviewerID, version)
 
 	if err != nil {
 		slog.Error("Failed to reassign reviewer", "error", err)
-		status, code, msg := MapDomainErrorToHTTPCode(err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
 		respondWithError(w, status, code, msg)
 		return
 	}
 
 	resp := struct {
-		PullRequest   *entity.PullRequest `json:"pull_request"`
+		PullRequest   *entity.PullRequest `json:"pr"`
 		NewReviewerID string              `json:"replaced_by"`
 	}{
 		PullRequest:   pr,
@@ -105,3 +118,186 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 
 	respondWithJSON(w, http.StatusOK, resp)
 }
+
+// SubmitReview records a reviewer's decision on a pull request
+func (h *PRHandler) SubmitReview(w http.ResponseWriter, r *http.Request, params api.SubmitReviewParams) {
+	var req SubmitReviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	version, err := parseIfMatch(params.IfMatch)
+	if err != nil {
+		httperr.WriteDomain(w, err)
+		return
+	}
+
+	// store the new review state
+	review, err := h.prService.SubmitReview(r.Context(), req.PullRequestID, req.ReviewerID, entity.ReviewAction(req.Action), version)
+
+	if err != nil {
+		slog.Error("Failed to submit review", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"review": review})
+}
+
+// MarkReady handles request to move a draft pr to open and assign reviewers
+func (h *PRHandler) MarkReady(w http.ResponseWriter, r *http.Request, params api.MarkReadyParams) {
+	h.changeStatus(w, r, params.IfMatch, h.prService.MarkReady, "Failed to mark PR ready")
+}
+
+// ClosePR handles request to close a pr without merging
+func (h *PRHandler) ClosePR(w http.ResponseWriter, r *http.Request, params api.ClosePRParams) {
+	h.changeStatus(w, r, params.IfMatch, h.prService.Close, "Failed to close PR")
+}
+
+// ReopenPR handles request to reopen a closed pr
+func (h *PRHandler) ReopenPR(w http.ResponseWriter, r *http.Request, params api.ReopenPRParams) {
+	h.changeStatus(w, r, params.IfMatch, h.prService.Reopen, "Failed to reopen PR")
+}
+
+// changeStatus decodes the pr id and applies a lifecycle operation to it
+func (h *PRHandler) changeStatus(w http.ResponseWriter, r *http.Request, ifMatch string, op func(ctx context.Context, prID string, expectedVersion int) (*entity.PullRequest, error), logMsg string) {
+	var req PRIDRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	version, err := parseIfMatch(ifMatch)
+	if err != nil {
+		httperr.WriteDomain(w, err)
+		return
+	}
+
+	pr, err := op(r.Context(), req.PullRequestID, version)
+
+	if err != nil {
+		slog.Error(logMsg, "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
+}
+
+// prETag tags a pr response by its version and a hash of the body, the embedded author
+// and history change without a new version but still change the tag
+func prETag(version int, body interface{}) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return `W/"` + strconv.Itoa(version) + `"`
+	}
+	sum := sha256.Sum256(data)
+	return `W/"` + strconv.Itoa(version) + "-" + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// parseIfMatch reads the pr version the client expects from the If-Match header,
+// either a bare "<version>" or the etag of GET /pullRequest/get whose body hash is ignored,
+// a missing header returns zero and disables the check
+func parseIfMatch(header string) (int, error) {
+	raw := strings.TrimSpace(header)
+	if raw == "" {
+		return 0, nil
+	}
+
+	raw = strings.Trim(strings.TrimPrefix(raw, "W/"), `"`)
+	raw, _, _ = strings.Cut(raw, "-")
+	version, err := strconv.Atoi(raw)
+	if err != nil || version <= 0 {
+		return 0, entity.ErrInvalidVersion
+	}
+	return version, nil
+}
+
+// ListPRResponse is a page of pull requests with the cursor of the next page
+type ListPRResponse struct {
+	PullRequests []*entity.PullRequest `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// ListPRs returns a filtered page of pull requests
+func (h *PRHandler) ListPRs(w http.ResponseWriter, r *http.Request, params api.ListPRsParams) {
+	filter, err := parsePRFilter(params)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		return
+	}
+
+	page, err := h.prService.List(r.Context(), filter)
+
+	if err != nil {
+		slog.Error("Failed to list PRs", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, ListPRResponse{
+		PullRequests: page.Items,
+		NextCursor:   cursor.Encode(page.Next),
+	})
+}
+
+// parsePRFilter builds a listing filter from query parameters,
+// timestamps and the limit are already parsed by the generated wrapper
+func parsePRFilter(params api.ListPRsParams) (entity.PRFilter, error) {
+	filter := entity.PRFilter{
+		AuthorID:    params.AuthorID,
+		ReviewerID:  params.ReviewerID,
+		TeamName:    params.TeamName,
+		CreatedFrom: optionalTime(params.CreatedFrom),
+		CreatedTo:   optionalTime(params.CreatedTo),
+		MergedFrom:  optionalTime(params.MergedFrom),
+		MergedTo:    optionalTime(params.MergedTo),
+		Desc:        true, // newest first by default
+	}
+
+	var err error
+	if filter.Statuses, err = parseStatuses(params.Status); err != nil {
+		return filter, err
+	}
+	if filter.Limit, err = checkLimit(params.Limit); err != nil {
+		return filter, err
+	}
+	if filter.After, err = cursor.Decode(params.Cursor); err != nil {
+		return filter, err
+	}
+
+	switch params.Sort {
+	case "", api.Desc:
+	case api.Asc:
+		filter.Desc = false
+	default:
+		return filter, errors.New("sort must be asc or desc")
+	}
+
+	return filter, nil
+}
+
+// GetPR returns a single pull request with reviewers and assignment history
+func (h *PRHandler) GetPR(w http.ResponseWriter, r *http.Request, params api.GetPRParams) {
+	prID := params.PullRequestID
+	if prID == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "pull_request_id query parameter is required")
+		return
+	}
+
+	pr, err := h.prService.Get(r.Context(), prID)
+
+	if err != nil {
+		slog.Error("Failed to get PR", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	// etag lets dashboards poll without downloading unchanged prs,
+	// the same value is accepted by If-Match of the write operations
+	body := map[string]interface{}{"pr": pr}
+	respondWithETag(w, r, http.StatusOK, prETag(pr.Version, body), body)
+}
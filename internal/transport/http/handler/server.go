@@ -0,0 +1,57 @@
+// Package handler processes incoming http requests
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/api"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/validate"
+)
+
+// Server combines all handlers into the interface generated from openapi.yml
+type Server struct {
+	*TeamHandler
+	*UserHandler
+	*PRHandler
+	*TokenHandler
+	*EventHandler
+}
+
+// the build fails here when openapi.yml gets an operation without a handler
+var _ api.ServerInterface = (*Server)(nil)
+
+// NewServer creates a server from the resource handlers
+func NewServer(team *TeamHandler, user *UserHandler, pr *PRHandler, token *TokenHandler, event *EventHandler) *Server {
+	return &Server{TeamHandler: team, UserHandler: user, PRHandler: pr, TokenHandler: token, EventHandler: event}
+}
+
+// RespondWithParamError sends 400 for query and header parameters
+// the generated wrapper failed to bind
+func RespondWithParamError(w http.ResponseWriter, _ *http.Request, err error) {
+	var (
+		required     *api.RequiredParamError
+		requiredHdr  *api.RequiredHeaderError
+		invalid      *api.InvalidParamFormatError
+		tooMany      *api.TooManyValuesForParamError
+		unmarshaling *api.UnmarshalingParamError
+	)
+
+	var v validate.Violations
+	switch {
+	case errors.As(err, &required):
+		v.Add(required.ParamName, "required", required.ParamName+" query parameter is required")
+	case errors.As(err, &requiredHdr):
+		v.Add(requiredHdr.ParamName, "required", requiredHdr.ParamName+" header is required")
+	case errors.As(err, &invalid):
+		v.Add(invalid.ParamName, "type", invalid.ParamName+" has invalid format")
+	case errors.As(err, &tooMany):
+		v.Add(tooMany.ParamName, "type", tooMany.ParamName+" must be passed once")
+	case errors.As(err, &unmarshaling):
+		v.Add(unmarshaling.ParamName, "type", unmarshaling.ParamName+" has invalid format")
+	default:
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		return
+	}
+	respondWithViolations(w, v)
+}
@@ -2,21 +2,45 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/api"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/validate"
 )
 
-type AddTeamRequest struct {
-	TeamName string `json:"team_name"`
-	Members  []struct {
-		UserID   string `json:"user_id"`
-		Username string `json:"username"`
-		IsActive bool   `json:"is_active"`
-	} `json:"members"`
+// request bodies are generated from openapi.yml
+type (
+	AddTeamRequest     api.AddTeamJSONRequestBody
+	SetSettingsRequest api.SetSettingsJSONRequestBody
+)
+
+// TeamDTO represents a team with its members
+type TeamDTO struct {
+	TeamName string          `json:"team_name"`
+	Members  []TeamMemberDTO `json:"members"`
+}
+
+// TeamMemberDTO represents a team member without the team name
+type TeamMemberDTO struct {
+	UserID   string      `json:"user_id"`
+	Username string      `json:"username"`
+	IsActive bool        `json:"is_active"`
+	Role     entity.Role `json:"role"`
+}
+
+// TeamSettingsDTO represents team settings with human readable durations (e.g. "24h")
+type TeamSettingsDTO struct {
+	TeamName      string             `json:"team_name"`
+	ReminderAfter string             `json:"reminder_after"`
+	EscalateAfter string             `json:"escalate_after"`
+	AutoReassign  bool               `json:"auto_reassign"`
+	MergePolicy   entity.MergePolicy `json:"merge_policy"`
 }
 
 type TeamHandler struct {
@@ -32,9 +56,8 @@ func NewTeamHandler(service services.TeamService) *TeamHandler {
 func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 	var req AddTeamRequest
 
-	// parse json body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid JSON body")
+	// parse and validate json body
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -42,11 +65,16 @@ func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 	teamEntity := &entity.Team{Name: req.TeamName}
 	var userEntities []*entity.User
 	for _, m := range req.Members {
+		role := entity.Role(m.Role)
+		if role == "" {
+			role = entity.RoleMember
+		}
 		userEntities = append(userEntities, &entity.User{
 			ID:       m.UserID,
 			Username: m.Username,
 			TeamName: req.TeamName,
 			IsActive: m.IsActive,
+			Role:     role,
 		})
 	}
 
@@ -55,15 +83,132 @@ func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		slog.Error("Failed to create team", "error", err)
-		status, code, msg := MapDomainErrorToHTTPCode(err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
 		respondWithError(w, status, code, msg)
 		return
 	}
 
 	resp := struct {
-		Team *entity.Team `json:"team"`
+		Team TeamDTO `json:"team"`
 	}{
-		Team: teamEntity,
+		Team: toTeamDTO(teamEntity, userEntities),
 	}
 	respondWithJSON(w, http.StatusCreated, resp)
 }
+
+// GetTeam returns a team with all its members
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request, params api.GetTeamParams) {
+	teamName := params.TeamName
+	if teamName == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name query parameter is required")
+		return
+	}
+
+	team, members, err := h.teamService.GetTeam(r.Context(), teamName)
+	if err != nil {
+		slog.Error("Failed to get team", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toTeamDTO(team, members))
+}
+
+// GetSettings returns review sla and merge policy settings of a team
+func (h *TeamHandler) GetSettings(w http.ResponseWriter, r *http.Request, params api.GetSettingsParams) {
+	teamName := params.TeamName
+	if teamName == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "team_name query parameter is required")
+		return
+	}
+
+	settings, err := h.teamService.GetSettings(r.Context(), teamName)
+	if err != nil {
+		slog.Error("Failed to get team settings", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toTeamSettingsDTO(settings))
+}
+
+// SetSettings replaces review settings of a team
+func (h *TeamHandler) SetSettings(w http.ResponseWriter, r *http.Request) {
+	var req SetSettingsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	// durations are already checked by Validate, empty value disables the check
+	reminderAfter, _ := parseSettingsDuration(req.ReminderAfter)
+	escalateAfter, _ := parseSettingsDuration(req.EscalateAfter)
+
+	settings, err := h.teamService.UpdateSettings(r.Context(), &entity.TeamSettings{
+		TeamName:      req.TeamName,
+		ReminderAfter: reminderAfter,
+		EscalateAfter: escalateAfter,
+		AutoReassign:  req.AutoReassign,
+		MergePolicy: entity.MergePolicy{
+			MinApprovals:            req.MergePolicy.MinApprovals,
+			RequireAllApproved:      req.MergePolicy.RequireAllApproved,
+			BlockOnChangesRequested: req.MergePolicy.BlockOnChangesRequested,
+		},
+	})
+	var invalid *entity.InvalidSettingsError
+	if errors.As(err, &invalid) {
+		respondWithViolations(w, settingsViolations(invalid))
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to update team settings", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, toTeamSettingsDTO(settings))
+}
+
+// parseSettingsDuration parses an optional duration, its sign is checked by the use case
+func parseSettingsDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// settingsViolations lists the broken settings invariants as request field violations
+func settingsViolations(err *entity.InvalidSettingsError) validate.Violations {
+	var v validate.Violations
+	for _, violation := range err.Violations {
+		v.Add(violation.Field, "range", violation.Err.Error())
+	}
+	return v
+}
+
+// toTeamDTO converts a domain team and its members to the api representation
+func toTeamDTO(team *entity.Team, members []*entity.User) TeamDTO {
+	dto := TeamDTO{TeamName: team.Name, Members: make([]TeamMemberDTO, 0, len(members))}
+	for _, m := range members {
+		dto.Members = append(dto.Members, TeamMemberDTO{
+			UserID:   m.ID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+			Role:     m.Role,
+		})
+	}
+	return dto
+}
+
+// toTeamSettingsDTO converts domain settings to the api representation
+func toTeamSettingsDTO(settings *entity.TeamSettings) TeamSettingsDTO {
+	return TeamSettingsDTO{
+		TeamName:      settings.TeamName,
+		ReminderAfter: settings.ReminderAfter.String(),
+		EscalateAfter: settings.EscalateAfter.String(),
+		AutoReassign:  settings.AutoReassign,
+		MergePolicy:   settings.MergePolicy,
+	}
+}
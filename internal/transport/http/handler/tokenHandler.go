@@ -0,0 +1,108 @@
+// Package handler processes incoming http requests
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/api"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
+)
+
+// request bodies are generated from openapi.yml
+type (
+	CreateTokenRequest api.CreateTokenJSONRequestBody
+	RevokeTokenRequest api.RevokeTokenJSONRequestBody
+)
+
+type TokenHandler struct {
+	tokenService services.TokenService
+}
+
+// NewTokenHandler creates a new token handler instance
+func NewTokenHandler(service services.TokenService) *TokenHandler {
+	return &TokenHandler{tokenService: service}
+}
+
+// CreateToken mints a new api token, the secret is returned only in this response
+func (h *TokenHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	var req CreateTokenRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "name is required")
+		return
+	}
+
+	ttl, err := parseSettingsDuration(req.ExpiresIn)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "expires_in must be a non-negative duration like 720h")
+		return
+	}
+
+	scopes := make([]entity.Scope, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		scopes = append(scopes, entity.Scope(s))
+	}
+
+	token, secret, err := h.tokenService.Mint(r.Context(), req.Name, req.UserID, scopes, ttl)
+	if err != nil {
+		slog.Error("Failed to create token", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	resp := struct {
+		Token  *entity.APIToken `json:"token"`
+		Secret string           `json:"secret"`
+	}{
+		Token:  token,
+		Secret: secret,
+	}
+	respondWithJSON(w, http.StatusCreated, resp)
+}
+
+// ListTokens returns all issued tokens without their secrets
+func (h *TokenHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.tokenService.List(r.Context())
+	if err != nil {
+		slog.Error("Failed to list tokens", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	resp := struct {
+		Tokens []*entity.APIToken `json:"tokens"`
+	}{
+		Tokens: tokens,
+	}
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// RevokeToken disables a token permanently
+func (h *TokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	var req RevokeTokenRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	token, err := h.tokenService.Revoke(r.Context(), req.TokenID)
+	if err != nil {
+		slog.Error("Failed to revoke token", "error", err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
+		respondWithError(w, status, code, msg)
+		return
+	}
+
+	resp := struct {
+		Token *entity.APIToken `json:"token"`
+	}{
+		Token: token,
+	}
+	respondWithJSON(w, http.StatusOK, resp)
+}
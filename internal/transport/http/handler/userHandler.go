@@ -2,25 +2,26 @@
 package handler
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/services"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/cursor"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/api"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
 )
 
 type UserHandler struct {
 	userService services.UserService
 }
 
-type SetIsActiveRequest struct {
-	UserID   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
-}
+type SetIsActiveRequest api.SetIsActiveJSONRequestBody
 
 type GetReviewResponse struct {
-	UserID       string      `json:"user_id"`
-	PullRequests interface{} `json:"pull_requests"`
+	UserID       string                `json:"user_id"`
+	PullRequests []*entity.PullRequest `json:"pull_requests"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
 }
 
 // NewUserHandler initializes new user handler
@@ -31,46 +32,63 @@ func NewUserHandler(userService services.UserService) *UserHandler {
 // SetIsActive updates the active status of a user
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	var req SetIsActiveRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid JSON body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
 	// call service to update status
-	user, err := h.userService.SetIsActive(r.Context(), req.UserID, req.IsActive)
+	user, err := h.userService.SetIsActive(r.Context(), req.UserID, *req.IsActive)
 
 	if err != nil {
 		slog.Error("Failed to set user active status", "error", err)
-		status, code, msg := MapDomainErrorToHTTPCode(err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
 		respondWithError(w, status, code, msg)
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, user)
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"user": user})
 }
 
-// GetReviews retrieves all reviews assigned to a specific user
-func (h *UserHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
-	// extract user id from query parameters
-	userID := r.URL.Query().Get("user_id")
+// GetReviews retrieves a page of reviews assigned to a specific user
+func (h *UserHandler) GetReviews(w http.ResponseWriter, r *http.Request, params api.GetReviewsParams) {
+	userID := params.UserID
 	if userID == "" {
 		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "user_id query parameter is required")
 		return
 	}
 
+	// pending limits the page to reviews the user has not responded to yet
+	filter := entity.PRFilter{Desc: true, OnlyPending: params.Pending}
+	var err error
+
+	// status defaults to OPEN in the service
+	if filter.Statuses, err = parseStatuses(params.Status); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		return
+	}
+	if filter.Limit, err = checkLimit(params.Limit); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		return
+	}
+	if filter.After, err = cursor.Decode(params.Cursor); err != nil {
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", err.Error())
+		return
+	}
+
 	// fetch reviews from service
-	prs, err := h.userService.GetReviews(r.Context(), userID)
+	page, err := h.userService.GetReviews(r.Context(), userID, filter)
 
 	if err != nil {
 		slog.Error("Failed to get reviews for user", "error", err)
-		status, code, msg := MapDomainErrorToHTTPCode(err)
+		status, code, msg := httperr.MapDomainErrorToHTTPCode(err)
 		respondWithError(w, status, code, msg)
 		return
 	}
 
 	resp := GetReviewResponse{
 		UserID:       userID,
-		PullRequests: prs,
+		PullRequests: page.Items,
+		NextCursor:   cursor.Encode(page.Next),
 	}
 
 	respondWithJSON(w, http.StatusOK, resp)
@@ -0,0 +1,163 @@
+// Package handler processes incoming http requests
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/validate"
+)
+
+// validatable is implemented by request bodies checked after decoding
+type validatable interface {
+	Validate() validate.Violations
+}
+
+// decodeJSON decodes a request body rejecting unknown fields and validates it,
+// on failure the 400 response is already written and false is returned
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		if v, ok := decodeViolation(err); ok {
+			respondWithViolations(w, validate.Violations{v})
+			return false
+		}
+		respondWithError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid JSON body")
+		return false
+	}
+
+	if req, ok := dst.(validatable); ok {
+		if details := req.Validate(); len(details) > 0 {
+			respondWithViolations(w, details)
+			return false
+		}
+	}
+	return true
+}
+
+// decodeViolation turns unknown field and type mismatch errors into a field violation
+func decodeViolation(err error) (validate.Violation, bool) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return validate.Violation{
+			Field:      typeErr.Field,
+			Constraint: "type",
+			Message:    fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type),
+		}, true
+	}
+
+	// encoding/json has no typed error for unknown fields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		field = strings.Trim(field, `"`)
+		return validate.Violation{Field: field, Constraint: "unknown", Message: "unknown field " + field}, true
+	}
+
+	return validate.Violation{}, false
+}
+
+// respondWithViolations sends 400 with the list of invalid fields
+func respondWithViolations(w http.ResponseWriter, v validate.Violations) {
+	details := make([]httperr.FieldViolation, 0, len(v))
+	for _, violation := range v {
+		details = append(details, httperr.FieldViolation(violation))
+	}
+	httperr.Write(w, http.StatusBadRequest, "INVALID_INPUT", v.Summary(), details)
+}
+
+// Validate checks the fields of a pr creation request
+func (req CreatePRRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("pull_request_id", req.PullRequestID)
+	v.RequireText("pull_request_name", req.PullRequestName)
+	v.RequireID("author_id", req.AuthorID)
+	return v
+}
+
+// Validate checks the fields of a reviewer reassignment request
+func (req ReassignReviewerRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("pull_request_id", req.PullRequestID)
+	v.RequireID("old_reviewer_id", req.OldReviewerID)
+	return v
+}
+
+// Validate checks the fields of a merge request
+func (req MergePRRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("pull_request_id", req.PullRequestID)
+	return v
+}
+
+// Validate checks the fields of a review submission
+func (req SubmitReviewRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("pull_request_id", req.PullRequestID)
+	v.RequireID("reviewer_id", req.ReviewerID)
+	if !entity.ReviewAction(req.Action).IsValid() {
+		v.Add("action", "enum", entity.ErrInvalidReviewAction.Error())
+	}
+	return v
+}
+
+// Validate checks the pr id of the ready, close and reopen requests
+func (req PRIDRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("pull_request_id", req.PullRequestID)
+	return v
+}
+
+// Validate checks the fields of an activity flag update
+func (req SetIsActiveRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("user_id", req.UserID)
+	if req.IsActive == nil {
+		v.Add("is_active", "required", "is_active is required")
+	}
+	return v
+}
+
+// Validate checks the team name, member fields and member id uniqueness
+func (req AddTeamRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("team_name", req.TeamName)
+
+	seen := make(map[string]bool, len(req.Members))
+	for i, m := range req.Members {
+		prefix := fmt.Sprintf("members[%d].", i)
+		v.RequireID(prefix+"user_id", m.UserID)
+		v.RequireText(prefix+"username", m.Username)
+		if m.Role != "" && !entity.Role(m.Role).IsValid() {
+			v.Add(prefix+"role", "enum", prefix+"role must be "+string(entity.RoleAdmin)+" or "+string(entity.RoleMember))
+		}
+
+		if m.UserID == "" {
+			continue
+		}
+		if seen[m.UserID] {
+			v.Add(prefix+"user_id", "unique", "duplicate member "+m.UserID)
+		}
+		seen[m.UserID] = true
+	}
+	return v
+}
+
+// Validate checks the team name and the format of the sla durations,
+// the settings invariants are checked by the use case
+func (req SetSettingsRequest) Validate() validate.Violations {
+	var v validate.Violations
+	v.RequireID("team_name", req.TeamName)
+	if _, err := parseSettingsDuration(req.ReminderAfter); err != nil {
+		v.Add("reminder_after", "format", "reminder_after must be a duration like 24h")
+	}
+	if _, err := parseSettingsDuration(req.EscalateAfter); err != nil {
+		v.Add("escalate_after", "format", "escalate_after must be a duration like 48h")
+	}
+	return v
+}
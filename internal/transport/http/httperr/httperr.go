@@ -0,0 +1,125 @@
+// Package httperr writes api error responses, shared by handlers and middleware
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+)
+
+// FieldViolation describes one invalid field of a request body
+type FieldViolation struct {
+	Field      string `json:"field"`
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
+}
+
+// Response standardizes error response structure
+type Response struct {
+	Error struct {
+		Code    string           `json:"code"`
+		Message string           `json:"message"`
+		Details []FieldViolation `json:"details,omitempty"`
+	} `json:"error"`
+}
+
+// MapDomainErrorToHTTPCode translates domain errors to http status codes,
+// the message is fixed for every domain error, wrapping context like repository
+// operation names only goes to the logs
+func MapDomainErrorToHTTPCode(err error) (int, string, string) {
+	if errors.Is(err, entity.ErrNotFound) {
+		return http.StatusNotFound, "NOT_FOUND", entity.ErrNotFound.Error()
+	}
+	if errors.Is(err, entity.ErrTeamExists) {
+		return http.StatusConflict, "TEAM_EXISTS", entity.ErrTeamExists.Error()
+	}
+	if errors.Is(err, entity.ErrUserExists) {
+		return http.StatusConflict, "USER_EXISTS", entity.ErrUserExists.Error()
+	}
+	if errors.Is(err, entity.ErrPRMerged) {
+		return http.StatusConflict, "PR_MERGED", entity.ErrPRMerged.Error()
+	}
+	if errors.Is(err, entity.ErrNotAssigned) {
+		return http.StatusConflict, "NOT_ASSIGNED", entity.ErrNotAssigned.Error()
+	}
+	if errors.Is(err, entity.ErrNoCandidate) {
+		return http.StatusNotFound, "NO_CANDIDATE", entity.ErrNoCandidate.Error()
+	}
+	if errors.Is(err, entity.ErrPRExists) {
+		return http.StatusConflict, "PR_EXISTS", entity.ErrPRExists.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidTransition) {
+		return http.StatusConflict, "INVALID_TRANSITION", entity.ErrInvalidTransition.Error()
+	}
+	if errors.Is(err, entity.ErrPRNotOpen) {
+		return http.StatusConflict, "PR_NOT_OPEN", entity.ErrPRNotOpen.Error()
+	}
+	// the unmet policy conditions are meant for the caller
+	var blocked *entity.MergeBlockedError
+	if errors.As(err, &blocked) {
+		return http.StatusConflict, "MERGE_BLOCKED", blocked.Error()
+	}
+	if errors.Is(err, entity.ErrMergeBlocked) {
+		return http.StatusConflict, "MERGE_BLOCKED", entity.ErrMergeBlocked.Error()
+	}
+	if errors.Is(err, entity.ErrConflict) {
+		return http.StatusConflict, "CONFLICT", entity.ErrConflict.Error()
+	}
+	if errors.Is(err, entity.ErrIdempotencyKeyReused) {
+		return http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", entity.ErrIdempotencyKeyReused.Error()
+	}
+	if errors.Is(err, entity.ErrRequestInProgress) {
+		return http.StatusConflict, "REQUEST_IN_PROGRESS", entity.ErrRequestInProgress.Error()
+	}
+	if errors.Is(err, entity.ErrUnauthorized) {
+		return http.StatusUnauthorized, "UNAUTHORIZED", entity.ErrUnauthorized.Error()
+	}
+	if errors.Is(err, entity.ErrForbidden) {
+		return http.StatusForbidden, "FORBIDDEN", entity.ErrForbidden.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidScope) {
+		return http.StatusBadRequest, "INVALID_INPUT", entity.ErrInvalidScope.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidOrg) {
+		return http.StatusBadRequest, "INVALID_INPUT", entity.ErrInvalidOrg.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidIdempotencyKey) {
+		return http.StatusBadRequest, "INVALID_INPUT", entity.ErrInvalidIdempotencyKey.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidVersion) {
+		return http.StatusBadRequest, "INVALID_INPUT", entity.ErrInvalidVersion.Error()
+	}
+	// so are the broken settings invariants
+	var invalid *entity.InvalidSettingsError
+	if errors.As(err, &invalid) {
+		return http.StatusBadRequest, "INVALID_INPUT", invalid.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidSettings) {
+		return http.StatusBadRequest, "INVALID_INPUT", entity.ErrInvalidSettings.Error()
+	}
+	if errors.Is(err, entity.ErrInvalidReviewAction) {
+		return http.StatusBadRequest, "INVALID_INPUT", entity.ErrInvalidReviewAction.Error()
+	}
+	return http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error"
+}
+
+// Write sends an error response with optional field violations
+func Write(w http.ResponseWriter, status int, code, message string, details []FieldViolation) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	var resp Response
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Details = details
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// WriteDomain writes the api error for a domain error
+func WriteDomain(w http.ResponseWriter, err error) {
+	status, code, msg := MapDomainErrorToHTTPCode(err)
+	Write(w, status, code, msg, nil)
+}
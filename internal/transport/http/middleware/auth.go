@@ -0,0 +1,46 @@
+// Package middleware contains http middleware of the service
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/authn"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
+)
+
+// OrgHeader selects the organization for credentials not bound to one
+const OrgHeader = "X-Org-ID"
+
+// Authenticate requires a valid bearer token and stores the caller principal and
+// organization in the request context, when disabled every request gets full access
+func Authenticate(authenticator authn.TokenAuthenticator, enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := authn.Resolve(r.Context(), authenticator, enabled, r.Header.Get("Authorization"), r.Header.Get(OrgHeader))
+			if err != nil {
+				if !authn.Rejected(err) {
+					slog.Error("Failed to authenticate request", "error", err)
+				}
+				httperr.WriteDomain(w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope rejects requests whose principal lacks the given scope
+func RequireScope(scope entity.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !auth.FromContext(r.Context()).HasScope(scope) {
+				httperr.WriteDomain(w, entity.ErrForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package middleware contains http middleware of the service
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/repository"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/httperr"
+)
+
+// IdempotencyKeyHeader identifies retries of the same request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marks responses replayed from the store
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+const (
+	maxIdempotencyKeyLength = 255
+	maxIdempotentBodySize   = 1 << 20
+)
+
+// Idempotency replays the stored response for requests repeating an Idempotency-Key
+// and rejects reuse of a key with a different request, requests without the header
+// are passed through, server errors are not stored so that they can be retried,
+// keys are scoped to the caller so that clients of one organization never share them
+func Idempotency(store repository.IdempotencyRepository, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				httperr.WriteDomain(w, entity.ErrInvalidIdempotencyKey)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+			if err != nil {
+				httperr.WriteDomain(w, entity.ErrInvalidIdempotencyKey)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			principal := idempotencyPrincipal(auth.FromContext(r.Context()))
+			requestHash := hashRequest(r, body)
+			existing, reserved, err := store.Reserve(r.Context(), principal, key, requestHash, ttl)
+			if err != nil {
+				slog.Error("Failed to reserve idempotency key", "error", err)
+				httperr.WriteDomain(w, err)
+				return
+			}
+
+			if !reserved {
+				switch {
+				case existing.RequestHash != requestHash:
+					httperr.WriteDomain(w, entity.ErrIdempotencyKeyReused)
+				case existing.CompletedAt == nil:
+					httperr.WriteDomain(w, entity.ErrRequestInProgress)
+				default:
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set(IdempotentReplayedHeader, "true")
+					w.WriteHeader(existing.StatusCode)
+					_, _ = w.Write(existing.Body)
+				}
+				return
+			}
+
+			// the outcome is stored even if the client has gone away
+			bgCtx := context.WithoutCancel(r.Context())
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			finished := false
+			defer func() {
+				if !finished {
+					// the handler panicked, let the client retry
+					releaseKey(bgCtx, store, principal, key)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+			finished = true
+
+			if rec.status >= http.StatusInternalServerError {
+				releaseKey(bgCtx, store, principal, key)
+				return
+			}
+			if err := store.Complete(bgCtx, principal, key, rec.status, rec.body.Bytes()); err != nil {
+				slog.Error("Failed to store idempotent response", "error", err)
+			}
+		})
+	}
+}
+
+// hashRequest binds a key to the endpoint and the exact request body
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyPrincipal identifies the caller owning a key, users keep their keys across
+// tokens, e.g. a refreshed sso jwt, while service tokens are told apart by token id
+func idempotencyPrincipal(p auth.Principal) string {
+	if p.UserID != "" {
+		return "user:" + p.UserID
+	}
+	if p.TokenID != "" {
+		return "token:" + p.TokenID
+	}
+	return "" // authentication is disabled
+}
+
+// releaseKey forgets an unfinished key
+func releaseKey(ctx context.Context, store repository.IdempotencyRepository, principal, key string) {
+	if err := store.Release(ctx, principal, key); err != nil {
+		slog.Error("Failed to release idempotency key", "error", err)
+	}
+}
+
+// responseRecorder passes the response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the body
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,165 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/auth"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/infrastructure/db/memory"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/middleware"
+)
+
+// idempotent wraps next with the middleware on a fresh in-memory store
+func idempotent(next http.HandlerFunc) http.Handler {
+	store := memory.NewIdempotencyRepository(memory.NewStore())
+	return middleware.Idempotency(store, time.Hour)(next)
+}
+
+// send posts body with the key on behalf of the principal
+func send(h http.Handler, p auth.Principal, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(body))
+	req = req.WithContext(auth.WithPrincipal(req.Context(), p))
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// errorCode reads the code of an error response
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode error response %q: %v", rec.Body.String(), err)
+	}
+	return resp.Error.Code
+}
+
+var (
+	alice = auth.Principal{TokenID: "t1", UserID: "u1", Scopes: []entity.Scope{entity.ScopePRWrite}}
+	bot   = auth.Principal{TokenID: "t2", Scopes: []entity.Scope{entity.ScopePRWrite}}
+)
+
+func TestIdempotency_Replay(t *testing.T) {
+	var calls atomic.Int32
+	h := idempotent(func(w http.ResponseWriter, _ *http.Request) {
+		n := calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"call":%d}`, n)
+	})
+
+	first := send(h, alice, "k1", `{"pull_request_id":"pr-1"}`)
+	replayed := send(h, alice, "k1", `{"pull_request_id":"pr-1"}`)
+	if calls.Load() != 1 {
+		t.Fatalf("handler called %d times, want once", calls.Load())
+	}
+	if replayed.Code != http.StatusCreated || replayed.Body.String() != first.Body.String() || replayed.Header().Get(middleware.IdempotentReplayedHeader) != "true" {
+		t.Errorf("replay = %d %q %v, want the first response", replayed.Code, replayed.Body.String(), replayed.Header())
+	}
+
+	// the same user keeps the key with another token, e.g. a refreshed jwt
+	refreshed := alice
+	refreshed.TokenID = "jwt-2"
+	if rec := send(h, refreshed, "k1", `{"pull_request_id":"pr-1"}`); rec.Header().Get(middleware.IdempotentReplayedHeader) != "true" {
+		t.Errorf("request with a new token of the same user was not replayed")
+	}
+
+	// another caller of the organization choosing the same key is not served alice's response
+	if rec := send(h, bot, "k1", `{"pull_request_id":"pr-1"}`); rec.Header().Get(middleware.IdempotentReplayedHeader) != "" || calls.Load() != 2 {
+		t.Errorf("request of another principal was replayed, calls %d", calls.Load())
+	}
+
+	// requests without a key are passed through
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(`{}`))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if calls.Load() != 3 {
+		t.Errorf("request without a key was not passed through")
+	}
+}
+
+func TestIdempotency_Reuse(t *testing.T) {
+	h := idempotent(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	send(h, alice, "k1", `{"pull_request_id":"pr-1"}`)
+	rec := send(h, alice, "k1", `{"pull_request_id":"pr-2"}`)
+	if rec.Code != http.StatusUnprocessableEntity || errorCode(t, rec) != "IDEMPOTENCY_KEY_REUSED" {
+		t.Errorf("reuse with another body = %d %s, want 422", rec.Code, rec.Body.String())
+	}
+
+	rec = send(h, alice, strings.Repeat("k", 256), `{}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("too long key = %d, want 400", rec.Code)
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	h := idempotent(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- send(h, alice, "k1", `{}`) }()
+	<-started
+
+	rec := send(h, alice, "k1", `{}`)
+	if rec.Code != http.StatusConflict || errorCode(t, rec) != "REQUEST_IN_PROGRESS" {
+		t.Errorf("retry while in progress = %d %s, want 409", rec.Code, rec.Body.String())
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("first request = %d", first.Code)
+	}
+}
+
+func TestIdempotency_Release(t *testing.T) {
+	tests := []struct {
+		name string
+		fail func(w http.ResponseWriter)
+	}{
+		{"server error", func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }},
+		{"panic", func(http.ResponseWriter) { panic("handler bug") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			h := idempotent(func(w http.ResponseWriter, _ *http.Request) {
+				if calls.Add(1) == 1 {
+					tt.fail(w)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			func() {
+				// the router recovers panics, here the test does
+				defer func() { _ = recover() }()
+				send(h, alice, "k1", `{}`)
+			}()
+
+			// the failed attempt released the key, the retry runs the handler again
+			if rec := send(h, alice, "k1", `{}`); rec.Code != http.StatusCreated || calls.Load() != 2 {
+				t.Errorf("retry = %d after %d calls, want 201 from a second call", rec.Code, calls.Load())
+			}
+			if rec := send(h, alice, "k1", `{}`); rec.Header().Get(middleware.IdempotentReplayedHeader) != "true" {
+				t.Errorf("successful retry was not stored")
+			}
+		})
+	}
+}
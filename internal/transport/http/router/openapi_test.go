@@ -0,0 +1,455 @@
+package router_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/testutil"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/go-chi/chi/v5"
+)
+
+const bootstrapToken = testutil.BootstrapToken
+
+// routes served outside of the api contract
+var undocumented = map[string]bool{"GET /health": true, "GET /ready": true}
+
+func init() {
+	// event stream messages are validated as plain text
+	openapi3filter.RegisterBodyDecoder("text/event-stream", openapi3filter.PlainBodyDecoder)
+}
+
+// contract validates every exchange with the service against openapi.yml
+type contract struct {
+	t       *testing.T
+	spec    *openapi3.T
+	router  routers.Router
+	handler http.Handler
+	covered map[string]bool // "METHOD /path" of exercised operations
+	ctx     context.Context // context of requests to the service, event streams end with it
+}
+
+// response is a validated response of the service
+type response struct {
+	status int
+	header http.Header
+	body   map[string]any
+	text   string // body of non-json responses
+}
+
+func newContract(t *testing.T) *contract {
+	t.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromFile(filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "openapi.yml"))
+	if err != nil {
+		t.Fatalf("load openapi.yml: %v", err)
+	}
+	if err := spec.Validate(loader.Context); err != nil {
+		t.Fatalf("openapi.yml is invalid: %v", err)
+	}
+
+	specRouter, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		t.Fatalf("build spec router: %v", err)
+	}
+
+	return &contract{
+		t:       t,
+		spec:    spec,
+		router:  specRouter,
+		handler: testutil.NewService().Router(),
+		covered: make(map[string]bool),
+		ctx:     context.Background(),
+	}
+}
+
+// do sends a request through the service, the request and the response must match the spec
+func (c *contract) do(method, target string, body any, headers map[string]string, wantStatus int) response {
+	c.t.Helper()
+	ctx := context.Background()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			c.t.Fatalf("marshal body: %v", err)
+		}
+	}
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequestWithContext(c.ctx, method, target, bytes.NewReader(payload))
+		req.Header.Set("Authorization", "Bearer "+bootstrapToken)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+
+	req := newRequest()
+	route, pathParams, err := c.router.FindRoute(req)
+	if err != nil {
+		c.t.Fatalf("%s %s is not in openapi.yml: %v", method, target, err)
+	}
+	c.covered[method+" "+route.Path] = true
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	}
+	if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+		c.t.Fatalf("%s %s: request does not match openapi.yml: %v", method, target, err)
+	}
+
+	// validation consumed the body
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, newRequest())
+	respBody := rec.Body.Bytes()
+
+	if rec.Code != wantStatus {
+		c.t.Fatalf("%s %s: status = %d, want %d, body %s", method, target, rec.Code, wantStatus, respBody)
+	}
+
+	input.Options = &openapi3filter.Options{IncludeResponseStatus: true}
+	respInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(respBody)),
+		Options:                input.Options,
+	}
+	if err := openapi3filter.ValidateResponse(ctx, respInput); err != nil {
+		c.t.Fatalf("%s %s: response %d does not match openapi.yml: %v\nbody: %s", method, target, rec.Code, err, respBody)
+	}
+
+	resp := response{status: rec.Code, header: rec.Header()}
+	if len(respBody) > 0 && !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		resp.text = string(respBody)
+	} else if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &resp.body); err != nil {
+			c.t.Fatalf("%s %s: decode response: %v", method, target, err)
+		}
+	}
+	return resp
+}
+
+// stream reads the event stream of a user until a short timeout cancels the request
+func (c *contract) stream(userID, lastEventID string, wantStatus int) response {
+	c.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c.ctx = ctx
+	defer func() { c.ctx = context.Background() }()
+
+	var headers map[string]string
+	if lastEventID != "" {
+		headers = map[string]string{"Last-Event-ID": lastEventID}
+	}
+	return c.do(http.MethodGet, "/events/stream?user_id="+userID, nil, headers, wantStatus)
+}
+
+// sseMessage is one message of an event stream
+type sseMessage struct {
+	id, event string
+	prID      string // pull request of the event, empty for RESET
+}
+
+// parseStream splits an event stream into messages, comments are skipped
+func parseStream(t *testing.T, text string) []sseMessage {
+	t.Helper()
+
+	var msgs []sseMessage
+	for _, block := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		var msg sseMessage
+		for _, line := range strings.Split(block, "\n") {
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				msg.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				msg.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var data struct {
+					PR struct {
+						ID string `json:"pull_request_id"`
+					} `json:"pr"`
+				}
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data); err != nil {
+					t.Fatalf("event data %q: %v", line, err)
+				}
+				msg.prID = data.PR.ID
+			}
+		}
+		if msg.event != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// prEvents returns the event types of a pull request in stream order
+func prEvents(msgs []sseMessage, prID string) []string {
+	var types []string
+	for _, m := range msgs {
+		if m.prID == prID {
+			types = append(types, m.event)
+		}
+	}
+	return types
+}
+
+// field returns a nested value of a decoded json object
+func field(body map[string]any, path ...string) any {
+	var v any = body
+	for _, key := range path {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+	return v
+}
+
+// reviewers returns the assigned reviewers of a pr object
+func reviewers(t *testing.T, pr any) []string {
+	t.Helper()
+	list, _ := field(map[string]any{"pr": pr}, "pr", "assigned_reviewers").([]any)
+	ids := make([]string, len(list))
+	for i, id := range list {
+		ids[i] = id.(string)
+	}
+	return ids
+}
+
+// TestOpenAPIContract walks through every operation of openapi.yml against the router
+func TestOpenAPIContract(t *testing.T) {
+	c := newContract(t)
+
+	// teams
+	c.do(http.MethodPost, "/team/add", map[string]any{
+		"team_name": "backend",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "Alice", "is_active": true, "role": "ADMIN"},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+			{"user_id": "u3", "username": "Carol", "is_active": true},
+			{"user_id": "u4", "username": "Dave", "is_active": true},
+			{"user_id": "u5", "username": "Eve", "is_active": true},
+		},
+	}, nil, http.StatusCreated)
+	c.do(http.MethodPost, "/team/add", map[string]any{"team_name": "backend", "members": []any{}}, nil, http.StatusConflict)
+
+	team := c.do(http.MethodGet, "/team/get?team_name=backend", nil, nil, http.StatusOK)
+	if members, _ := team.body["members"].([]any); len(members) != 5 {
+		t.Errorf("team members = %v, want 5", team.body["members"])
+	}
+	c.do(http.MethodGet, "/team/get?team_name=nope", nil, nil, http.StatusNotFound)
+
+	c.do(http.MethodPost, "/team/setSettings", map[string]any{
+		"team_name":    "backend",
+		"merge_policy": map[string]any{"min_approvals": 1},
+	}, nil, http.StatusOK)
+	c.do(http.MethodPost, "/team/setSettings", map[string]any{"team_name": "backend", "reminder_after": "soon"}, nil, http.StatusBadRequest)
+	// every broken settings invariant is listed in the details
+	invalid := c.do(http.MethodPost, "/team/setSettings", map[string]any{
+		"team_name":      "backend",
+		"reminder_after": "-1h",
+		"escalate_after": "-2h",
+	}, nil, http.StatusBadRequest)
+	if details, _ := invalid.body["error"].(map[string]any)["details"].([]any); len(details) != 2 {
+		t.Errorf("settings violations = %v, want reminder_after and escalate_after", details)
+	}
+	c.do(http.MethodGet, "/team/getSettings?team_name=backend", nil, nil, http.StatusOK)
+	c.do(http.MethodGet, "/team/getSettings?team_name=nope", nil, nil, http.StatusNotFound)
+
+	// a client resuming from an unknown id is told to reload, the reset id is the resume point
+	reset := c.stream("u2", "1", http.StatusOK)
+	resetMsgs := parseStream(t, reset.text)
+	if len(resetMsgs) != 1 || resetMsgs[0].event != "RESET" || reset.header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("resumed stream = %q", reset.text)
+	}
+	c.stream("nope", "", http.StatusNotFound)
+	c.stream("u2", "abc", http.StatusBadRequest)
+
+	// users, the inactive user is never assigned
+	c.do(http.MethodPost, "/users/setIsActive", map[string]any{"user_id": "u4", "is_active": false}, nil, http.StatusOK)
+	c.do(http.MethodPost, "/users/setIsActive", map[string]any{"user_id": "nope", "is_active": false}, nil, http.StatusNotFound)
+
+	// pull request creation, replayed by idempotency key
+	createBody := map[string]any{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1"}
+	created := c.do(http.MethodPost, "/pullRequest/create", createBody, map[string]string{"Idempotency-Key": "create-pr-1"}, http.StatusCreated)
+	replayed := c.do(http.MethodPost, "/pullRequest/create", createBody, map[string]string{"Idempotency-Key": "create-pr-1"}, http.StatusCreated)
+	if replayed.header.Get("Idempotent-Replayed") != "true" {
+		t.Error("repeated create was not replayed")
+	}
+	c.do(http.MethodPost, "/pullRequest/create", map[string]any{"pull_request_id": "pr-1", "pull_request_name": "x", "author_id": "u1"}, nil, http.StatusConflict)
+	c.do(http.MethodPost, "/pullRequest/create", map[string]any{"pull_request_id": "pr-9", "pull_request_name": "x", "author_id": "nope"}, nil, http.StatusNotFound)
+	c.do(http.MethodPost, "/pullRequest/create", createBody, map[string]string{"Idempotency-Key": "create-pr-1", "X-Test": "1"}, http.StatusCreated)
+	c.do(http.MethodPost, "/pullRequest/create", map[string]any{"pull_request_id": "pr-2", "pull_request_name": "x", "author_id": "u1"},
+		map[string]string{"Idempotency-Key": "create-pr-1"}, http.StatusUnprocessableEntity)
+
+	assigned := reviewers(t, created.body["pr"])
+	if len(assigned) != 2 {
+		t.Fatalf("assigned reviewers = %v, want 2", assigned)
+	}
+
+	// reads with conditional requests
+	got := c.do(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil, nil, http.StatusOK)
+	etag := got.header.Get("ETag")
+	c.do(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil, map[string]string{"If-None-Match": etag}, http.StatusNotModified)
+	c.do(http.MethodGet, "/pullRequest/get?pull_request_id=nope", nil, nil, http.StatusNotFound)
+
+	// reviews and reassignment
+	c.do(http.MethodPost, "/pullRequest/review", map[string]any{"pull_request_id": "pr-1", "reviewer_id": assigned[0], "action": "APPROVE"}, nil, http.StatusOK)
+	c.do(http.MethodPost, "/pullRequest/review", map[string]any{"pull_request_id": "pr-1", "reviewer_id": "u1", "action": "APPROVE"}, nil, http.StatusConflict)
+	c.do(http.MethodGet, fmt.Sprintf("/users/getReview?user_id=%s&pending=true", assigned[1]), nil, nil, http.StatusOK)
+
+	reassigned := c.do(http.MethodPost, "/pullRequest/reassign",
+		map[string]any{"pull_request_id": "pr-1", "old_reviewer_id": assigned[1]}, map[string]string{"Idempotency-Key": "reassign-1"}, http.StatusOK)
+	if reassigned.body["replaced_by"] == assigned[1] {
+		t.Errorf("reviewer %s was not replaced", assigned[1])
+	}
+	c.do(http.MethodPost, "/pullRequest/reassign", map[string]any{"pull_request_id": "pr-1", "old_reviewer_id": "u1"}, nil, http.StatusConflict)
+	c.do(http.MethodPost, "/pullRequest/reassign", map[string]any{"pull_request_id": "nope", "old_reviewer_id": "u2"}, nil, http.StatusNotFound)
+
+	// listing
+	c.do(http.MethodGet, "/pullRequest/list?status=OPEN&team_name=backend&sort=asc&limit=10", nil, nil, http.StatusOK)
+	c.do(http.MethodGet, "/pullRequest/list?cursor=broken", nil, nil, http.StatusBadRequest)
+
+	// lifecycle of a draft
+	c.do(http.MethodPost, "/pullRequest/create", map[string]any{"pull_request_id": "pr-2", "pull_request_name": "Draft", "author_id": "u2", "draft": true}, nil, http.StatusCreated)
+	c.do(http.MethodPost, "/pullRequest/ready", map[string]any{"pull_request_id": "pr-2"}, nil, http.StatusOK)
+	c.do(http.MethodPost, "/pullRequest/ready", map[string]any{"pull_request_id": "pr-2"}, nil, http.StatusConflict)
+	c.do(http.MethodPost, "/pullRequest/close", map[string]any{"pull_request_id": "pr-2"}, nil, http.StatusOK)
+	c.do(http.MethodPost, "/pullRequest/reopen", map[string]any{"pull_request_id": "pr-2"}, nil, http.StatusOK)
+	c.do(http.MethodPost, "/pullRequest/close", map[string]any{"pull_request_id": "nope"}, nil, http.StatusNotFound)
+	c.do(http.MethodPost, "/pullRequest/reopen", map[string]any{"pull_request_id": "pr-2"}, nil, http.StatusConflict)
+
+	// merge with a stale version, then for real
+	c.do(http.MethodPost, "/pullRequest/merge", map[string]any{"pull_request_id": "pr-1"}, map[string]string{"If-Match": `"1"`}, http.StatusConflict)
+	merged := c.do(http.MethodPost, "/pullRequest/merge", map[string]any{"pull_request_id": "pr-1"}, nil, http.StatusOK)
+	if status := field(merged.body, "pr", "status"); status != "MERGED" {
+		t.Errorf("merged status = %v", status)
+	}
+	c.do(http.MethodPost, "/pullRequest/merge", map[string]any{"pull_request_id": "nope"}, nil, http.StatusNotFound)
+	c.do(http.MethodPost, "/pullRequest/reassign", map[string]any{"pull_request_id": "pr-1", "old_reviewer_id": assigned[0]}, nil, http.StatusConflict)
+
+	// events missed since the reset are replayed in order
+	replay := func(userID string) []string {
+		return prEvents(parseStream(t, c.stream(userID, resetMsgs[0].id, http.StatusOK).text), "pr-1")
+	}
+	if got := fmt.Sprint(replay(assigned[0])); got != "[ASSIGNED MERGED]" {
+		t.Errorf("replayed events of %s = %v, want [ASSIGNED MERGED]", assigned[0], got)
+	}
+	if got := fmt.Sprint(replay(assigned[1])); got != "[ASSIGNED UNASSIGNED]" {
+		t.Errorf("replayed events of %s = %v, want [ASSIGNED UNASSIGNED]", assigned[1], got)
+	}
+
+	// api tokens
+	minted := c.do(http.MethodPost, "/admin/tokens/create", map[string]any{"name": "ci", "user_id": "u1", "scopes": []string{"pr:write"}, "expires_in": "720h"}, nil, http.StatusCreated)
+	c.do(http.MethodPost, "/admin/tokens/create", map[string]any{"name": "ci", "user_id": "nope", "scopes": []string{"read"}}, nil, http.StatusNotFound)
+	c.do(http.MethodPost, "/admin/tokens/create", map[string]any{"name": "ci", "scopes": []string{"read"}, "expires_in": "later"}, nil, http.StatusBadRequest)
+	c.do(http.MethodGet, "/admin/tokens/list", nil, nil, http.StatusOK)
+
+	// a user token acts on its own behalf, u1 is an admin of the team of pr-2 author
+	asU1 := map[string]string{"Authorization": fmt.Sprint("Bearer ", minted.body["secret"])}
+	c.do(http.MethodPost, "/pullRequest/create", map[string]any{"pull_request_id": "pr-3", "pull_request_name": "x", "author_id": "u2"}, asU1, http.StatusForbidden)
+	c.do(http.MethodPost, "/pullRequest/review", map[string]any{"pull_request_id": "pr-2", "reviewer_id": "u3", "action": "APPROVE"}, asU1, http.StatusForbidden)
+	c.do(http.MethodPost, "/pullRequest/close", map[string]any{"pull_request_id": "pr-2"}, asU1, http.StatusOK)
+	c.do(http.MethodPost, "/admin/tokens/revoke", map[string]any{"token_id": field(minted.body, "token", "token_id")}, nil, http.StatusOK)
+	c.do(http.MethodPost, "/admin/tokens/revoke", map[string]any{"token_id": "nope"}, nil, http.StatusNotFound)
+
+	// every documented operation must have been exercised
+	for path, item := range c.spec.Paths.Map() {
+		for method := range item.Operations() {
+			if !c.covered[method+" "+path] {
+				t.Errorf("%s %s from openapi.yml is not exercised", method, path)
+			}
+		}
+	}
+}
+
+// TestRoutesDocumented checks that every route of the router is described in openapi.yml
+func TestRoutesDocumented(t *testing.T) {
+	c := newContract(t)
+
+	routes, ok := c.handler.(chi.Routes)
+	if !ok {
+		t.Fatalf("router %T does not expose its routes", c.handler)
+	}
+
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = strings.TrimSuffix(route, "/")
+		if undocumented[method+" "+route] {
+			return nil
+		}
+		item := c.spec.Paths.Find(route)
+		if item == nil || item.GetOperation(method) == nil {
+			t.Errorf("%s %s is served but missing from openapi.yml", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+}
+
+// TestPRETagRoundTrip checks that the etag of a read serves as If-Match of a write
+func TestPRETagRoundTrip(t *testing.T) {
+	c := newContract(t)
+
+	c.do(http.MethodPost, "/team/add", map[string]any{
+		"team_name": "backend",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{"user_id": "u2", "username": "Bob", "is_active": true},
+		},
+	}, nil, http.StatusCreated)
+	c.do(http.MethodPost, "/pullRequest/create", map[string]any{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1", "draft": true}, nil, http.StatusCreated)
+
+	got := c.do(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil, nil, http.StatusOK)
+	etag := got.header.Get("ETag")
+	if want := fmt.Sprintf(`W/"%v-`, field(got.body, "pr", "version")); !strings.HasPrefix(etag, want) {
+		t.Fatalf("etag = %q, want prefix %q", etag, want)
+	}
+	c.do(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil, map[string]string{"If-None-Match": etag}, http.StatusNotModified)
+
+	ready := c.do(http.MethodPost, "/pullRequest/ready", map[string]any{"pull_request_id": "pr-1"}, map[string]string{"If-Match": etag}, http.StatusOK)
+
+	// the pr changed, the old tag neither matches reads nor passes writes
+	fresh := c.do(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil, map[string]string{"If-None-Match": etag}, http.StatusOK)
+	if fresh.header.Get("ETag") == etag || !strings.HasPrefix(fresh.header.Get("ETag"), fmt.Sprintf(`W/"%v-`, field(ready.body, "pr", "version"))) {
+		t.Errorf("etag after ready = %q, was %q", fresh.header.Get("ETag"), etag)
+	}
+	c.do(http.MethodPost, "/pullRequest/close", map[string]any{"pull_request_id": "pr-1"}, map[string]string{"If-Match": etag}, http.StatusConflict)
+
+	// deactivating the author keeps the version, but the embedded author changed
+	c.do(http.MethodPost, "/users/setIsActive", map[string]any{"user_id": "u1", "is_active": false}, nil, http.StatusOK)
+	stale := c.do(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil, map[string]string{"If-None-Match": fresh.header.Get("ETag")}, http.StatusOK)
+	if stale.header.Get("ETag") == fresh.header.Get("ETag") {
+		t.Errorf("etag %q did not change with the author", stale.header.Get("ETag"))
+	}
+
+	// only the version part of the tag is compared by writes
+	c.do(http.MethodPost, "/pullRequest/close", map[string]any{"pull_request_id": "pr-1"}, map[string]string{"If-Match": fresh.header.Get("ETag")}, http.StatusOK)
+}
@@ -4,14 +4,23 @@ package router
 import (
 	"net/http"
 
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/domain/entity"
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/api"
 	"github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/handler"
+	authmw "github.com/hryak228pizza/pr-reviewer-assigner/internal/transport/http/middleware"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 // NewRouter initializes and configures the http router
-func NewRouter(teamHandler *handler.TeamHandler, userHandler *handler.UserHandler, prHandler *handler.PRHandler) http.Handler {
+func NewRouter(teamHandler *handler.TeamHandler, userHandler *handler.UserHandler, prHandler *handler.PRHandler, tokenHandler *handler.TokenHandler, eventHandler *handler.EventHandler, ready func() bool, authenticate, idempotent func(http.Handler) http.Handler) http.Handler {
+	// the generated wrapper binds query and header parameters before calling the handlers
+	h := &api.ServerInterfaceWrapper{
+		Handler:          handler.NewServer(teamHandler, userHandler, prHandler, tokenHandler, eventHandler),
+		ErrorHandlerFunc: handler.RespondWithParamError,
+	}
+
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
@@ -21,20 +30,59 @@ func NewRouter(teamHandler *handler.TeamHandler, userHandler *handler.UserHandle
 	r.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
-
-	r.Route("/team", func(r chi.Router) {
-		r.Post("/add", teamHandler.AddTeam)
+	// readiness turns off first on shutdown so that load balancers stop routing here
+	r.Get("/ready", func(w http.ResponseWriter, _ *http.Request) {
+		if !ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
 	})
 
-	r.Route("/users", func(r chi.Router) {
-		r.Post("/setIsActive", userHandler.SetIsActive)
-		r.Get("/getReview", userHandler.GetReviews)
-	})
+	// everything except the health checks requires a token
+	r.Group(func(r chi.Router) {
+		r.Use(authenticate)
+
+		// team management needs only pr:write, the role rules of services.Authorizer decide
+		r.Route("/team", func(r chi.Router) {
+			r.With(authmw.RequireScope(entity.ScopePRWrite)).Post("/add", h.AddTeam)
+			r.With(authmw.RequireScope(entity.ScopeRead)).Get("/get", h.GetTeam)
+			r.With(authmw.RequireScope(entity.ScopeRead)).Get("/getSettings", h.GetSettings)
+			r.With(authmw.RequireScope(entity.ScopePRWrite)).Post("/setSettings", h.SetSettings)
+		})
+
+		r.Route("/users", func(r chi.Router) {
+			r.With(authmw.RequireScope(entity.ScopePRWrite)).Post("/setIsActive", h.SetIsActive)
+			r.With(authmw.RequireScope(entity.ScopeRead)).Get("/getReview", h.GetReviews)
+		})
+
+		r.Route("/pullRequest", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Use(authmw.RequireScope(entity.ScopePRWrite))
+				r.With(idempotent).Post("/create", h.CreatePR)
+				r.Post("/merge", h.MergePR)
+				r.With(idempotent).Post("/reassign", h.ReassignReviewer)
+				r.Post("/review", h.SubmitReview)
+				r.Post("/ready", h.MarkReady)
+				r.Post("/close", h.ClosePR)
+				r.Post("/reopen", h.ReopenPR)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(authmw.RequireScope(entity.ScopeRead))
+				r.Get("/list", h.ListPRs)
+				r.Get("/get", h.GetPR)
+			})
+		})
+
+		// server-sent events, the stream stays open until the client disconnects
+		r.With(authmw.RequireScope(entity.ScopeRead)).Get("/events/stream", h.StreamEvents)
 
-	r.Route("/pullRequest", func(r chi.Router) {
-		r.Post("/create", prHandler.CreatePR)
-		r.Post("/merge", prHandler.MergePR)
-		r.Post("/reassign", prHandler.ReassignReviewer)
+		r.Route("/admin/tokens", func(r chi.Router) {
+			r.Use(authmw.RequireScope(entity.ScopeTeamAdmin))
+			r.Post("/create", h.CreateToken)
+			r.Post("/revoke", h.RevokeToken)
+			r.Get("/list", h.ListTokens)
+		})
 	})
 
 	return r
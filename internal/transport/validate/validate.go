@@ -0,0 +1,54 @@
+// Package validate collects invalid fields of a request, shared by the http and grpc apis
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxIDLength matches the varchar columns holding team names and user and pr ids
+const MaxIDLength = 255
+
+// Violation is an invalid field, constraint names the failed rule, e.g. required or max_length
+type Violation struct {
+	Field      string
+	Constraint string
+	Message    string
+}
+
+// Violations collects field violations of a single request
+type Violations []Violation
+
+// Add records a violation
+func (v *Violations) Add(field, constraint, message string) {
+	*v = append(*v, Violation{Field: field, Constraint: constraint, Message: message})
+}
+
+// RequireID checks that an identifier is present and fits into its column
+func (v *Violations) RequireID(field, value string) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		v.Add(field, "required", field+" is required")
+	case len(value) > MaxIDLength:
+		v.Add(field, "max_length", fmt.Sprintf("%s must be at most %d characters", field, MaxIDLength))
+	}
+}
+
+// RequireText checks that a free text field is present
+func (v *Violations) RequireText(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.Add(field, "required", field+" is required")
+	}
+}
+
+// Summary is the error message of the request: the first violation and the count of the rest
+func (v Violations) Summary() string {
+	if len(v) == 0 {
+		return ""
+	}
+	msg := v[0].Message
+	if len(v) > 1 {
+		msg = fmt.Sprintf("%s (and %d more)", msg, len(v)-1)
+	}
+	return msg
+}
@@ -0,0 +1,267 @@
+// Package client is a go client of the pr reviewer assignment service,
+// models are generated from openapi.yml and operations are typed methods of Client
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how idempotent calls are retried after network errors,
+// 429, 502, 503, 504 and REQUEST_IN_PROGRESS responses
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first one, 1 disables retries
+	BaseDelay   time.Duration // delay before the second attempt, doubled for every next one
+	MaxDelay    time.Duration // upper bound of a single delay including Retry-After
+}
+
+// DefaultRetryPolicy is used unless WithRetry is passed
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+// delay returns the pause after the given failed attempt
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	d := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	if retryAfter > d {
+		d = retryAfter
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// Client calls the service api, it is safe for concurrent use
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+	orgID      string
+	retry      RetryPolicy
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken sets the api token or sso jwt sent as a bearer token
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithOrg selects the organization for tokens not bound to one
+func WithOrg(orgID string) Option {
+	return func(c *Client) { c.orgID = orgID }
+}
+
+// WithHTTPClient replaces http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRetry replaces DefaultRetryPolicy
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) { c.retry = policy }
+}
+
+// New creates a client of the service at baseURL, e.g. http://localhost:8080
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("client - New - base url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("client - New - base url %q must be an absolute http(s) url", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+	return c, nil
+}
+
+// CallOption customizes a single call
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	version        int
+	idempotencyKey string
+}
+
+// WithIfMatch makes a pr operation fail with ErrConflict if the pr version changed,
+// calls guarded by it are not retried because a lost response changes the version
+func WithIfMatch(version int) CallOption {
+	return func(o *callOptions) { o.version = version }
+}
+
+// WithIdempotencyKey sets the key of CreatePR and ReassignReviewer instead of a random one,
+// use it to retry a call across process restarts
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) { o.idempotencyKey = key }
+}
+
+func newCallOptions(opts []CallOption) callOptions {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// request describes one api call
+type request struct {
+	method     string
+	path       string
+	query      url.Values
+	body       any
+	header     http.Header
+	idempotent bool // safe to send again if the outcome is unknown
+}
+
+// withOptions applies per call options, a pr version check makes the call non idempotent
+// unless an idempotency key replays the first response
+func (r request) withOptions(o callOptions, keyed bool) request {
+	r.header = http.Header{}
+	if o.version > 0 {
+		r.header.Set("If-Match", `"`+strconv.Itoa(o.version)+`"`)
+		r.idempotent = false
+	}
+	if keyed {
+		key := o.idempotencyKey
+		if key == "" {
+			key = newIdempotencyKey()
+		}
+		r.header.Set("Idempotency-Key", key)
+		r.idempotent = true
+	}
+	return r
+}
+
+// do sends the request retrying idempotent calls and decodes a successful response into out
+func (c *Client) do(ctx context.Context, req request, out any) error {
+	var payload []byte
+	if req.body != nil {
+		var err error
+		if payload, err = json.Marshal(req.body); err != nil {
+			return fmt.Errorf("client - %s %s - encode body: %w", req.method, req.path, err)
+		}
+	}
+
+	attempts := 1
+	if req.idempotent {
+		attempts = c.retry.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.send(ctx, req, payload, out)
+		if err == nil || attempt >= attempts || !retryable(ctx, err) {
+			return err
+		}
+
+		var retryAfter time.Duration
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			retryAfter = apiErr.retryAfter
+		}
+
+		timer := time.NewTimer(c.retry.delay(attempt, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// send performs a single attempt
+func (c *Client) send(ctx context.Context, req request, payload []byte, out any) error {
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	body := io.Reader(http.NoBody)
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return fmt.Errorf("client - %s %s: %w", req.method, req.path, err)
+	}
+
+	for k, v := range req.header {
+		httpReq.Header[k] = v
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if payload != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.orgID != "" {
+		httpReq.Header.Set("X-Org-ID", c.orgID)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("client - %s %s: %w", req.method, req.path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("client - %s %s - decode response: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// retryable reports whether a failed attempt may be repeated
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.temporary()
+	}
+	// the request did not reach the service or the response was lost
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// newIdempotencyKey returns a random key for a single logical call
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Health checks that the service is up
+func (c *Client) Health(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodGet, path: "/health", idempotent: true}, nil)
+}
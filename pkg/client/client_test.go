@@ -0,0 +1,322 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hryak228pizza/pr-reviewer-assigner/internal/testutil"
+	"github.com/hryak228pizza/pr-reviewer-assigner/pkg/client"
+)
+
+const bootstrapToken = testutil.BootstrapToken
+
+// fastRetry keeps retry tests quick
+var fastRetry = client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+// newService starts the service on the in-memory storage and returns a client with the bootstrap token
+func newService(t *testing.T) (*client.Client, *httptest.Server) {
+	t.Helper()
+
+	srv := httptest.NewServer(testutil.NewService().Router())
+	t.Cleanup(srv.Close)
+
+	c, err := client.New(srv.URL, client.WithToken(bootstrapToken), client.WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return c, srv
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newService(t)
+
+	if err := c.Health(ctx); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+
+	team, err := c.AddTeam(ctx, client.Team{
+		TeamName: "backend",
+		Members: []client.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddTeam: %v", err)
+	}
+	if len(team.Members) != 3 {
+		t.Fatalf("AddTeam members = %d, want 3", len(team.Members))
+	}
+	if _, err := c.AddTeam(ctx, client.Team{TeamName: "backend", Members: []client.TeamMember{}}); !errors.Is(err, client.ErrTeamExists) {
+		t.Fatalf("AddTeam twice: err = %v, want ErrTeamExists", err)
+	}
+
+	settings, err := c.SetTeamSettings(ctx, client.TeamSettings{TeamName: "backend", ReminderAfter: "24h"})
+	if err != nil {
+		t.Fatalf("SetTeamSettings: %v", err)
+	}
+	if got, err := c.GetTeamSettings(ctx, "backend"); err != nil || got.ReminderAfter != settings.ReminderAfter {
+		t.Fatalf("GetTeamSettings = %+v, %v, want reminder %s", got, err, settings.ReminderAfter)
+	}
+
+	pr, err := c.CreatePR(ctx, client.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePR: %v", err)
+	}
+	if pr.Status != client.PullRequestStatusOPEN || len(pr.AssignedReviewers) != 2 {
+		t.Fatalf("CreatePR = %+v, want OPEN with 2 reviewers", pr)
+	}
+	if _, err := c.CreatePR(ctx, client.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "Again", AuthorID: "u1"}); !errors.Is(err, client.ErrPRExists) {
+		t.Fatalf("CreatePR twice: err = %v, want ErrPRExists", err)
+	}
+
+	// both other members are already reviewers
+	if _, _, err := c.ReassignReviewer(ctx, "pr-1", "u2"); !errors.Is(err, client.ErrNoCandidate) {
+		t.Fatalf("ReassignReviewer: err = %v, want ErrNoCandidate", err)
+	}
+
+	reviews, err := c.GetReviews(ctx, "u2", client.ReviewsOptions{Pending: true})
+	if err != nil || len(reviews.PullRequests) != 1 {
+		t.Fatalf("GetReviews = %+v, %v, want pr-1", reviews, err)
+	}
+
+	if _, err := c.SubmitReview(ctx, "pr-1", "u2", client.ActionApprove); err != nil {
+		t.Fatalf("SubmitReview: %v", err)
+	}
+
+	got, err := c.GetPR(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPR: %v", err)
+	}
+	if _, err := c.MergePR(ctx, "pr-1", false, client.WithIfMatch(got.Version+1)); !errors.Is(err, client.ErrConflict) {
+		t.Fatalf("MergePR with stale version: err = %v, want ErrConflict", err)
+	}
+	merged, err := c.MergePR(ctx, "pr-1", false, client.WithIfMatch(got.Version))
+	if err != nil || merged.Status != client.PullRequestStatusMERGED {
+		t.Fatalf("MergePR = %+v, %v, want MERGED", merged, err)
+	}
+	if _, _, err := c.ReassignReviewer(ctx, "pr-1", "u2"); !errors.Is(err, client.ErrPRMerged) {
+		t.Fatalf("ReassignReviewer after merge: err = %v, want ErrPRMerged", err)
+	}
+
+	page, err := c.ListPRs(ctx, client.ListPRsOptions{Statuses: []client.PullRequestStatus{client.PullRequestStatusMERGED}, AuthorID: "u1"})
+	if err != nil || len(page.PullRequests) != 1 || page.NextCursor != "" {
+		t.Fatalf("ListPRs = %+v, %v, want one merged pr", page, err)
+	}
+
+	if _, err := c.GetPR(ctx, "pr-404"); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("GetPR missing: err = %v, want ErrNotFound", err)
+	}
+
+	user, err := c.SetIsActive(ctx, "u3", false)
+	if err != nil || user.IsActive {
+		t.Fatalf("SetIsActive = %+v, %v, want inactive", user, err)
+	}
+
+	token, secret, err := c.CreateToken(ctx, client.CreateTokenRequest{Name: "ci", Scopes: []client.APITokenScopes{client.Read}, ExpiresIn: time.Hour})
+	if err != nil || secret == "" {
+		t.Fatalf("CreateToken = %+v, %q, %v", token, secret, err)
+	}
+	if tokens, err := c.ListTokens(ctx); err != nil || len(tokens) != 1 {
+		t.Fatalf("ListTokens = %+v, %v, want one token", tokens, err)
+	}
+	if revoked, err := c.RevokeToken(ctx, token.TokenID); err != nil || revoked.RevokedAt.IsZero() {
+		t.Fatalf("RevokeToken = %+v, %v, want revoked", revoked, err)
+	}
+}
+
+func TestClientErrorDetails(t *testing.T) {
+	c, _ := newService(t)
+
+	_, err := c.CreatePR(context.Background(), client.CreatePRRequest{PullRequestName: "No id", AuthorID: "u1"})
+	if !errors.Is(err, client.ErrInvalidInput) {
+		t.Fatalf("err = %v, want ErrInvalidInput", err)
+	}
+
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %T, want *client.Error", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Details) != 1 || apiErr.Details[0].Field != "pull_request_id" {
+		t.Fatalf("error = %+v, want 400 with pull_request_id violation", apiErr)
+	}
+
+	// operations taking only a pr id report a missing one as a field, not as an unknown pr
+	ctx := context.Background()
+	calls := map[string]func() error{
+		"merge":  func() error { _, err := c.MergePR(ctx, "", false); return err },
+		"review": func() error { _, err := c.SubmitReview(ctx, "", "u2", client.ActionApprove); return err },
+		"ready":  func() error { _, err := c.MarkReady(ctx, ""); return err },
+		"close":  func() error { _, err := c.ClosePR(ctx, ""); return err },
+		"reopen": func() error { _, err := c.ReopenPR(ctx, ""); return err },
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			var apiErr *client.Error
+			if err := call(); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest ||
+				len(apiErr.Details) != 1 || apiErr.Details[0].Field != "pull_request_id" {
+				t.Errorf("err = %v, want 400 with pull_request_id violation", err)
+			}
+		})
+	}
+}
+
+func TestClientUnauthorized(t *testing.T) {
+	_, srv := newService(t)
+
+	c, err := client.New(srv.URL, client.WithToken("wrong"))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if _, err := c.ListTokens(context.Background()); !errors.Is(err, client.ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+}
+
+// flaky answers with the given statuses before letting requests through to the handler
+type flaky struct {
+	mu       sync.Mutex
+	failures []int
+	requests []*http.Request
+	next     http.Handler
+}
+
+func (f *flaky) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests = append(f.requests, r)
+	var status int
+	if len(f.failures) > 0 {
+		status, f.failures = f.failures[0], f.failures[1:]
+	}
+	f.mu.Unlock()
+
+	if status != 0 {
+		w.WriteHeader(status)
+		return
+	}
+	f.next.ServeHTTP(w, r)
+}
+
+func TestClientRetry(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"pr":{"pull_request_id":"pr-1","pull_request_name":"n","author_id":"u1","status":"OPEN","assigned_reviewers":[]},"team":{"team_name":"t","members":[]}}`))
+	})
+
+	tests := []struct {
+		name         string
+		failures     []int
+		call         func(*client.Client) error
+		wantErr      bool
+		wantRequests int
+	}{
+		{
+			name:     "idempotency key is reused across attempts",
+			failures: []int{http.StatusServiceUnavailable, http.StatusBadGateway},
+			call: func(c *client.Client) error {
+				_, err := c.CreatePR(context.Background(), client.CreatePRRequest{PullRequestID: "pr-1", PullRequestName: "n", AuthorID: "u1"})
+				return err
+			},
+			wantRequests: 3,
+		},
+		{
+			name:     "gives up after max attempts",
+			failures: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			call: func(c *client.Client) error {
+				_, err := c.GetPR(context.Background(), "pr-1")
+				return err
+			},
+			wantErr:      true,
+			wantRequests: 3,
+		},
+		{
+			name:     "non idempotent call is sent once",
+			failures: []int{http.StatusServiceUnavailable},
+			call: func(c *client.Client) error {
+				_, err := c.AddTeam(context.Background(), client.Team{TeamName: "t"})
+				return err
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:     "version check disables retries",
+			failures: []int{http.StatusServiceUnavailable},
+			call: func(c *client.Client) error {
+				_, err := c.MergePR(context.Background(), "pr-1", false, client.WithIfMatch(2))
+				return err
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:     "client errors are not retried",
+			failures: []int{http.StatusBadRequest},
+			call: func(c *client.Client) error {
+				_, err := c.GetPR(context.Background(), "pr-1")
+				return err
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &flaky{failures: tt.failures, next: ok}
+			srv := httptest.NewServer(f)
+			defer srv.Close()
+
+			c, err := client.New(srv.URL, client.WithRetry(fastRetry))
+			if err != nil {
+				t.Fatalf("new client: %v", err)
+			}
+
+			err = tt.call(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if len(f.requests) != tt.wantRequests {
+				t.Fatalf("requests = %d, want %d", len(f.requests), tt.wantRequests)
+			}
+
+			key := f.requests[0].Header.Get("Idempotency-Key")
+			for _, r := range f.requests[1:] {
+				if r.Header.Get("Idempotency-Key") != key {
+					t.Fatalf("Idempotency-Key changed between attempts: %q, %q", key, r.Header.Get("Idempotency-Key"))
+				}
+			}
+		})
+	}
+}
+
+func TestClientRetryStopsOnCancel(t *testing.T) {
+	f := &flaky{failures: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}, next: http.NotFoundHandler()}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	c, err := client.New(srv.URL, client.WithRetry(client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour}))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.GetPR(ctx, "pr-1")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want the last 503", err)
+	}
+	if len(f.requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(f.requests))
+	}
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CodeInternalError is returned with 500 responses, it is not part of the documented codes
+const CodeInternalError ErrorResponseErrorCode = "INTERNAL_ERROR"
+
+// errors for every api error code, match them with errors.Is
+var (
+	ErrTeamExists           = &Error{Code: TEAMEXISTS}
+	ErrUserExists           = &Error{Code: USEREXISTS}
+	ErrPRExists             = &Error{Code: PREXISTS}
+	ErrPRMerged             = &Error{Code: PRMERGED}
+	ErrNotAssigned          = &Error{Code: NOTASSIGNED}
+	ErrNoCandidate          = &Error{Code: NOCANDIDATE}
+	ErrNotFound             = &Error{Code: NOTFOUND}
+	ErrMergeBlocked         = &Error{Code: MERGEBLOCKED}
+	ErrForbidden            = &Error{Code: FORBIDDEN}
+	ErrInvalidTransition    = &Error{Code: INVALIDTRANSITION}
+	ErrPRNotOpen            = &Error{Code: PRNOTOPEN}
+	ErrUnauthorized         = &Error{Code: UNAUTHORIZED}
+	ErrInvalidInput         = &Error{Code: INVALIDINPUT}
+	ErrIdempotencyKeyReused = &Error{Code: IDEMPOTENCYKEYREUSED}
+	ErrRequestInProgress    = &Error{Code: REQUESTINPROGRESS}
+	ErrConflict             = &Error{Code: CONFLICT}
+	ErrInternal             = &Error{Code: CodeInternalError}
+)
+
+// FieldViolation describes one invalid field of a request, returned with ErrInvalidInput
+type FieldViolation struct {
+	Field      string
+	Constraint ErrorResponseErrorDetailsConstraint
+	Message    string
+}
+
+// Error is an error response of the api
+type Error struct {
+	StatusCode int
+	Code       ErrorResponseErrorCode // empty if the body is not an api error, e.g. from a proxy
+	Message    string
+	Details    []FieldViolation
+
+	retryAfter time.Duration // from the Retry-After header
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Code == "":
+		return fmt.Sprintf("http %d: %s", e.StatusCode, e.Message)
+	case e.Message == "":
+		return string(e.Code)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is matches errors by code so errors.Is(err, client.ErrPRMerged) works
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// temporary reports whether the same request may succeed later
+func (e *Error) temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	// another request with the same idempotency key is still running
+	return e.Code == REQUESTINPROGRESS
+}
+
+// maxErrorBody limits how much of an error response is read
+const maxErrorBody = 64 << 10
+
+// decodeError builds an Error from a non-2xx response
+func decodeError(resp *http.Response) *Error {
+	apiErr := &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.retryAfter = time.Duration(seconds) * time.Second
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return apiErr
+	}
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) != nil || errResp.Error.Code == "" {
+		return apiErr
+	}
+
+	apiErr.Code = errResp.Error.Code
+	apiErr.Message = errResp.Error.Message
+	for _, d := range errResp.Error.Details {
+		apiErr.Details = append(apiErr.Details, FieldViolation{Field: d.Field, Constraint: d.Constraint, Message: d.Message})
+	}
+	return apiErr
+}
@@ -0,0 +1,294 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package client
+
+import (
+	"time"
+)
+
+// Defines values for APITokenScopes.
+const (
+	PrWrite   APITokenScopes = "pr:write"
+	Read      APITokenScopes = "read"
+	TeamAdmin APITokenScopes = "team:admin"
+)
+
+// Defines values for AssignmentHistoryEventsAction.
+const (
+	AssignmentHistoryEventsActionASSIGNED   AssignmentHistoryEventsAction = "ASSIGNED"
+	AssignmentHistoryEventsActionUNASSIGNED AssignmentHistoryEventsAction = "UNASSIGNED"
+)
+
+// Defines values for ErrorResponseErrorCode.
+const (
+	CONFLICT             ErrorResponseErrorCode = "CONFLICT"
+	FORBIDDEN            ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INVALIDINPUT         ErrorResponseErrorCode = "INVALID_INPUT"
+	INVALIDTRANSITION    ErrorResponseErrorCode = "INVALID_TRANSITION"
+	MERGEBLOCKED         ErrorResponseErrorCode = "MERGE_BLOCKED"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	PRNOTOPEN            ErrorResponseErrorCode = "PR_NOT_OPEN"
+	REQUESTINPROGRESS    ErrorResponseErrorCode = "REQUEST_IN_PROGRESS"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED         ErrorResponseErrorCode = "UNAUTHORIZED"
+	USEREXISTS           ErrorResponseErrorCode = "USER_EXISTS"
+)
+
+// Defines values for ErrorResponseErrorDetailsConstraint.
+const (
+	Enum      ErrorResponseErrorDetailsConstraint = "enum"
+	Format    ErrorResponseErrorDetailsConstraint = "format"
+	MaxLength ErrorResponseErrorDetailsConstraint = "max_length"
+	Range     ErrorResponseErrorDetailsConstraint = "range"
+	Required  ErrorResponseErrorDetailsConstraint = "required"
+	Type      ErrorResponseErrorDetailsConstraint = "type"
+	Unique    ErrorResponseErrorDetailsConstraint = "unique"
+	Unknown   ErrorResponseErrorDetailsConstraint = "unknown"
+)
+
+// Defines values for EventType.
+const (
+	EventTypeASSIGNED   EventType = "ASSIGNED"
+	EventTypeMERGED     EventType = "MERGED"
+	EventTypeUNASSIGNED EventType = "UNASSIGNED"
+)
+
+// Defines values for PullRequestStatus.
+const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
+	PullRequestStatusMERGED PullRequestStatus = "MERGED"
+	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
+)
+
+// Defines values for PullRequestShortStatus.
+const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
+	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
+	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
+)
+
+// Defines values for ReviewState.
+const (
+	APPROVED         ReviewState = "APPROVED"
+	CHANGESREQUESTED ReviewState = "CHANGES_REQUESTED"
+	COMMENTED        ReviewState = "COMMENTED"
+	PENDING          ReviewState = "PENDING"
+)
+
+// Defines values for TeamMemberRole.
+const (
+	TeamMemberRoleADMIN  TeamMemberRole = "ADMIN"
+	TeamMemberRoleMEMBER TeamMemberRole = "MEMBER"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleADMIN  UserRole = "ADMIN"
+	UserRoleMEMBER UserRole = "MEMBER"
+)
+
+// APIToken defines model for APIToken.
+type APIToken struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+	Name      string    `json:"name"`
+
+	// OrgID Организация, к которой привязан токен
+	OrgID     string           `json:"org_id,omitempty"`
+	RevokedAt time.Time        `json:"revokedAt,omitempty"`
+	Scopes    []APITokenScopes `json:"scopes"`
+	TokenID   string           `json:"token_id"`
+
+	// UserID Пользователь, от имени которого действует токен
+	UserID string `json:"user_id,omitempty"`
+}
+
+// APITokenScopes defines model for APIToken.Scopes.
+type APITokenScopes string
+
+// AssignmentHistory Сводка истории назначений ревьюверов (только в /pullRequest/get)
+type AssignmentHistory struct {
+	// Assignments Всего назначений за историю PR
+	Assignments int `json:"assignments"`
+
+	// Events Последнее событие каждого ревьювера в хронологическом порядке; полный журнал не возвращается
+	Events []struct {
+		Action     AssignmentHistoryEventsAction `json:"action"`
+		CreatedAt  time.Time                     `json:"createdAt"`
+		ReviewerID string                        `json:"reviewer_id"`
+	} `json:"events"`
+	LastChangeAt time.Time `json:"lastChangeAt,omitempty"`
+
+	// Unassignments Всего снятий за историю PR
+	Unassignments int `json:"unassignments"`
+}
+
+// AssignmentHistoryEventsAction defines model for AssignmentHistory.Events.Action.
+type AssignmentHistoryEventsAction string
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error struct {
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// Details Нарушения по полям тела запроса (только для INVALID_INPUT)
+		Details []struct {
+			Constraint ErrorResponseErrorDetailsConstraint `json:"constraint"`
+			Field      string                              `json:"field"`
+			Message    string                              `json:"message"`
+		} `json:"details,omitempty"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
+type ErrorResponseErrorCode string
+
+// ErrorResponseErrorDetailsConstraint defines model for ErrorResponse.Error.Details.Constraint.
+type ErrorResponseErrorDetailsConstraint string
+
+// Event Данные (`data`) SSE-сообщения потока `/events/stream`
+type Event struct {
+	CreatedAt time.Time   `json:"createdAt"`
+	Pr        PullRequest `json:"pr"`
+
+	// Type Совпадает с полем `event` сообщения
+	Type EventType `json:"type"`
+
+	// UserID Получатель события
+	UserID string `json:"user_id"`
+}
+
+// EventType Совпадает с полем `event` сообщения
+type EventType string
+
+// MergePolicy Условия, которые должны выполняться перед мерджем PR авторов команды
+type MergePolicy struct {
+	// BlockOnChangesRequested Запрещать мердж, пока есть запрос изменений
+	BlockOnChangesRequested bool `json:"block_on_changes_requested,omitempty"`
+
+	// MinApprovals Минимальное число одобрений, не больше числа назначаемых ревьюверов (2)
+	MinApprovals int `json:"min_approvals,omitempty"`
+
+	// RequireAllApproved Все назначенные ревьюверы должны одобрить PR
+	RequireAllApproved bool `json:"require_all_approved,omitempty"`
+}
+
+// PullRequest defines model for PullRequest.
+type PullRequest struct {
+	// AssignedReviewers user_id назначенных ревьюверов (0..2)
+	AssignedReviewers []string `json:"assigned_reviewers"`
+
+	// AssignmentHistory Сводка истории назначений ревьюверов (только в /pullRequest/get)
+	AssignmentHistory AssignmentHistory `json:"assignment_history,omitempty"`
+	Author            User              `json:"author,omitempty"`
+	AuthorID          string            `json:"author_id"`
+	ClosedAt          time.Time         `json:"closedAt"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	MergedAt          time.Time         `json:"mergedAt"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+
+	// Reviews Состояние ревью каждого назначенного ревьювера
+	Reviews []Review          `json:"reviews,omitempty"`
+	Status  PullRequestStatus `json:"status"`
+
+	// Version Версия PR, увеличивается при каждом изменении статуса, ревьюверов и ревью
+	Version int `json:"version,omitempty"`
+}
+
+// PullRequestStatus defines model for PullRequest.Status.
+type PullRequestStatus string
+
+// PullRequestShort defines model for PullRequestShort.
+type PullRequestShort struct {
+	AuthorID        string                 `json:"author_id"`
+	PullRequestID   string                 `json:"pull_request_id"`
+	PullRequestName string                 `json:"pull_request_name"`
+	Status          PullRequestShortStatus `json:"status"`
+}
+
+// PullRequestShortStatus defines model for PullRequestShort.Status.
+type PullRequestShortStatus string
+
+// Review defines model for Review.
+type Review struct {
+	AssignedAt time.Time `json:"assignedAt"`
+
+	// FirstResponseAt Время первого ответа ревьювера
+	FirstResponseAt time.Time   `json:"firstResponseAt"`
+	PullRequestID   string      `json:"pull_request_id"`
+	ReviewerID      string      `json:"reviewer_id"`
+	State           ReviewState `json:"state"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+}
+
+// ReviewState defines model for Review.State.
+type ReviewState string
+
+// Team defines model for Team.
+type Team struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// TeamMember defines model for TeamMember.
+type TeamMember struct {
+	IsActive bool `json:"is_active"`
+
+	// Role Администраторы команды управляют её участниками и настройками
+	Role     TeamMemberRole `json:"role,omitempty"`
+	UserID   string         `json:"user_id"`
+	Username string         `json:"username"`
+}
+
+// TeamMemberRole Администраторы команды управляют её участниками и настройками
+type TeamMemberRole string
+
+// TeamSettings defines model for TeamSettings.
+type TeamSettings struct {
+	// AutoReassign Переназначать ревьюверов автоматически при эскалации
+	AutoReassign bool `json:"auto_reassign,omitempty"`
+
+	// EscalateAfter Через сколько после создания PR эскалировать (Go duration, "0s" — выключено)
+	EscalateAfter string `json:"escalate_after,omitempty"`
+
+	// MergePolicy Условия, которые должны выполняться перед мерджем PR авторов команды
+	MergePolicy MergePolicy `json:"merge_policy,omitempty"`
+
+	// ReminderAfter Через сколько после создания PR напомнить ревьюверам (Go duration, "0s" — выключено)
+	ReminderAfter string `json:"reminder_after,omitempty"`
+	TeamName      string `json:"team_name"`
+}
+
+// User defines model for User.
+type User struct {
+	IsActive bool     `json:"is_active"`
+	Role     UserRole `json:"role,omitempty"`
+	TeamName string   `json:"team_name"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+}
+
+// UserRole defines model for User.Role.
+type UserRole string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// TeamNameQuery defines model for TeamNameQuery.
+type TeamNameQuery = string
+
+// UserIDQuery defines model for UserIdQuery.
+type UserIDQuery = string
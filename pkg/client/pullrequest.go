@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ReviewAction is a reviewer response submitted with SubmitReview
+type ReviewAction string
+
+const (
+	ActionApprove        ReviewAction = "APPROVE"
+	ActionRequestChanges ReviewAction = "REQUEST_CHANGES"
+	ActionComment        ReviewAction = "COMMENT"
+)
+
+// CreatePRRequest is the body of CreatePR
+type CreatePRRequest struct {
+	PullRequestID   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	AuthorID        string `json:"author_id"`
+	Draft           bool   `json:"draft,omitempty"` // reviewers are assigned by MarkReady
+}
+
+// ListPRsOptions filters ListPRs, zero fields are not applied
+type ListPRsOptions struct {
+	Statuses    []PullRequestStatus
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	MergedFrom  time.Time
+	MergedTo    time.Time
+	Ascending   bool // oldest first, newest first by default
+	Limit       int
+	Cursor      string // NextCursor of the previous page
+}
+
+// PullRequestPage is a page of pull requests
+type PullRequestPage struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty"` // empty on the last page
+}
+
+// prResponse wraps a pr returned by pr operations
+type prResponse struct {
+	PR PullRequest `json:"pr"`
+}
+
+// prIDBody is the body of operations addressing a pr by id
+type prIDBody struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// CreatePR creates a pull request and assigns reviewers,
+// the call is sent with an idempotency key and retried safely
+func (c *Client) CreatePR(ctx context.Context, pr CreatePRRequest, opts ...CallOption) (*PullRequest, error) {
+	req := request{method: http.MethodPost, path: "/pullRequest/create", body: pr}
+	return c.doPR(ctx, req.withOptions(newCallOptions(opts), true))
+}
+
+// MergePR merges a pull request, merging a merged pr returns it unchanged,
+// force skips the merge policy and needs the ADMIN role in the author team
+func (c *Client) MergePR(ctx context.Context, prID string, force bool, opts ...CallOption) (*PullRequest, error) {
+	body := struct {
+		PullRequestID string `json:"pull_request_id"`
+		Force         bool   `json:"force,omitempty"`
+	}{PullRequestID: prID, Force: force}
+
+	req := request{method: http.MethodPost, path: "/pullRequest/merge", body: body, idempotent: true}
+	return c.doPR(ctx, req.withOptions(newCallOptions(opts), false))
+}
+
+// ReassignReviewer replaces a reviewer with a random active member of their team,
+// it returns the updated pr and the id of the new reviewer
+func (c *Client) ReassignReviewer(ctx context.Context, prID, oldReviewerID string, opts ...CallOption) (*PullRequest, string, error) {
+	body := struct {
+		PullRequestID string `json:"pull_request_id"`
+		OldReviewerID string `json:"old_reviewer_id"`
+	}{PullRequestID: prID, OldReviewerID: oldReviewerID}
+
+	var resp struct {
+		PR         PullRequest `json:"pr"`
+		ReplacedBy string      `json:"replaced_by"`
+	}
+	req := request{method: http.MethodPost, path: "/pullRequest/reassign", body: body}
+	if err := c.do(ctx, req.withOptions(newCallOptions(opts), true), &resp); err != nil {
+		return nil, "", err
+	}
+	return &resp.PR, resp.ReplacedBy, nil
+}
+
+// SubmitReview records the response of an assigned reviewer
+func (c *Client) SubmitReview(ctx context.Context, prID, reviewerID string, action ReviewAction, opts ...CallOption) (*Review, error) {
+	body := struct {
+		PullRequestID string       `json:"pull_request_id"`
+		ReviewerID    string       `json:"reviewer_id"`
+		Action        ReviewAction `json:"action"`
+	}{PullRequestID: prID, ReviewerID: reviewerID, Action: action}
+
+	var resp struct {
+		Review Review `json:"review"`
+	}
+	req := request{method: http.MethodPost, path: "/pullRequest/review", body: body}
+	if err := c.do(ctx, req.withOptions(newCallOptions(opts), false), &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Review, nil
+}
+
+// MarkReady moves a draft to OPEN and assigns reviewers
+func (c *Client) MarkReady(ctx context.Context, prID string, opts ...CallOption) (*PullRequest, error) {
+	req := request{method: http.MethodPost, path: "/pullRequest/ready", body: prIDBody{prID}}
+	return c.doPR(ctx, req.withOptions(newCallOptions(opts), false))
+}
+
+// ClosePR closes a pull request without merging, closing a closed pr returns it unchanged
+func (c *Client) ClosePR(ctx context.Context, prID string, opts ...CallOption) (*PullRequest, error) {
+	req := request{method: http.MethodPost, path: "/pullRequest/close", body: prIDBody{prID}, idempotent: true}
+	return c.doPR(ctx, req.withOptions(newCallOptions(opts), false))
+}
+
+// ReopenPR reopens a closed pull request
+func (c *Client) ReopenPR(ctx context.Context, prID string, opts ...CallOption) (*PullRequest, error) {
+	req := request{method: http.MethodPost, path: "/pullRequest/reopen", body: prIDBody{prID}}
+	return c.doPR(ctx, req.withOptions(newCallOptions(opts), false))
+}
+
+// GetPR returns a pull request with its reviews and assignment history
+func (c *Client) GetPR(ctx context.Context, prID string) (*PullRequest, error) {
+	return c.doPR(ctx, request{
+		method:     http.MethodGet,
+		path:       "/pullRequest/get",
+		query:      url.Values{"pull_request_id": {prID}},
+		idempotent: true,
+	})
+}
+
+// ListPRs returns a filtered page of pull requests
+func (c *Client) ListPRs(ctx context.Context, opts ListPRsOptions) (*PullRequestPage, error) {
+	q := url.Values{}
+	setStatuses(q, opts.Statuses)
+	setNonEmpty(q, "author_id", opts.AuthorID)
+	setNonEmpty(q, "reviewer_id", opts.ReviewerID)
+	setNonEmpty(q, "team_name", opts.TeamName)
+	setTime(q, "created_from", opts.CreatedFrom)
+	setTime(q, "created_to", opts.CreatedTo)
+	setTime(q, "merged_from", opts.MergedFrom)
+	setTime(q, "merged_to", opts.MergedTo)
+	if opts.Ascending {
+		q.Set("sort", "asc")
+	}
+	setPage(q, opts.Limit, opts.Cursor)
+
+	var page PullRequestPage
+	err := c.do(ctx, request{method: http.MethodGet, path: "/pullRequest/list", query: q, idempotent: true}, &page)
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// doPR performs an operation responding with a wrapped pr
+func (c *Client) doPR(ctx context.Context, req request) (*PullRequest, error) {
+	var resp prResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.PR, nil
+}
+
+// setStatuses adds a comma separated status filter
+func setStatuses(q url.Values, statuses []PullRequestStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+	values := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		values = append(values, string(s))
+	}
+	q.Set("status", strings.Join(values, ","))
+}
+
+func setNonEmpty(q url.Values, name, value string) {
+	if value != "" {
+		q.Set(name, value)
+	}
+}
+
+func setTime(q url.Values, name string, t time.Time) {
+	if !t.IsZero() {
+		q.Set(name, t.Format(time.RFC3339))
+	}
+}
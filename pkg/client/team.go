@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// AddTeam creates a team with its members
+func (c *Client) AddTeam(ctx context.Context, team Team) (*Team, error) {
+	var resp struct {
+		Team Team `json:"team"`
+	}
+	err := c.do(ctx, request{method: http.MethodPost, path: "/team/add", body: team}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Team, nil
+}
+
+// GetTeam returns a team with all its members including inactive ones
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*Team, error) {
+	var team Team
+	err := c.do(ctx, request{
+		method:     http.MethodGet,
+		path:       "/team/get",
+		query:      url.Values{"team_name": {teamName}},
+		idempotent: true,
+	}, &team)
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// GetTeamSettings returns review sla and merge policy settings of a team
+func (c *Client) GetTeamSettings(ctx context.Context, teamName string) (*TeamSettings, error) {
+	var settings TeamSettings
+	err := c.do(ctx, request{
+		method:     http.MethodGet,
+		path:       "/team/getSettings",
+		query:      url.Values{"team_name": {teamName}},
+		idempotent: true,
+	}, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SetTeamSettings replaces the settings of settings.TeamName
+func (c *Client) SetTeamSettings(ctx context.Context, settings TeamSettings) (*TeamSettings, error) {
+	var resp TeamSettings
+	err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/team/setSettings",
+		body:       settings,
+		idempotent: true, // replaces the whole settings
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
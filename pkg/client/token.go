@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// CreateTokenRequest is the body of CreateToken
+type CreateTokenRequest struct {
+	Name      string
+	UserID    string // the user the token acts as, empty for bots
+	Scopes    []APITokenScopes
+	ExpiresIn time.Duration // zero for a token without expiry
+}
+
+// CreateToken mints an api token, the secret is returned only once
+func (c *Client) CreateToken(ctx context.Context, token CreateTokenRequest) (*APIToken, string, error) {
+	body := struct {
+		Name      string           `json:"name"`
+		UserID    string           `json:"user_id,omitempty"`
+		Scopes    []APITokenScopes `json:"scopes"`
+		ExpiresIn string           `json:"expires_in,omitempty"`
+	}{Name: token.Name, UserID: token.UserID, Scopes: token.Scopes}
+	if token.ExpiresIn > 0 {
+		body.ExpiresIn = token.ExpiresIn.String()
+	}
+
+	var resp struct {
+		Token  APIToken `json:"token"`
+		Secret string   `json:"secret"`
+	}
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/admin/tokens/create", body: body}, &resp); err != nil {
+		return nil, "", err
+	}
+	return &resp.Token, resp.Secret, nil
+}
+
+// ListTokens returns all issued tokens without their secrets
+func (c *Client) ListTokens(ctx context.Context) ([]APIToken, error) {
+	var resp struct {
+		Tokens []APIToken `json:"tokens"`
+	}
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/admin/tokens/list", idempotent: true}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tokens, nil
+}
+
+// RevokeToken disables a token permanently
+func (c *Client) RevokeToken(ctx context.Context, tokenID string) (*APIToken, error) {
+	body := struct {
+		TokenID string `json:"token_id"`
+	}{TokenID: tokenID}
+
+	var resp struct {
+		Token APIToken `json:"token"`
+	}
+	if err := c.do(ctx, request{method: http.MethodPost, path: "/admin/tokens/revoke", body: body}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Token, nil
+}
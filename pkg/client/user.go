@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ReviewsOptions filters the reviews of a user
+type ReviewsOptions struct {
+	Pending  bool                // only prs the user has not responded to yet
+	Statuses []PullRequestStatus // OPEN if empty
+	Limit    int                 // server default if zero
+	Cursor   string              // NextCursor of the previous page
+}
+
+// SetIsActive updates the active flag of a user
+func (c *Client) SetIsActive(ctx context.Context, userID string, isActive bool) (*User, error) {
+	body := struct {
+		UserID   string `json:"user_id"`
+		IsActive bool   `json:"is_active"`
+	}{UserID: userID, IsActive: isActive}
+
+	var resp struct {
+		User User `json:"user"`
+	}
+	err := c.do(ctx, request{
+		method:     http.MethodPost,
+		path:       "/users/setIsActive",
+		body:       body,
+		idempotent: true, // sets an absolute value
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.User, nil
+}
+
+// GetReviews returns a page of prs where the user is an assigned reviewer
+func (c *Client) GetReviews(ctx context.Context, userID string, opts ReviewsOptions) (*PullRequestPage, error) {
+	q := url.Values{"user_id": {userID}}
+	if opts.Pending {
+		q.Set("pending", "true")
+	}
+	setStatuses(q, opts.Statuses)
+	setPage(q, opts.Limit, opts.Cursor)
+
+	var page PullRequestPage
+	err := c.do(ctx, request{method: http.MethodGet, path: "/users/getReview", query: q, idempotent: true}, &page)
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// setPage adds pagination query parameters
+func setPage(q url.Values, limit int, cursor string) {
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+}
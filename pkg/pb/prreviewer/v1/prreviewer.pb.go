@@ -0,0 +1,2929 @@
+// gRPC api of the pr reviewer assigner, mirrors the http api described in openapi.yml.
+//
+// Every call except the health check needs a bearer token in the "authorization"
+// metadata, the organization is selected with "x-org-id" like the X-Org-ID header.
+// Failed calls carry a google.rpc.ErrorInfo detail whose reason is the http api
+// error code (PR_MERGED, NO_CANDIDATE, ...).
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: prreviewer/v1/prreviewer.proto
+
+package prreviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_ADMIN       Role = 1
+	Role_ROLE_MEMBER      Role = 2
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_ADMIN",
+		2: "ROLE_MEMBER",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_ADMIN":       1,
+		"ROLE_MEMBER":      2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreviewer_v1_prreviewer_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_prreviewer_v1_prreviewer_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{0}
+}
+
+type PRStatus int32
+
+const (
+	PRStatus_PR_STATUS_UNSPECIFIED PRStatus = 0
+	PRStatus_PR_STATUS_DRAFT       PRStatus = 1
+	PRStatus_PR_STATUS_OPEN        PRStatus = 2
+	PRStatus_PR_STATUS_MERGED      PRStatus = 3
+	PRStatus_PR_STATUS_CLOSED      PRStatus = 4
+)
+
+// Enum value maps for PRStatus.
+var (
+	PRStatus_name = map[int32]string{
+		0: "PR_STATUS_UNSPECIFIED",
+		1: "PR_STATUS_DRAFT",
+		2: "PR_STATUS_OPEN",
+		3: "PR_STATUS_MERGED",
+		4: "PR_STATUS_CLOSED",
+	}
+	PRStatus_value = map[string]int32{
+		"PR_STATUS_UNSPECIFIED": 0,
+		"PR_STATUS_DRAFT":       1,
+		"PR_STATUS_OPEN":        2,
+		"PR_STATUS_MERGED":      3,
+		"PR_STATUS_CLOSED":      4,
+	}
+)
+
+func (x PRStatus) Enum() *PRStatus {
+	p := new(PRStatus)
+	*p = x
+	return p
+}
+
+func (x PRStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PRStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreviewer_v1_prreviewer_proto_enumTypes[1].Descriptor()
+}
+
+func (PRStatus) Type() protoreflect.EnumType {
+	return &file_prreviewer_v1_prreviewer_proto_enumTypes[1]
+}
+
+func (x PRStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PRStatus.Descriptor instead.
+func (PRStatus) EnumDescriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{1}
+}
+
+type ReviewState int32
+
+const (
+	ReviewState_REVIEW_STATE_UNSPECIFIED       ReviewState = 0
+	ReviewState_REVIEW_STATE_PENDING           ReviewState = 1
+	ReviewState_REVIEW_STATE_APPROVED          ReviewState = 2
+	ReviewState_REVIEW_STATE_CHANGES_REQUESTED ReviewState = 3
+	ReviewState_REVIEW_STATE_COMMENTED         ReviewState = 4
+)
+
+// Enum value maps for ReviewState.
+var (
+	ReviewState_name = map[int32]string{
+		0: "REVIEW_STATE_UNSPECIFIED",
+		1: "REVIEW_STATE_PENDING",
+		2: "REVIEW_STATE_APPROVED",
+		3: "REVIEW_STATE_CHANGES_REQUESTED",
+		4: "REVIEW_STATE_COMMENTED",
+	}
+	ReviewState_value = map[string]int32{
+		"REVIEW_STATE_UNSPECIFIED":       0,
+		"REVIEW_STATE_PENDING":           1,
+		"REVIEW_STATE_APPROVED":          2,
+		"REVIEW_STATE_CHANGES_REQUESTED": 3,
+		"REVIEW_STATE_COMMENTED":         4,
+	}
+)
+
+func (x ReviewState) Enum() *ReviewState {
+	p := new(ReviewState)
+	*p = x
+	return p
+}
+
+func (x ReviewState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewState) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreviewer_v1_prreviewer_proto_enumTypes[2].Descriptor()
+}
+
+func (ReviewState) Type() protoreflect.EnumType {
+	return &file_prreviewer_v1_prreviewer_proto_enumTypes[2]
+}
+
+func (x ReviewState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewState.Descriptor instead.
+func (ReviewState) EnumDescriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{2}
+}
+
+type ReviewAction int32
+
+const (
+	ReviewAction_REVIEW_ACTION_UNSPECIFIED     ReviewAction = 0
+	ReviewAction_REVIEW_ACTION_APPROVE         ReviewAction = 1
+	ReviewAction_REVIEW_ACTION_REQUEST_CHANGES ReviewAction = 2
+	ReviewAction_REVIEW_ACTION_COMMENT         ReviewAction = 3
+)
+
+// Enum value maps for ReviewAction.
+var (
+	ReviewAction_name = map[int32]string{
+		0: "REVIEW_ACTION_UNSPECIFIED",
+		1: "REVIEW_ACTION_APPROVE",
+		2: "REVIEW_ACTION_REQUEST_CHANGES",
+		3: "REVIEW_ACTION_COMMENT",
+	}
+	ReviewAction_value = map[string]int32{
+		"REVIEW_ACTION_UNSPECIFIED":     0,
+		"REVIEW_ACTION_APPROVE":         1,
+		"REVIEW_ACTION_REQUEST_CHANGES": 2,
+		"REVIEW_ACTION_COMMENT":         3,
+	}
+)
+
+func (x ReviewAction) Enum() *ReviewAction {
+	p := new(ReviewAction)
+	*p = x
+	return p
+}
+
+func (x ReviewAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreviewer_v1_prreviewer_proto_enumTypes[3].Descriptor()
+}
+
+func (ReviewAction) Type() protoreflect.EnumType {
+	return &file_prreviewer_v1_prreviewer_proto_enumTypes[3]
+}
+
+func (x ReviewAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewAction.Descriptor instead.
+func (ReviewAction) EnumDescriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{3}
+}
+
+type AssignmentAction int32
+
+const (
+	AssignmentAction_ASSIGNMENT_ACTION_UNSPECIFIED AssignmentAction = 0
+	AssignmentAction_ASSIGNMENT_ACTION_ASSIGNED    AssignmentAction = 1
+	AssignmentAction_ASSIGNMENT_ACTION_UNASSIGNED  AssignmentAction = 2
+)
+
+// Enum value maps for AssignmentAction.
+var (
+	AssignmentAction_name = map[int32]string{
+		0: "ASSIGNMENT_ACTION_UNSPECIFIED",
+		1: "ASSIGNMENT_ACTION_ASSIGNED",
+		2: "ASSIGNMENT_ACTION_UNASSIGNED",
+	}
+	AssignmentAction_value = map[string]int32{
+		"ASSIGNMENT_ACTION_UNSPECIFIED": 0,
+		"ASSIGNMENT_ACTION_ASSIGNED":    1,
+		"ASSIGNMENT_ACTION_UNASSIGNED":  2,
+	}
+)
+
+func (x AssignmentAction) Enum() *AssignmentAction {
+	p := new(AssignmentAction)
+	*p = x
+	return p
+}
+
+func (x AssignmentAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignmentAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_prreviewer_v1_prreviewer_proto_enumTypes[4].Descriptor()
+}
+
+func (AssignmentAction) Type() protoreflect.EnumType {
+	return &file_prreviewer_v1_prreviewer_proto_enumTypes[4]
+}
+
+func (x AssignmentAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignmentAction.Descriptor instead.
+func (AssignmentAction) EnumDescriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{4}
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	TeamName      string                 `protobuf:"bytes,3,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IsActive      bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Role          Role                   `protobuf:"varint,5,opt,name=role,proto3,enum=prreviewer.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type TeamMember struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// defaults to ROLE_MEMBER
+	Role          Role `protobuf:"varint,4,opt,name=role,proto3,enum=prreviewer.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamMember) Reset() {
+	*x = TeamMember{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamMember) ProtoMessage() {}
+
+func (x *TeamMember) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamMember.ProtoReflect.Descriptor instead.
+func (*TeamMember) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TeamMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TeamMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TeamMember) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *TeamMember) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Members       []*TeamMember          `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*TeamMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type MergePolicy struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	MinApprovals            int32                  `protobuf:"varint,1,opt,name=min_approvals,json=minApprovals,proto3" json:"min_approvals,omitempty"`
+	RequireAllApproved      bool                   `protobuf:"varint,2,opt,name=require_all_approved,json=requireAllApproved,proto3" json:"require_all_approved,omitempty"`
+	BlockOnChangesRequested bool                   `protobuf:"varint,3,opt,name=block_on_changes_requested,json=blockOnChangesRequested,proto3" json:"block_on_changes_requested,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *MergePolicy) Reset() {
+	*x = MergePolicy{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePolicy) ProtoMessage() {}
+
+func (x *MergePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePolicy.ProtoReflect.Descriptor instead.
+func (*MergePolicy) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MergePolicy) GetMinApprovals() int32 {
+	if x != nil {
+		return x.MinApprovals
+	}
+	return 0
+}
+
+func (x *MergePolicy) GetRequireAllApproved() bool {
+	if x != nil {
+		return x.RequireAllApproved
+	}
+	return false
+}
+
+func (x *MergePolicy) GetBlockOnChangesRequested() bool {
+	if x != nil {
+		return x.BlockOnChangesRequested
+	}
+	return false
+}
+
+// TeamSettings holds per-team review policy, zero values disable the check
+type TeamSettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ReminderAfter *durationpb.Duration   `protobuf:"bytes,2,opt,name=reminder_after,json=reminderAfter,proto3" json:"reminder_after,omitempty"`
+	EscalateAfter *durationpb.Duration   `protobuf:"bytes,3,opt,name=escalate_after,json=escalateAfter,proto3" json:"escalate_after,omitempty"`
+	AutoReassign  bool                   `protobuf:"varint,4,opt,name=auto_reassign,json=autoReassign,proto3" json:"auto_reassign,omitempty"`
+	MergePolicy   *MergePolicy           `protobuf:"bytes,5,opt,name=merge_policy,json=mergePolicy,proto3" json:"merge_policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamSettings) Reset() {
+	*x = TeamSettings{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamSettings) ProtoMessage() {}
+
+func (x *TeamSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamSettings.ProtoReflect.Descriptor instead.
+func (*TeamSettings) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TeamSettings) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamSettings) GetReminderAfter() *durationpb.Duration {
+	if x != nil {
+		return x.ReminderAfter
+	}
+	return nil
+}
+
+func (x *TeamSettings) GetEscalateAfter() *durationpb.Duration {
+	if x != nil {
+		return x.EscalateAfter
+	}
+	return nil
+}
+
+func (x *TeamSettings) GetAutoReassign() bool {
+	if x != nil {
+		return x.AutoReassign
+	}
+	return false
+}
+
+func (x *TeamSettings) GetMergePolicy() *MergePolicy {
+	if x != nil {
+		return x.MergePolicy
+	}
+	return nil
+}
+
+type Review struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ReviewerId      string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	State           ReviewState            `protobuf:"varint,3,opt,name=state,proto3,enum=prreviewer.v1.ReviewState" json:"state,omitempty"`
+	AssignedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	FirstResponseAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=first_response_at,json=firstResponseAt,proto3" json:"first_response_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Review) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *Review) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *Review) GetState() ReviewState {
+	if x != nil {
+		return x.State
+	}
+	return ReviewState_REVIEW_STATE_UNSPECIFIED
+}
+
+func (x *Review) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *Review) GetFirstResponseAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstResponseAt
+	}
+	return nil
+}
+
+func (x *Review) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type AssignmentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewerId    string                 `protobuf:"bytes,1,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Action        AssignmentAction       `protobuf:"varint,2,opt,name=action,proto3,enum=prreviewer.v1.AssignmentAction" json:"action,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentEvent) Reset() {
+	*x = AssignmentEvent{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentEvent) ProtoMessage() {}
+
+func (x *AssignmentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentEvent.ProtoReflect.Descriptor instead.
+func (*AssignmentEvent) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AssignmentEvent) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *AssignmentEvent) GetAction() AssignmentAction {
+	if x != nil {
+		return x.Action
+	}
+	return AssignmentAction_ASSIGNMENT_ACTION_UNSPECIFIED
+}
+
+func (x *AssignmentEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AssignmentHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignments   int32                  `protobuf:"varint,1,opt,name=assignments,proto3" json:"assignments,omitempty"`
+	Unassignments int32                  `protobuf:"varint,2,opt,name=unassignments,proto3" json:"unassignments,omitempty"`
+	LastChangeAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_change_at,json=lastChangeAt,proto3" json:"last_change_at,omitempty"`
+	// last event of every reviewer in chronological order, the full log is not returned
+	Events        []*AssignmentEvent `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentHistory) Reset() {
+	*x = AssignmentHistory{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentHistory) ProtoMessage() {}
+
+func (x *AssignmentHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentHistory.ProtoReflect.Descriptor instead.
+func (*AssignmentHistory) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AssignmentHistory) GetAssignments() int32 {
+	if x != nil {
+		return x.Assignments
+	}
+	return 0
+}
+
+func (x *AssignmentHistory) GetUnassignments() int32 {
+	if x != nil {
+		return x.Unassignments
+	}
+	return 0
+}
+
+func (x *AssignmentHistory) GetLastChangeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastChangeAt
+	}
+	return nil
+}
+
+func (x *AssignmentHistory) GetEvents() []*AssignmentEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type PullRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          PRStatus               `protobuf:"varint,4,opt,name=status,proto3,enum=prreviewer.v1.PRStatus" json:"status,omitempty"`
+	// compared with expected_version of write calls
+	Version           int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,6,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	Reviews           []*Review              `protobuf:"bytes,7,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	ClosedAt          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	// set by Get only
+	AssignmentHistory *AssignmentHistory `protobuf:"bytes,11,opt,name=assignment_history,json=assignmentHistory,proto3" json:"assignment_history,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PRStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PRStatus_PR_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetAssignmentHistory() *AssignmentHistory {
+	if x != nil {
+		return x.AssignmentHistory
+	}
+	return nil
+}
+
+type AddTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamRequest) Reset() {
+	*x = AddTeamRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamRequest) ProtoMessage() {}
+
+func (x *AddTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddTeamRequest) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type AddTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamResponse) Reset() {
+	*x = AddTeamResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamResponse) ProtoMessage() {}
+
+func (x *AddTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type GetTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamResponse) Reset() {
+	*x = GetTeamResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamResponse) ProtoMessage() {}
+
+func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSettingsRequest) Reset() {
+	*x = GetSettingsRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingsRequest) ProtoMessage() {}
+
+func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetSettingsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+type GetSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *TeamSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSettingsResponse) Reset() {
+	*x = GetSettingsResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingsResponse) ProtoMessage() {}
+
+func (x *GetSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSettingsResponse) GetSettings() *TeamSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *TeamSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSettingsRequest) Reset() {
+	*x = UpdateSettingsRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateSettingsRequest) GetSettings() *TeamSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *TeamSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSettingsResponse) Reset() {
+	*x = UpdateSettingsResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateSettingsResponse) GetSettings() *TeamSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetIsActiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsActive      bool                   `protobuf:"varint,2,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveRequest) Reset() {
+	*x = SetIsActiveRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveRequest) ProtoMessage() {}
+
+func (x *SetIsActiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveRequest.ProtoReflect.Descriptor instead.
+func (*SetIsActiveRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetIsActiveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetIsActiveRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+type SetIsActiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIsActiveResponse) Reset() {
+	*x = SetIsActiveResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIsActiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIsActiveResponse) ProtoMessage() {}
+
+func (x *SetIsActiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIsActiveResponse.ProtoReflect.Descriptor instead.
+func (*SetIsActiveResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetIsActiveResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetReviewsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// defaults to PR_STATUS_OPEN
+	Statuses []PRStatus `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=prreviewer.v1.PRStatus" json:"statuses,omitempty"`
+	// only pull requests the user has not responded to
+	Pending bool  `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	Limit   int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page
+	Cursor        string `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewsRequest) Reset() {
+	*x = GetReviewsRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewsRequest) ProtoMessage() {}
+
+func (x *GetReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewsRequest) GetStatuses() []PRStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *GetReviewsRequest) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *GetReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReviewsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetReviewsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserId       string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PullRequests []*PullRequest         `protobuf:"bytes,2,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	// empty on the last page
+	NextCursor    string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewsResponse) Reset() {
+	*x = GetReviewsResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewsResponse) ProtoMessage() {}
+
+func (x *GetReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewsResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetReviewsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReviewsResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *GetReviewsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreateRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Draft           bool                   `protobuf:"varint,4,opt,name=draft,proto3" json:"draft,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+type CreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type MergeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Force           bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergeRequest) Reset() {
+	*x = MergeRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeRequest) ProtoMessage() {}
+
+func (x *MergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeRequest.ProtoReflect.Descriptor instead.
+func (*MergeRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MergeRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MergeRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *MergeRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type MergeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeResponse) Reset() {
+	*x = MergeResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeResponse) ProtoMessage() {}
+
+func (x *MergeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeResponse.ProtoReflect.Descriptor instead.
+func (*MergeResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MergeResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type MarkReadyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MarkReadyRequest) Reset() {
+	*x = MarkReadyRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadyRequest) ProtoMessage() {}
+
+func (x *MarkReadyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadyRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadyRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MarkReadyRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MarkReadyRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type MarkReadyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadyResponse) Reset() {
+	*x = MarkReadyResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadyResponse) ProtoMessage() {}
+
+func (x *MarkReadyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadyResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadyResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MarkReadyResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type CloseRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CloseRequest) Reset() {
+	*x = CloseRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseRequest) ProtoMessage() {}
+
+func (x *CloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseRequest.ProtoReflect.Descriptor instead.
+func (*CloseRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CloseRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CloseRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type CloseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseResponse) Reset() {
+	*x = CloseResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseResponse) ProtoMessage() {}
+
+func (x *CloseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseResponse.ProtoReflect.Descriptor instead.
+func (*CloseResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CloseResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type ReopenRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReopenRequest) Reset() {
+	*x = ReopenRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenRequest) ProtoMessage() {}
+
+func (x *ReopenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenRequest.ProtoReflect.Descriptor instead.
+func (*ReopenRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReopenRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReopenRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReopenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenResponse) Reset() {
+	*x = ReopenResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReopenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenResponse) ProtoMessage() {}
+
+func (x *ReopenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenResponse.ProtoReflect.Descriptor instead.
+func (*ReopenResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReopenResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+type ReassignRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	OldReviewerId   string                 `protobuf:"bytes,2,opt,name=old_reviewer_id,json=oldReviewerId,proto3" json:"old_reviewer_id,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReassignRequest) Reset() {
+	*x = ReassignRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignRequest) ProtoMessage() {}
+
+func (x *ReassignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignRequest.ProtoReflect.Descriptor instead.
+func (*ReassignRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReassignRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignRequest) GetOldReviewerId() string {
+	if x != nil {
+		return x.OldReviewerId
+	}
+	return ""
+}
+
+func (x *ReassignRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReassignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	ReplacedBy    string                 `protobuf:"bytes,2,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignResponse) Reset() {
+	*x = ReassignResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignResponse) ProtoMessage() {}
+
+func (x *ReassignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignResponse.ProtoReflect.Descriptor instead.
+func (*ReassignResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReassignResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+func (x *ReassignResponse) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+type SubmitReviewRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	ReviewerId      string                 `protobuf:"bytes,2,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Action          ReviewAction           `protobuf:"varint,3,opt,name=action,proto3,enum=prreviewer.v1.ReviewAction" json:"action,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SubmitReviewRequest) Reset() {
+	*x = SubmitReviewRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReviewRequest) ProtoMessage() {}
+
+func (x *SubmitReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReviewRequest.ProtoReflect.Descriptor instead.
+func (*SubmitReviewRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SubmitReviewRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *SubmitReviewRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *SubmitReviewRequest) GetAction() ReviewAction {
+	if x != nil {
+		return x.Action
+	}
+	return ReviewAction_REVIEW_ACTION_UNSPECIFIED
+}
+
+func (x *SubmitReviewRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type SubmitReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitReviewResponse) Reset() {
+	*x = SubmitReviewResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReviewResponse) ProtoMessage() {}
+
+func (x *SubmitReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReviewResponse.ProtoReflect.Descriptor instead.
+func (*SubmitReviewResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SubmitReviewResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Statuses   []PRStatus             `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=prreviewer.v1.PRStatus" json:"statuses,omitempty"`
+	AuthorId   string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	ReviewerId string                 `protobuf:"bytes,3,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// team of the author
+	TeamName    string                 `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	MergedFrom  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_from,json=mergedFrom,proto3" json:"merged_from,omitempty"`
+	MergedTo    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=merged_to,json=mergedTo,proto3" json:"merged_to,omitempty"`
+	// oldest first, newest first by default
+	Ascending bool  `protobuf:"varint,9,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	Limit     int32 `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page
+	Cursor        string `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListRequest) GetStatuses() []PRStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ListRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *ListRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListRequest) GetMergedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedFrom
+	}
+	return nil
+}
+
+func (x *ListRequest) GetMergedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedTo
+	}
+	return nil
+}
+
+func (x *ListRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests []*PullRequest         `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	// empty on the last page
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListResponse) GetPullRequests() []*PullRequest {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *ListResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type GetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pr            *PullRequest           `protobuf:"bytes,1,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_prreviewer_v1_prreviewer_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_prreviewer_v1_prreviewer_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetResponse) GetPr() *PullRequest {
+	if x != nil {
+		return x.Pr
+	}
+	return nil
+}
+
+var File_prreviewer_v1_prreviewer_proto protoreflect.FileDescriptor
+
+const file_prreviewer_v1_prreviewer_proto_rawDesc = "" +
+	"\n" +
+	"\x1eprreviewer/v1/prreviewer.proto\x12\rprreviewer.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x01\n" +
+	"\x04User\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tteam_name\x18\x03 \x01(\tR\bteamName\x12\x1b\n" +
+	"\tis_active\x18\x04 \x01(\bR\bisActive\x12'\n" +
+	"\x04role\x18\x05 \x01(\x0e2\x13.prreviewer.v1.RoleR\x04role\"\x87\x01\n" +
+	"\n" +
+	"TeamMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12'\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x13.prreviewer.v1.RoleR\x04role\"X\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x123\n" +
+	"\amembers\x18\x02 \x03(\v2\x19.prreviewer.v1.TeamMemberR\amembers\"\xa1\x01\n" +
+	"\vMergePolicy\x12#\n" +
+	"\rmin_approvals\x18\x01 \x01(\x05R\fminApprovals\x120\n" +
+	"\x14require_all_approved\x18\x02 \x01(\bR\x12requireAllApproved\x12;\n" +
+	"\x1ablock_on_changes_requested\x18\x03 \x01(\bR\x17blockOnChangesRequested\"\x93\x02\n" +
+	"\fTeamSettings\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12@\n" +
+	"\x0ereminder_after\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\rreminderAfter\x12@\n" +
+	"\x0eescalate_after\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\rescalateAfter\x12#\n" +
+	"\rauto_reassign\x18\x04 \x01(\bR\fautoReassign\x12=\n" +
+	"\fmerge_policy\x18\x05 \x01(\v2\x1a.prreviewer.v1.MergePolicyR\vmergePolicy\"\xc3\x02\n" +
+	"\x06Review\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x120\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1a.prreviewer.v1.ReviewStateR\x05state\x12;\n" +
+	"\vassigned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x12F\n" +
+	"\x11first_response_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0ffirstResponseAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa6\x01\n" +
+	"\x0fAssignmentEvent\x12\x1f\n" +
+	"\vreviewer_id\x18\x01 \x01(\tR\n" +
+	"reviewerId\x127\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x1f.prreviewer.v1.AssignmentActionR\x06action\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd5\x01\n" +
+	"\x11AssignmentHistory\x12 \n" +
+	"\vassignments\x18\x01 \x01(\x05R\vassignments\x12$\n" +
+	"\runassignments\x18\x02 \x01(\x05R\runassignments\x12@\n" +
+	"\x0elast_change_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\flastChangeAt\x126\n" +
+	"\x06events\x18\x04 \x03(\v2\x1e.prreviewer.v1.AssignmentEventR\x06events\"\xa7\x04\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12/\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x17.prreviewer.v1.PRStatusR\x06status\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12-\n" +
+	"\x12assigned_reviewers\x18\x06 \x03(\tR\x11assignedReviewers\x12/\n" +
+	"\areviews\x18\a \x03(\v2\x15.prreviewer.v1.ReviewR\areviews\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x127\n" +
+	"\tclosed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12O\n" +
+	"\x12assignment_history\x18\v \x01(\v2 .prreviewer.v1.AssignmentHistoryR\x11assignmentHistory\"9\n" +
+	"\x0eAddTeamRequest\x12'\n" +
+	"\x04team\x18\x01 \x01(\v2\x13.prreviewer.v1.TeamR\x04team\":\n" +
+	"\x0fAddTeamResponse\x12'\n" +
+	"\x04team\x18\x01 \x01(\v2\x13.prreviewer.v1.TeamR\x04team\"-\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\":\n" +
+	"\x0fGetTeamResponse\x12'\n" +
+	"\x04team\x18\x01 \x01(\v2\x13.prreviewer.v1.TeamR\x04team\"1\n" +
+	"\x12GetSettingsRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\"N\n" +
+	"\x13GetSettingsResponse\x127\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1b.prreviewer.v1.TeamSettingsR\bsettings\"P\n" +
+	"\x15UpdateSettingsRequest\x127\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1b.prreviewer.v1.TeamSettingsR\bsettings\"Q\n" +
+	"\x16UpdateSettingsResponse\x127\n" +
+	"\bsettings\x18\x01 \x01(\v2\x1b.prreviewer.v1.TeamSettingsR\bsettings\"J\n" +
+	"\x12SetIsActiveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\">\n" +
+	"\x13SetIsActiveResponse\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.prreviewer.v1.UserR\x04user\"\xa9\x01\n" +
+	"\x11GetReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x123\n" +
+	"\bstatuses\x18\x02 \x03(\x0e2\x17.prreviewer.v1.PRStatusR\bstatuses\x12\x18\n" +
+	"\apending\x18\x03 \x01(\bR\apending\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"\x8f\x01\n" +
+	"\x12GetReviewsResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12?\n" +
+	"\rpull_requests\x18\x02 \x03(\v2\x1a.prreviewer.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\x96\x01\n" +
+	"\rCreateRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x14\n" +
+	"\x05draft\x18\x04 \x01(\bR\x05draft\"<\n" +
+	"\x0eCreateResponse\x12*\n" +
+	"\x02pr\x18\x01 \x01(\v2\x1a.prreviewer.v1.PullRequestR\x02pr\"w\n" +
+	"\fMergeRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\";\n" +
+	"\rMergeResponse\x12*\n" +
+	"\x02pr\x18\x01 \x01(\v2\x1a.prreviewer.v1.PullRequestR\x02pr\"e\n" +
+	"\x10MarkReadyRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"?\n" +
+	"\x11MarkReadyResponse\x12*\n" +
+	"\x02pr\x18\x01 \x01(\v2\x1a.prreviewer.v1.PullRequestR\x02pr\"a\n" +
+	"\fCloseRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\";\n" +
+	"\rCloseResponse\x12*\n" +
+	"\x02pr\x18\x01 \x01(\v2\x1a.prreviewer.v1.PullRequestR\x02pr\"b\n" +
+	"\rReopenRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"<\n" +
+	"\x0eReopenResponse\x12*\n" +
+	"\x02pr\x18\x01 \x01(\v2\x1a.prreviewer.v1.PullRequestR\x02pr\"\x8c\x01\n" +
+	"\x0fReassignRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12&\n" +
+	"\x0fold_reviewer_id\x18\x02 \x01(\tR\roldReviewerId\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\"_\n" +
+	"\x10ReassignResponse\x12*\n" +
+	"\x02pr\x18\x01 \x01(\v2\x1a.prreviewer.v1.PullRequestR\x02pr\x12\x1f\n" +
+	"\vreplaced_by\x18\x02 \x01(\tR\n" +
+	"replacedBy\"\xbe\x01\n" +
+	"\x13SubmitReviewRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12\x1f\n" +
+	"\vreviewer_id\x18\x02 \x01(\tR\n" +
+	"reviewerId\x123\n" +
+	"\x06action\x18\x03 \x01(\x0e2\x1b.prreviewer.v1.ReviewActionR\x06action\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\x03R\x0fexpectedVersion\"E\n" +
+	"\x14SubmitReviewResponse\x12-\n" +
+	"\x06review\x18\x01 \x01(\v2\x15.prreviewer.v1.ReviewR\x06review\"\xd9\x03\n" +
+	"\vListRequest\x123\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x17.prreviewer.v1.PRStatusR\bstatuses\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x1f\n" +
+	"\vreviewer_id\x18\x03 \x01(\tR\n" +
+	"reviewerId\x12\x1b\n" +
+	"\tteam_name\x18\x04 \x01(\tR\bteamName\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12;\n" +
+	"\vmerged_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mergedFrom\x127\n" +
+	"\tmerged_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bmergedTo\x12\x1c\n" +
+	"\tascending\x18\t \x01(\bR\tascending\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\v \x01(\tR\x06cursor\"p\n" +
+	"\fListResponse\x12?\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x1a.prreviewer.v1.PullRequestR\fpullRequests\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"4\n" +
+	"\n" +
+	"GetRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\"9\n" +
+	"\vGetResponse\x12*\n" +
+	"\x02pr\x18\x01 \x01(\v2\x1a.prreviewer.v1.PullRequestR\x02pr*=\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ROLE_ADMIN\x10\x01\x12\x0f\n" +
+	"\vROLE_MEMBER\x10\x02*z\n" +
+	"\bPRStatus\x12\x19\n" +
+	"\x15PR_STATUS_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fPR_STATUS_DRAFT\x10\x01\x12\x12\n" +
+	"\x0ePR_STATUS_OPEN\x10\x02\x12\x14\n" +
+	"\x10PR_STATUS_MERGED\x10\x03\x12\x14\n" +
+	"\x10PR_STATUS_CLOSED\x10\x04*\xa0\x01\n" +
+	"\vReviewState\x12\x1c\n" +
+	"\x18REVIEW_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14REVIEW_STATE_PENDING\x10\x01\x12\x19\n" +
+	"\x15REVIEW_STATE_APPROVED\x10\x02\x12\"\n" +
+	"\x1eREVIEW_STATE_CHANGES_REQUESTED\x10\x03\x12\x1a\n" +
+	"\x16REVIEW_STATE_COMMENTED\x10\x04*\x86\x01\n" +
+	"\fReviewAction\x12\x1d\n" +
+	"\x19REVIEW_ACTION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15REVIEW_ACTION_APPROVE\x10\x01\x12!\n" +
+	"\x1dREVIEW_ACTION_REQUEST_CHANGES\x10\x02\x12\x19\n" +
+	"\x15REVIEW_ACTION_COMMENT\x10\x03*w\n" +
+	"\x10AssignmentAction\x12!\n" +
+	"\x1dASSIGNMENT_ACTION_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aASSIGNMENT_ACTION_ASSIGNED\x10\x01\x12 \n" +
+	"\x1cASSIGNMENT_ACTION_UNASSIGNED\x10\x022\xd6\x02\n" +
+	"\vTeamService\x12H\n" +
+	"\aAddTeam\x12\x1d.prreviewer.v1.AddTeamRequest\x1a\x1e.prreviewer.v1.AddTeamResponse\x12H\n" +
+	"\aGetTeam\x12\x1d.prreviewer.v1.GetTeamRequest\x1a\x1e.prreviewer.v1.GetTeamResponse\x12T\n" +
+	"\vGetSettings\x12!.prreviewer.v1.GetSettingsRequest\x1a\".prreviewer.v1.GetSettingsResponse\x12]\n" +
+	"\x0eUpdateSettings\x12$.prreviewer.v1.UpdateSettingsRequest\x1a%.prreviewer.v1.UpdateSettingsResponse2\xb6\x01\n" +
+	"\vUserService\x12T\n" +
+	"\vSetIsActive\x12!.prreviewer.v1.SetIsActiveRequest\x1a\".prreviewer.v1.SetIsActiveResponse\x12Q\n" +
+	"\n" +
+	"GetReviews\x12 .prreviewer.v1.GetReviewsRequest\x1a!.prreviewer.v1.GetReviewsResponse2\x96\x05\n" +
+	"\tPRService\x12E\n" +
+	"\x06Create\x12\x1c.prreviewer.v1.CreateRequest\x1a\x1d.prreviewer.v1.CreateResponse\x12B\n" +
+	"\x05Merge\x12\x1b.prreviewer.v1.MergeRequest\x1a\x1c.prreviewer.v1.MergeResponse\x12N\n" +
+	"\tMarkReady\x12\x1f.prreviewer.v1.MarkReadyRequest\x1a .prreviewer.v1.MarkReadyResponse\x12B\n" +
+	"\x05Close\x12\x1b.prreviewer.v1.CloseRequest\x1a\x1c.prreviewer.v1.CloseResponse\x12E\n" +
+	"\x06Reopen\x12\x1c.prreviewer.v1.ReopenRequest\x1a\x1d.prreviewer.v1.ReopenResponse\x12K\n" +
+	"\bReassign\x12\x1e.prreviewer.v1.ReassignRequest\x1a\x1f.prreviewer.v1.ReassignResponse\x12W\n" +
+	"\fSubmitReview\x12\".prreviewer.v1.SubmitReviewRequest\x1a#.prreviewer.v1.SubmitReviewResponse\x12?\n" +
+	"\x04List\x12\x1a.prreviewer.v1.ListRequest\x1a\x1b.prreviewer.v1.ListResponse\x12<\n" +
+	"\x03Get\x12\x19.prreviewer.v1.GetRequest\x1a\x1a.prreviewer.v1.GetResponseBQZOgithub.com/hryak228pizza/pr-reviewer-assigner/pkg/pb/prreviewer/v1;prreviewerv1b\x06proto3"
+
+var (
+	file_prreviewer_v1_prreviewer_proto_rawDescOnce sync.Once
+	file_prreviewer_v1_prreviewer_proto_rawDescData []byte
+)
+
+func file_prreviewer_v1_prreviewer_proto_rawDescGZIP() []byte {
+	file_prreviewer_v1_prreviewer_proto_rawDescOnce.Do(func() {
+		file_prreviewer_v1_prreviewer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_prreviewer_v1_prreviewer_proto_rawDesc), len(file_prreviewer_v1_prreviewer_proto_rawDesc)))
+	})
+	return file_prreviewer_v1_prreviewer_proto_rawDescData
+}
+
+var file_prreviewer_v1_prreviewer_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_prreviewer_v1_prreviewer_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_prreviewer_v1_prreviewer_proto_goTypes = []any{
+	(Role)(0),                      // 0: prreviewer.v1.Role
+	(PRStatus)(0),                  // 1: prreviewer.v1.PRStatus
+	(ReviewState)(0),               // 2: prreviewer.v1.ReviewState
+	(ReviewAction)(0),              // 3: prreviewer.v1.ReviewAction
+	(AssignmentAction)(0),          // 4: prreviewer.v1.AssignmentAction
+	(*User)(nil),                   // 5: prreviewer.v1.User
+	(*TeamMember)(nil),             // 6: prreviewer.v1.TeamMember
+	(*Team)(nil),                   // 7: prreviewer.v1.Team
+	(*MergePolicy)(nil),            // 8: prreviewer.v1.MergePolicy
+	(*TeamSettings)(nil),           // 9: prreviewer.v1.TeamSettings
+	(*Review)(nil),                 // 10: prreviewer.v1.Review
+	(*AssignmentEvent)(nil),        // 11: prreviewer.v1.AssignmentEvent
+	(*AssignmentHistory)(nil),      // 12: prreviewer.v1.AssignmentHistory
+	(*PullRequest)(nil),            // 13: prreviewer.v1.PullRequest
+	(*AddTeamRequest)(nil),         // 14: prreviewer.v1.AddTeamRequest
+	(*AddTeamResponse)(nil),        // 15: prreviewer.v1.AddTeamResponse
+	(*GetTeamRequest)(nil),         // 16: prreviewer.v1.GetTeamRequest
+	(*GetTeamResponse)(nil),        // 17: prreviewer.v1.GetTeamResponse
+	(*GetSettingsRequest)(nil),     // 18: prreviewer.v1.GetSettingsRequest
+	(*GetSettingsResponse)(nil),    // 19: prreviewer.v1.GetSettingsResponse
+	(*UpdateSettingsRequest)(nil),  // 20: prreviewer.v1.UpdateSettingsRequest
+	(*UpdateSettingsResponse)(nil), // 21: prreviewer.v1.UpdateSettingsResponse
+	(*SetIsActiveRequest)(nil),     // 22: prreviewer.v1.SetIsActiveRequest
+	(*SetIsActiveResponse)(nil),    // 23: prreviewer.v1.SetIsActiveResponse
+	(*GetReviewsRequest)(nil),      // 24: prreviewer.v1.GetReviewsRequest
+	(*GetReviewsResponse)(nil),     // 25: prreviewer.v1.GetReviewsResponse
+	(*CreateRequest)(nil),          // 26: prreviewer.v1.CreateRequest
+	(*CreateResponse)(nil),         // 27: prreviewer.v1.CreateResponse
+	(*MergeRequest)(nil),           // 28: prreviewer.v1.MergeRequest
+	(*MergeResponse)(nil),          // 29: prreviewer.v1.MergeResponse
+	(*MarkReadyRequest)(nil),       // 30: prreviewer.v1.MarkReadyRequest
+	(*MarkReadyResponse)(nil),      // 31: prreviewer.v1.MarkReadyResponse
+	(*CloseRequest)(nil),           // 32: prreviewer.v1.CloseRequest
+	(*CloseResponse)(nil),          // 33: prreviewer.v1.CloseResponse
+	(*ReopenRequest)(nil),          // 34: prreviewer.v1.ReopenRequest
+	(*ReopenResponse)(nil),         // 35: prreviewer.v1.ReopenResponse
+	(*ReassignRequest)(nil),        // 36: prreviewer.v1.ReassignRequest
+	(*ReassignResponse)(nil),       // 37: prreviewer.v1.ReassignResponse
+	(*SubmitReviewRequest)(nil),    // 38: prreviewer.v1.SubmitReviewRequest
+	(*SubmitReviewResponse)(nil),   // 39: prreviewer.v1.SubmitReviewResponse
+	(*ListRequest)(nil),            // 40: prreviewer.v1.ListRequest
+	(*ListResponse)(nil),           // 41: prreviewer.v1.ListResponse
+	(*GetRequest)(nil),             // 42: prreviewer.v1.GetRequest
+	(*GetResponse)(nil),            // 43: prreviewer.v1.GetResponse
+	(*durationpb.Duration)(nil),    // 44: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),  // 45: google.protobuf.Timestamp
+}
+var file_prreviewer_v1_prreviewer_proto_depIdxs = []int32{
+	0,  // 0: prreviewer.v1.User.role:type_name -> prreviewer.v1.Role
+	0,  // 1: prreviewer.v1.TeamMember.role:type_name -> prreviewer.v1.Role
+	6,  // 2: prreviewer.v1.Team.members:type_name -> prreviewer.v1.TeamMember
+	44, // 3: prreviewer.v1.TeamSettings.reminder_after:type_name -> google.protobuf.Duration
+	44, // 4: prreviewer.v1.TeamSettings.escalate_after:type_name -> google.protobuf.Duration
+	8,  // 5: prreviewer.v1.TeamSettings.merge_policy:type_name -> prreviewer.v1.MergePolicy
+	2,  // 6: prreviewer.v1.Review.state:type_name -> prreviewer.v1.ReviewState
+	45, // 7: prreviewer.v1.Review.assigned_at:type_name -> google.protobuf.Timestamp
+	45, // 8: prreviewer.v1.Review.first_response_at:type_name -> google.protobuf.Timestamp
+	45, // 9: prreviewer.v1.Review.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 10: prreviewer.v1.AssignmentEvent.action:type_name -> prreviewer.v1.AssignmentAction
+	45, // 11: prreviewer.v1.AssignmentEvent.created_at:type_name -> google.protobuf.Timestamp
+	45, // 12: prreviewer.v1.AssignmentHistory.last_change_at:type_name -> google.protobuf.Timestamp
+	11, // 13: prreviewer.v1.AssignmentHistory.events:type_name -> prreviewer.v1.AssignmentEvent
+	1,  // 14: prreviewer.v1.PullRequest.status:type_name -> prreviewer.v1.PRStatus
+	10, // 15: prreviewer.v1.PullRequest.reviews:type_name -> prreviewer.v1.Review
+	45, // 16: prreviewer.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	45, // 17: prreviewer.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	45, // 18: prreviewer.v1.PullRequest.closed_at:type_name -> google.protobuf.Timestamp
+	12, // 19: prreviewer.v1.PullRequest.assignment_history:type_name -> prreviewer.v1.AssignmentHistory
+	7,  // 20: prreviewer.v1.AddTeamRequest.team:type_name -> prreviewer.v1.Team
+	7,  // 21: prreviewer.v1.AddTeamResponse.team:type_name -> prreviewer.v1.Team
+	7,  // 22: prreviewer.v1.GetTeamResponse.team:type_name -> prreviewer.v1.Team
+	9,  // 23: prreviewer.v1.GetSettingsResponse.settings:type_name -> prreviewer.v1.TeamSettings
+	9,  // 24: prreviewer.v1.UpdateSettingsRequest.settings:type_name -> prreviewer.v1.TeamSettings
+	9,  // 25: prreviewer.v1.UpdateSettingsResponse.settings:type_name -> prreviewer.v1.TeamSettings
+	5,  // 26: prreviewer.v1.SetIsActiveResponse.user:type_name -> prreviewer.v1.User
+	1,  // 27: prreviewer.v1.GetReviewsRequest.statuses:type_name -> prreviewer.v1.PRStatus
+	13, // 28: prreviewer.v1.GetReviewsResponse.pull_requests:type_name -> prreviewer.v1.PullRequest
+	13, // 29: prreviewer.v1.CreateResponse.pr:type_name -> prreviewer.v1.PullRequest
+	13, // 30: prreviewer.v1.MergeResponse.pr:type_name -> prreviewer.v1.PullRequest
+	13, // 31: prreviewer.v1.MarkReadyResponse.pr:type_name -> prreviewer.v1.PullRequest
+	13, // 32: prreviewer.v1.CloseResponse.pr:type_name -> prreviewer.v1.PullRequest
+	13, // 33: prreviewer.v1.ReopenResponse.pr:type_name -> prreviewer.v1.PullRequest
+	13, // 34: prreviewer.v1.ReassignResponse.pr:type_name -> prreviewer.v1.PullRequest
+	3,  // 35: prreviewer.v1.SubmitReviewRequest.action:type_name -> prreviewer.v1.ReviewAction
+	10, // 36: prreviewer.v1.SubmitReviewResponse.review:type_name -> prreviewer.v1.Review
+	1,  // 37: prreviewer.v1.ListRequest.statuses:type_name -> prreviewer.v1.PRStatus
+	45, // 38: prreviewer.v1.ListRequest.created_from:type_name -> google.protobuf.Timestamp
+	45, // 39: prreviewer.v1.ListRequest.created_to:type_name -> google.protobuf.Timestamp
+	45, // 40: prreviewer.v1.ListRequest.merged_from:type_name -> google.protobuf.Timestamp
+	45, // 41: prreviewer.v1.ListRequest.merged_to:type_name -> google.protobuf.Timestamp
+	13, // 42: prreviewer.v1.ListResponse.pull_requests:type_name -> prreviewer.v1.PullRequest
+	13, // 43: prreviewer.v1.GetResponse.pr:type_name -> prreviewer.v1.PullRequest
+	14, // 44: prreviewer.v1.TeamService.AddTeam:input_type -> prreviewer.v1.AddTeamRequest
+	16, // 45: prreviewer.v1.TeamService.GetTeam:input_type -> prreviewer.v1.GetTeamRequest
+	18, // 46: prreviewer.v1.TeamService.GetSettings:input_type -> prreviewer.v1.GetSettingsRequest
+	20, // 47: prreviewer.v1.TeamService.UpdateSettings:input_type -> prreviewer.v1.UpdateSettingsRequest
+	22, // 48: prreviewer.v1.UserService.SetIsActive:input_type -> prreviewer.v1.SetIsActiveRequest
+	24, // 49: prreviewer.v1.UserService.GetReviews:input_type -> prreviewer.v1.GetReviewsRequest
+	26, // 50: prreviewer.v1.PRService.Create:input_type -> prreviewer.v1.CreateRequest
+	28, // 51: prreviewer.v1.PRService.Merge:input_type -> prreviewer.v1.MergeRequest
+	30, // 52: prreviewer.v1.PRService.MarkReady:input_type -> prreviewer.v1.MarkReadyRequest
+	32, // 53: prreviewer.v1.PRService.Close:input_type -> prreviewer.v1.CloseRequest
+	34, // 54: prreviewer.v1.PRService.Reopen:input_type -> prreviewer.v1.ReopenRequest
+	36, // 55: prreviewer.v1.PRService.Reassign:input_type -> prreviewer.v1.ReassignRequest
+	38, // 56: prreviewer.v1.PRService.SubmitReview:input_type -> prreviewer.v1.SubmitReviewRequest
+	40, // 57: prreviewer.v1.PRService.List:input_type -> prreviewer.v1.ListRequest
+	42, // 58: prreviewer.v1.PRService.Get:input_type -> prreviewer.v1.GetRequest
+	15, // 59: prreviewer.v1.TeamService.AddTeam:output_type -> prreviewer.v1.AddTeamResponse
+	17, // 60: prreviewer.v1.TeamService.GetTeam:output_type -> prreviewer.v1.GetTeamResponse
+	19, // 61: prreviewer.v1.TeamService.GetSettings:output_type -> prreviewer.v1.GetSettingsResponse
+	21, // 62: prreviewer.v1.TeamService.UpdateSettings:output_type -> prreviewer.v1.UpdateSettingsResponse
+	23, // 63: prreviewer.v1.UserService.SetIsActive:output_type -> prreviewer.v1.SetIsActiveResponse
+	25, // 64: prreviewer.v1.UserService.GetReviews:output_type -> prreviewer.v1.GetReviewsResponse
+	27, // 65: prreviewer.v1.PRService.Create:output_type -> prreviewer.v1.CreateResponse
+	29, // 66: prreviewer.v1.PRService.Merge:output_type -> prreviewer.v1.MergeResponse
+	31, // 67: prreviewer.v1.PRService.MarkReady:output_type -> prreviewer.v1.MarkReadyResponse
+	33, // 68: prreviewer.v1.PRService.Close:output_type -> prreviewer.v1.CloseResponse
+	35, // 69: prreviewer.v1.PRService.Reopen:output_type -> prreviewer.v1.ReopenResponse
+	37, // 70: prreviewer.v1.PRService.Reassign:output_type -> prreviewer.v1.ReassignResponse
+	39, // 71: prreviewer.v1.PRService.SubmitReview:output_type -> prreviewer.v1.SubmitReviewResponse
+	41, // 72: prreviewer.v1.PRService.List:output_type -> prreviewer.v1.ListResponse
+	43, // 73: prreviewer.v1.PRService.Get:output_type -> prreviewer.v1.GetResponse
+	59, // [59:74] is the sub-list for method output_type
+	44, // [44:59] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
+}
+
+func init() { file_prreviewer_v1_prreviewer_proto_init() }
+func file_prreviewer_v1_prreviewer_proto_init() {
+	if File_prreviewer_v1_prreviewer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_prreviewer_v1_prreviewer_proto_rawDesc), len(file_prreviewer_v1_prreviewer_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   39,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_prreviewer_v1_prreviewer_proto_goTypes,
+		DependencyIndexes: file_prreviewer_v1_prreviewer_proto_depIdxs,
+		EnumInfos:         file_prreviewer_v1_prreviewer_proto_enumTypes,
+		MessageInfos:      file_prreviewer_v1_prreviewer_proto_msgTypes,
+	}.Build()
+	File_prreviewer_v1_prreviewer_proto = out.File
+	file_prreviewer_v1_prreviewer_proto_goTypes = nil
+	file_prreviewer_v1_prreviewer_proto_depIdxs = nil
+}